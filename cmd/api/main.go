@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	_ "time/tzdata"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
@@ -14,6 +16,8 @@ import (
 	_ "github.com/lib/pq"
 
 	"github.com/tufee/desk-reservation-go/internal/api"
+	"github.com/tufee/desk-reservation-go/internal/middleware"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
 )
 
 func init() {
@@ -48,11 +52,22 @@ func init() {
 }
 
 func main() {
+	if err := pkg.ValidateSecretEncryptionKey(); err != nil {
+		log.Fatal("Error loading secret encryption key:", err)
+	}
+
+	keyManager, err := pkg.DefaultKeyManager()
+	if err != nil {
+		log.Fatal("Error loading JWT signing keys:", err)
+	}
+	keyManager.StartRotation(context.Background())
+	api.StartReservationRetention(context.Background())
+
 	router := api.SetupRoutes()
 
 	server := http.Server{
 		Addr:    ":8080",
-		Handler: router,
+		Handler: middleware.RequestMiddleware(router),
 	}
 
 	fmt.Println("Server listening on port 8080")
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/infra"
+	"github.com/tufee/desk-reservation-go/internal/infra/breach"
+	"github.com/tufee/desk-reservation-go/internal/infra/notification"
+	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
+	"github.com/tufee/desk-reservation-go/internal/service"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func RequestPasswordResetHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.RequestPasswordReset
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+
+	accountService, err := buildAccountTokenService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := accountService.RequestPasswordResetService(ctx, data); err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "If the email is registered, a password reset link has been sent",
+	})
+}
+
+func ResetPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.ResetPassword
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+
+	accountService, err := buildAccountTokenService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := accountService.ResetPasswordService(ctx, data); err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Password updated successfully",
+	})
+}
+
+func RequestEmailVerificationHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.RequestEmailVerification
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+
+	accountService, err := buildAccountTokenService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := accountService.SendEmailVerificationService(ctx, data); err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "If the email needs verification, a verification link has been sent",
+	})
+}
+
+func VerifyEmailHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.VerifyEmail
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+
+	accountService, err := buildAccountTokenService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := accountService.VerifyEmailService(ctx, data); err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Email verified successfully",
+	})
+}
+
+func buildAccountTokenService() (*service.AccountTokenService, error) {
+	db, err := infra.InitializeDB()
+	if err != nil {
+		return nil, err
+	}
+
+	accountService := &service.AccountTokenService{
+		UserRepository:      &repo.UserRepositoryDb{Conn: db.Conn},
+		UserTokenRepository: &repo.UserTokenRepositoryDb{Conn: db.Conn},
+		MailSender:          notification.NewMailSenderFromEnv(),
+		BaseURL:             os.Getenv("APP_BASE_URL"),
+		PasswordPolicy:      buildPasswordPolicy(),
+	}
+
+	if breached := breach.NewRangeStoreFromEnv(); breached != nil {
+		accountService.BreachedPasswords = breached
+	}
+
+	return accountService, nil
+}
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/infra"
+	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
+	"github.com/tufee/desk-reservation-go/internal/service"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const (
+	defaultPageSize = 25
+	maxPageSize     = 100
+)
+
+func ListAdminUsersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	page, pageSize, err := parsePageQuery(r)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	adminUserService, err := buildAdminUserService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	users, err := adminUserService.ListUsersService(ctx, domain.AdminUserQuery{
+		Query:    query.Get("q"),
+		Team:     query.Get("team"),
+		Role:     query.Get("role"),
+		Status:   query.Get("status"),
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(users)
+}
+
+func GetAdminUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	adminUserService, err := buildAdminUserService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	user, err := adminUserService.GetUserService(ctx, r.PathValue("id"))
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(user)
+}
+
+func UpdateAdminUserHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.UpdateAdminUser
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	actorId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	adminUserService, err := buildAdminUserService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	user, err := adminUserService.UpdateUserService(ctx, actorId, r.PathValue("id"), data)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(user)
+}
+
+func DeactivateAdminUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	actorId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	adminUserService, err := buildAdminUserService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	cancelled, err := adminUserService.DeactivateUserService(ctx, actorId, r.PathValue("id"))
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message":                "User deactivated successfully",
+		"cancelled_reservations": cancelled,
+	})
+}
+
+func ReactivateAdminUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	actorId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	adminUserService, err := buildAdminUserService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := adminUserService.ReactivateUserService(ctx, actorId, r.PathValue("id")); err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "User reactivated successfully",
+	})
+}
+
+func DeleteAdminUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	actorId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	adminUserService, err := buildAdminUserService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	cancelled, err := adminUserService.DeleteUserService(ctx, actorId, r.PathValue("id"))
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message":                "User deleted successfully",
+		"cancelled_reservations": cancelled,
+	})
+}
+
+func ListAdminUserReservationsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page, pageSize, err := parsePageQuery(r)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	adminUserService, err := buildAdminUserService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	reservations, err := adminUserService.ListUserReservationsService(ctx, r.PathValue("id"), page, pageSize)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reservations)
+}
+
+func parsePageQuery(r *http.Request) (int, int, error) {
+	query := r.URL.Query()
+	page, pageSize := 1, defaultPageSize
+
+	if value := query.Get("page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return 0, 0, pkg.NewBadRequestError("invalid page, expected a positive integer")
+		}
+		page = parsed
+	}
+
+	if value := query.Get("page_size"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxPageSize {
+			return 0, 0, pkg.NewBadRequestError("invalid page_size, expected 1 to 100")
+		}
+		pageSize = parsed
+	}
+
+	return page, pageSize, nil
+}
+
+func buildAdminUserService() (*service.AdminUserService, error) {
+	db, err := infra.InitializeDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.AdminUserService{
+		UserRepository:        &repo.UserRepositoryDb{Conn: db.Conn},
+		ReservationRepository: &repo.ReservationRepositoryDb{Conn: db.Conn},
+		AuditLogRepository:    &repo.AuditLogRepositoryDb{Conn: db.Conn},
+	}, nil
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/infra"
+	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
+	"github.com/tufee/desk-reservation-go/internal/service"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func CreateAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.CreateAPIKeyRequest
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	apiKeyService, err := buildAPIKeyService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	key, err := apiKeyService.CreateAPIKeyService(ctx, userId, data)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(key)
+}
+
+func ListAPIKeysHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	apiKeyService, err := buildAPIKeyService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	keys, err := apiKeyService.ListAPIKeysService(ctx, userId)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(keys)
+}
+
+func RevokeAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	apiKeyService, err := buildAPIKeyService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := apiKeyService.RevokeAPIKeyService(ctx, userId, r.PathValue("id")); err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "API key revoked successfully",
+	})
+}
+
+func buildAPIKeyService() (*service.APIKeyService, error) {
+	db, err := infra.InitializeDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.APIKeyService{
+		APIKeyRepository: &repo.APIKeyRepositoryDb{Conn: db.Conn},
+		UserRepository:   &repo.UserRepositoryDb{Conn: db.Conn},
+	}, nil
+}
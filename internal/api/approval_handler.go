@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/tufee/desk-reservation-go/internal/utils"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func ListPendingApprovalsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reservationService, err := buildReservationService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	reservations, err := reservationService.ListPendingApprovalsService(ctx)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"reservations": reservations,
+	})
+}
+
+func ApproveReservationHandler(w http.ResponseWriter, r *http.Request) {
+	reviewReservation(w, r, true, "Reservation approved successfully")
+}
+
+func RejectReservationHandler(w http.ResponseWriter, r *http.Request) {
+	reviewReservation(w, r, false, "Reservation rejected successfully")
+}
+
+func reviewReservation(w http.ResponseWriter, r *http.Request, approve bool, message string) {
+	ctx := r.Context()
+	reviewerId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	reservationService, err := buildReservationService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = reservationService.ReviewReservationService(ctx, reviewerId, r.PathValue("id"), approve)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": message,
+	})
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/infra"
+	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
+	"github.com/tufee/desk-reservation-go/internal/service"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func ListAuditLogsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	page, pageSize, err := parsePageQuery(r)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	from, err := parseTimeQuery(r, "from")
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	to, err := parseTimeQuery(r, "to")
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	db, err := infra.InitializeDB()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	auditService := service.AuditService{AuditLogRepository: &repo.AuditLogRepositoryDb{Conn: db.Conn}}
+
+	logs, err := auditService.ListAuditLogsService(ctx, domain.AuditLogQuery{
+		ActorId:    query.Get("actor_id"),
+		Action:     query.Get("action"),
+		TargetType: query.Get("target_type"),
+		TargetId:   query.Get("target_id"),
+		RequestId:  query.Get("request_id"),
+		From:       from,
+		To:         to,
+		Page:       page,
+		PageSize:   pageSize,
+	})
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(logs)
+}
+
+func parseTimeQuery(r *http.Request, key string) (*time.Time, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, pkg.NewBadRequestError("invalid " + key + ", expected RFC 3339 timestamp")
+	}
+
+	return &parsed, nil
+}
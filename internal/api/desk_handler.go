@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/infra"
+	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
+	"github.com/tufee/desk-reservation-go/internal/service"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func UpdateDeskApprovalHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.UpdateDeskApproval
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	db, err := infra.InitializeDB()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	deskService := service.DeskService{
+		DeskRepository:     &repo.DeskRepositoryDb{Conn: db.Conn},
+		AuditLogRepository: &repo.AuditLogRepositoryDb{Conn: db.Conn},
+	}
+
+	err = deskService.UpdateDeskApprovalService(ctx, userId, r.PathValue("id"), *data.RequiresApproval)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Desk updated successfully",
+	})
+}
+
+func UpdateZoneApprovalHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.UpdateZoneApproval
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	db, err := infra.InitializeDB()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	deskService := service.DeskService{
+		DeskRepository:     &repo.DeskRepositoryDb{Conn: db.Conn},
+		AuditLogRepository: &repo.AuditLogRepositoryDb{Conn: db.Conn},
+	}
+
+	err = deskService.UpdateZoneApprovalService(ctx, userId, r.PathValue("id"), *data.RequiresApproval)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Zone updated successfully",
+	})
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func CreateGuestReservationHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.CreateGuestReservation
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	hostId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	reservationService, err := buildReservationService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := reservationService.CreateGuestReservationService(ctx, hostId, data); err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Guest reservation created successfully",
+	})
+}
+
+func ListGuestVisitsHandler(w http.ResponseWriter, r *http.Request) {
+	date, err := parseDateQuery(r, "date")
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	ctx := r.Context()
+
+	reservationService, err := buildReservationService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	visits, err := reservationService.ListGuestVisitsService(ctx, date)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	response := map[string]any{"guests": visits}
+	if date != nil {
+		response["date"] = date.Format("2006-01-02")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseDateQuery returns nil when the query is absent so the repository can
+// fall back to each site's local day rather than the server's.
+func parseDateQuery(r *http.Request, key string) (*time.Time, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, pkg.NewBadRequestError("invalid " + key + ", expected YYYY-MM-DD")
+	}
+
+	return &date, nil
+}
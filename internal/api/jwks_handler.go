@@ -0,0 +1,21 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func JWKSHandler(w http.ResponseWriter, r *http.Request) {
+	keyManager, err := pkg.DefaultKeyManager()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(keyManager.JWKS())
+}
@@ -1,11 +1,13 @@
 package api
 
 import (
-	"context"
+	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/tufee/desk-reservation-go/internal/domain"
 	"github.com/tufee/desk-reservation-go/internal/infra"
+	"github.com/tufee/desk-reservation-go/internal/infra/ldap"
 	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
 	"github.com/tufee/desk-reservation-go/internal/service"
 	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
@@ -18,16 +20,29 @@ func LoginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	credentials = buildCredentialsFromRequest(credentials)
-	ctx := context.Background()
+	credentials = buildCredentialsFromRequest(r, credentials)
+	ctx := r.Context()
 
 	db, err := infra.InitializeDB()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	userRepository := &repo.UserRepositoryDb{Conn: db.Conn}
-	userService := service.LoginService{UserRepository: userRepository}
+	tokenRepository := &repo.TokenRepositoryDb{Conn: db.Conn}
+	userService := service.LoginService{
+		UserRepository:         userRepository,
+		TokenRepository:        tokenRepository,
+		MFARepository:          &repo.MFARepositoryDb{Conn: db.Conn},
+		LoginAttemptRepository: &repo.LoginAttemptRepositoryDb{Conn: db.Conn},
+		AuditLogRepository:     &repo.AuditLogRepositoryDb{Conn: db.Conn},
+		Throttle:               buildLoginThrottlePolicy(),
+	}
+
+	if directory := ldap.NewAuthenticatorFromEnv(); directory != nil {
+		userService.Directory = directory
+	}
 
 	token, err := userService.LoginService(ctx, credentials)
 	if err != nil {
@@ -37,12 +52,23 @@ func LoginHandler(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(`{"token": "` + token.Token + `"}`))
+	json.NewEncoder(w).Encode(token)
 }
 
-func buildCredentialsFromRequest(data domain.Credentials) domain.Credentials {
+func buildCredentialsFromRequest(r *http.Request, data domain.Credentials) domain.Credentials {
 	return domain.Credentials{
-		Email:    data.Email,
-		Password: data.Password,
+		Email:     data.Email,
+		Password:  data.Password,
+		IpAddress: pkg.ClientIP(r),
+	}
+}
+
+func buildLoginThrottlePolicy() domain.LoginThrottlePolicy {
+	return domain.LoginThrottlePolicy{
+		MaxAccountFailures: pkg.GetEnvInt("LOGIN_MAX_ACCOUNT_FAILURES", 5),
+		MaxIPFailures:      pkg.GetEnvInt("LOGIN_MAX_IP_FAILURES", 50),
+		Window:             time.Duration(pkg.GetEnvInt("LOGIN_FAILURE_WINDOW_MINUTES", 60)) * time.Minute,
+		BaseLockout:        time.Duration(pkg.GetEnvInt("LOGIN_BASE_LOCKOUT_SECONDS", 30)) * time.Second,
+		MaxLockout:         time.Duration(pkg.GetEnvInt("LOGIN_MAX_LOCKOUT_MINUTES", 60)) * time.Minute,
 	}
 }
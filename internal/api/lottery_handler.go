@@ -0,0 +1,124 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/infra"
+	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
+	"github.com/tufee/desk-reservation-go/internal/service"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func RequestLotteryHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.LotteryEntry
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	lotteryService, err := buildLotteryService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := lotteryService.RequestLotteryService(ctx, userId, r.PathValue("id"), data); err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Lottery request registered successfully",
+	})
+}
+
+func DrawLotteryHandler(w http.ResponseWriter, r *http.Request) {
+	date, err := parseDatePath(r, "date")
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	ctx := r.Context()
+	adminId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	lotteryService, err := buildLotteryService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	draw, err := lotteryService.DrawLotteryService(ctx, adminId, r.PathValue("id"), date)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]any{
+		"site_id": draw.SiteId,
+		"date":    draw.Date.Format("2006-01-02"),
+		"seed":    draw.Seed,
+		"results": draw.Outcomes,
+	})
+}
+
+func GetLotteryDrawHandler(w http.ResponseWriter, r *http.Request) {
+	date, err := parseDatePath(r, "date")
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	ctx := r.Context()
+
+	lotteryService, err := buildLotteryService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	draw, err := lotteryService.GetLotteryDrawService(ctx, r.PathValue("id"), date)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(draw)
+}
+
+func buildLotteryService() (*service.LotteryService, error) {
+	db, err := infra.InitializeDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.LotteryService{
+		LotteryRepository:  &repo.LotteryRepositoryDb{Conn: db.Conn},
+		SiteRepository:     &repo.SiteRepositoryDb{Conn: db.Conn},
+		DeskRepository:     &repo.DeskRepositoryDb{Conn: db.Conn},
+		AuditLogRepository: &repo.AuditLogRepositoryDb{Conn: db.Conn},
+		Policy:             buildReservationPolicy(),
+	}, nil
+}
+
+func parseDatePath(r *http.Request, key string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", r.PathValue(key))
+	if err != nil {
+		return time.Time{}, pkg.NewBadRequestError("invalid " + key + ", expected YYYY-MM-DD")
+	}
+
+	return date, nil
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/infra"
+	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
+	"github.com/tufee/desk-reservation-go/internal/service"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func EnrollMFAHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	mfaService, err := buildMFAService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	enrollment, err := mfaService.EnrollMFAService(ctx, userId)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(enrollment)
+}
+
+func ConfirmMFAHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.ConfirmMFA
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	mfaService, err := buildMFAService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	codes, err := mfaService.ConfirmMFAService(ctx, userId, data)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(codes)
+}
+
+func VerifyMFALoginHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.VerifyMFALogin
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	data.IpAddress = pkg.ClientIP(r)
+	ctx := r.Context()
+
+	mfaService, err := buildMFAService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	token, err := mfaService.VerifyMFALoginService(ctx, data)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(token)
+}
+
+func ResetMFAHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	adminId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	mfaService, err := buildMFAService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := mfaService.ResetMFAService(ctx, adminId, r.PathValue("id")); err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Two-factor authentication reset successfully",
+	})
+}
+
+func buildMFAService() (*service.MFAService, error) {
+	db, err := infra.InitializeDB()
+	if err != nil {
+		return nil, err
+	}
+
+	issuer := os.Getenv("MFA_ISSUER")
+	if issuer == "" {
+		issuer = "Desk Reservation"
+	}
+
+	return &service.MFAService{
+		MFARepository:          &repo.MFARepositoryDb{Conn: db.Conn},
+		UserRepository:         &repo.UserRepositoryDb{Conn: db.Conn},
+		TokenRepository:        &repo.TokenRepositoryDb{Conn: db.Conn},
+		LoginAttemptRepository: &repo.LoginAttemptRepositoryDb{Conn: db.Conn},
+		AuditLogRepository:     &repo.AuditLogRepositoryDb{Conn: db.Conn},
+		Throttle:               buildLoginThrottlePolicy(),
+		Issuer:                 issuer,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/infra"
+	"github.com/tufee/desk-reservation-go/internal/infra/oidc"
+	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
+	"github.com/tufee/desk-reservation-go/internal/service"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const (
+	oidcLoginCookie     = "oidc_login"
+	oidcLoginCookiePath = "/auth/oidc"
+	oidcLoginCookieTTL  = 600
+)
+
+func OIDCLoginHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	provider, err := oidc.DefaultProvider(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	oidcService := service.OIDCService{Provider: provider}
+
+	request, err := oidcService.StartOIDCLoginService()
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcLoginCookie,
+		Value:    strings.Join([]string{request.State, request.Nonce, request.Verifier}, "."),
+		Path:     oidcLoginCookiePath,
+		MaxAge:   oidcLoginCookieTTL,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, request.URL, http.StatusFound)
+}
+
+func OIDCCallbackHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	data := domain.OIDCCallback{
+		Code:  r.URL.Query().Get("code"),
+		State: r.URL.Query().Get("state"),
+	}
+
+	if cookie, err := r.Cookie(oidcLoginCookie); err == nil {
+		if parts := strings.Split(cookie.Value, "."); len(parts) == 3 {
+			data.ExpectedState, data.Nonce, data.Verifier = parts[0], parts[1], parts[2]
+		}
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcLoginCookie,
+		Path:     oidcLoginCookiePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	if errorCode := r.URL.Query().Get("error"); errorCode != "" {
+		pkg.HandleHTTPError(w, pkg.NewUnauthorizedError("OIDC login failed: "+errorCode))
+		return
+	}
+
+	provider, err := oidc.DefaultProvider(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	db, err := infra.InitializeDB()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	oidcService := service.OIDCService{
+		UserRepository:         &repo.UserRepositoryDb{Conn: db.Conn},
+		UserIdentityRepository: &repo.UserIdentityRepositoryDb{Conn: db.Conn},
+		TokenRepository:        &repo.TokenRepositoryDb{Conn: db.Conn},
+		Provider:               provider,
+	}
+
+	token, err := oidcService.CompleteOIDCLoginService(ctx, data)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(token)
+}
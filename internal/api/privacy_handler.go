@@ -0,0 +1,298 @@
+package api
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/infra"
+	"github.com/tufee/desk-reservation-go/internal/infra/notification"
+	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
+	"github.com/tufee/desk-reservation-go/internal/service"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const retentionPurgeInterval = 24 * time.Hour
+
+func ExportUserDataHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "zip" && format != "json" {
+		pkg.HandleHTTPError(w, pkg.NewBadRequestError("invalid format, expected zip or json"))
+		return
+	}
+
+	privacyService, err := buildPrivacyService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	export, err := privacyService.ExportUserDataService(ctx, userId)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	if format == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", `attachment; filename="user-data.json"`)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(export)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="user-data.zip"`)
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeUserDataZip(w, export); err != nil {
+		pkg.GetLogger().Error("Error writing data export archive: %v", err)
+	}
+}
+
+func EraseAccountHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.EraseAccount
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	privacyService, err := buildPrivacyService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	erased, err := privacyService.EraseAccountService(ctx, userId, data)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !erased {
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]any{
+			"message": "Check your email to confirm the erasure",
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Account erased successfully",
+	})
+}
+
+func ConfirmAccountErasureHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.ConfirmAccountErasure
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	privacyService, err := buildPrivacyService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := privacyService.ConfirmAccountErasureService(ctx, userId, data); err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Account erased successfully",
+	})
+}
+
+func EraseAdminUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	actorId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	privacyService, err := buildPrivacyService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := privacyService.EraseUserService(ctx, actorId, r.PathValue("id")); err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "User erased successfully",
+	})
+}
+
+func PurgeExpiredReservationsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	privacyService, err := buildPrivacyService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	adminId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	purge, err := privacyService.PurgeExpiredReservationsService(ctx, adminId, time.Now())
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(purge)
+}
+
+func GetReservationRetentionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	privacyService, err := buildPrivacyService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	retention, err := privacyService.GetReservationRetentionService(ctx)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(retention)
+}
+
+func UpdateReservationRetentionHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.UpdateReservationRetention
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	adminId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	privacyService, err := buildPrivacyService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	retention, err := privacyService.UpdateReservationRetentionService(ctx, adminId, data)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(retention)
+}
+
+// Organisations can enable retention on their own, so the job runs even when
+// the server-wide default keeps reservations forever.
+func StartReservationRetention(ctx context.Context) {
+	privacyService, err := buildPrivacyService()
+	if err != nil {
+		pkg.GetLogger().Error("Failed to start reservation retention purge: %v", err)
+		return
+	}
+
+	ticker := time.NewTicker(retentionPurgeInterval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				privacyService.PurgeAllExpiredReservationsService(ctx, time.Now())
+			}
+		}
+	}()
+}
+
+func writeUserDataZip(w http.ResponseWriter, export *domain.UserDataExport) error {
+	archive := zip.NewWriter(w)
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", export.Profile},
+		{"reservations.json", export.Reservations},
+		{"audit_logs.json", export.AuditLogs},
+	}
+
+	for _, file := range files {
+		entry, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: export.ExportedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(entry)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func buildRetentionPolicy() domain.RetentionPolicy {
+	days := pkg.GetEnvInt("RESERVATION_RETENTION_DAYS", 0)
+
+	return domain.RetentionPolicy{
+		ReservationRetention: time.Duration(days) * 24 * time.Hour,
+	}
+}
+
+func buildPrivacyService() (*service.PrivacyService, error) {
+	db, err := infra.InitializeDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.PrivacyService{
+		UserRepository:         &repo.UserRepositoryDb{Conn: db.Conn},
+		ReservationRepository:  &repo.ReservationRepositoryDb{Conn: db.Conn},
+		AuditLogRepository:     &repo.AuditLogRepositoryDb{Conn: db.Conn},
+		OrganisationRepository: &repo.OrganisationRepositoryDb{Conn: db.Conn},
+		UserTokenRepository:    &repo.UserTokenRepositoryDb{Conn: db.Conn},
+		MailSender:             notification.NewMailSenderFromEnv(),
+		BaseURL:                os.Getenv("APP_BASE_URL"),
+		Retention:              buildRetentionPolicy(),
+	}, nil
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/infra"
+	"github.com/tufee/desk-reservation-go/internal/infra/breach"
+	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
+	"github.com/tufee/desk-reservation-go/internal/service"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func GetProfileHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	profileService, err := buildProfileService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	user, err := profileService.GetProfileService(ctx, userId)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(user)
+}
+
+func UpdateProfileHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.UpdateProfile
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	profileService, err := buildProfileService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	user, err := profileService.UpdateProfileService(ctx, userId, data)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(user)
+}
+
+func ChangePasswordHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.ChangePassword
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	profileService, err := buildProfileService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := profileService.ChangePasswordService(ctx, userId, data); err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Password changed successfully",
+	})
+}
+
+func buildProfileService() (*service.ProfileService, error) {
+	db, err := infra.InitializeDB()
+	if err != nil {
+		return nil, err
+	}
+
+	profileService := &service.ProfileService{
+		UserRepository: &repo.UserRepositoryDb{Conn: db.Conn},
+		SiteRepository: &repo.SiteRepositoryDb{Conn: db.Conn},
+		DeskRepository: &repo.DeskRepositoryDb{Conn: db.Conn},
+		PasswordPolicy: buildPasswordPolicy(),
+	}
+
+	if breached := breach.NewRangeStoreFromEnv(); breached != nil {
+		profileService.BreachedPasswords = breached
+	}
+
+	return profileService, nil
+}
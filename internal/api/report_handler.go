@@ -0,0 +1,220 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/infra"
+	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
+	"github.com/tufee/desk-reservation-go/internal/service"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const defaultReportDays = 30
+
+func OccupancyReportHandler(w http.ResponseWriter, r *http.Request) {
+	query, reportService, ok := prepareReport(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := reportService.OccupancyReportService(r.Context(), query)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	header := []string{"period", "group_id", "group_name", "desks", "desk_days", "booked_desk_days", "occupancy"}
+	writeReport(w, r, "occupancy", rows, header, func(row domain.OccupancyRow) []string {
+		return append(
+			reportGroupRecord(row.Period, row.GroupId, row.GroupName),
+			strconv.Itoa(row.Desks),
+			strconv.Itoa(row.DeskDays),
+			strconv.Itoa(row.BookedDeskDays),
+			formatRatio(row.Occupancy),
+		)
+	})
+}
+
+func CheckInReportHandler(w http.ResponseWriter, r *http.Request) {
+	query, reportService, ok := prepareReport(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := reportService.CheckInReportService(r.Context(), query)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	header := []string{
+		"period", "group_id", "group_name", "bookings", "check_ins", "no_shows", "check_in_rate", "no_show_rate",
+	}
+	writeReport(w, r, "check-ins", rows, header, func(row domain.CheckInRateRow) []string {
+		return append(
+			reportGroupRecord(row.Period, row.GroupId, row.GroupName),
+			strconv.Itoa(row.Bookings),
+			strconv.Itoa(row.CheckIns),
+			strconv.Itoa(row.NoShows),
+			formatRatio(row.CheckInRate),
+			formatRatio(row.NoShowRate),
+		)
+	})
+}
+
+func WeekdayReportHandler(w http.ResponseWriter, r *http.Request) {
+	query, reportService, ok := prepareReport(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := reportService.WeekdayReportService(r.Context(), query)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	header := []string{"weekday", "name", "days", "bookings", "average_bookings"}
+	writeReport(w, r, "weekdays", rows, header, func(row domain.WeekdayPeakRow) []string {
+		return []string{
+			strconv.Itoa(row.Weekday),
+			row.Name,
+			strconv.Itoa(row.Days),
+			strconv.Itoa(row.Bookings),
+			formatRatio(row.AverageBookings),
+		}
+	})
+}
+
+func LeadTimeReportHandler(w http.ResponseWriter, r *http.Request) {
+	query, reportService, ok := prepareReport(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := reportService.LeadTimeReportService(r.Context(), query)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	header := []string{"label", "min_days", "max_days", "bookings", "share"}
+	writeReport(w, r, "lead-times", rows, header, func(row domain.LeadTimeRow) []string {
+		maxDays := ""
+		if row.MaxDays != nil {
+			maxDays = strconv.Itoa(*row.MaxDays)
+		}
+
+		return []string{
+			row.Label,
+			strconv.Itoa(row.MinDays),
+			maxDays,
+			strconv.Itoa(row.Bookings),
+			formatRatio(row.Share),
+		}
+	})
+}
+
+func prepareReport(w http.ResponseWriter, r *http.Request) (domain.ReportQuery, *service.ReportService, bool) {
+	query, err := parseReportQuery(r)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return query, nil, false
+	}
+
+	db, err := infra.InitializeDB()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return query, nil, false
+	}
+
+	return query, &service.ReportService{ReportRepository: &repo.ReportRepositoryDb{Conn: db.Conn}}, true
+}
+
+func parseReportQuery(r *http.Request) (domain.ReportQuery, error) {
+	values := r.URL.Query()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	to, err := parseReportDate(values.Get("to"), "to", today)
+	if err != nil {
+		return domain.ReportQuery{}, err
+	}
+
+	from, err := parseReportDate(values.Get("from"), "from", to.AddDate(0, 0, 1-defaultReportDays))
+	if err != nil {
+		return domain.ReportQuery{}, err
+	}
+
+	return domain.ReportQuery{
+		From:    from,
+		To:      to,
+		SiteId:  values.Get("site_id"),
+		GroupBy: values.Get("group_by"),
+		Period:  values.Get("period"),
+	}, nil
+}
+
+func parseReportDate(value string, key string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, pkg.NewBadRequestError("invalid " + key + ", expected YYYY-MM-DD")
+	}
+
+	return parsed, nil
+}
+
+func writeReport[T any](
+	w http.ResponseWriter,
+	r *http.Request,
+	name string,
+	rows []T,
+	header []string,
+	record func(T) []string,
+) {
+	if r.URL.Query().Get("format") != "csv" && !strings.Contains(r.Header.Get("Accept"), "text/csv") {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(rows)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".csv"))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write(header)
+	for _, row := range rows {
+		writer.Write(record(row))
+	}
+	writer.Flush()
+}
+
+func reportGroupRecord(period time.Time, groupId *string, groupName *string) []string {
+	record := []string{period.Format(time.DateOnly), "", ""}
+
+	if groupId != nil {
+		record[1] = *groupId
+	}
+
+	if groupName != nil {
+		record[2] = *groupName
+	}
+
+	return record
+}
+
+func formatRatio(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
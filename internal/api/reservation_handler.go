@@ -1,14 +1,15 @@
 package api
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
 
 	"github.com/tufee/desk-reservation-go/internal/domain"
 	"github.com/tufee/desk-reservation-go/internal/infra"
+	"github.com/tufee/desk-reservation-go/internal/infra/notification"
 	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
 	"github.com/tufee/desk-reservation-go/internal/service"
+	"github.com/tufee/desk-reservation-go/internal/utils"
 	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
 )
 
@@ -19,18 +20,17 @@ func CreateReservationHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	reservation := buildReservationFromRequest(data)
-	ctx := context.Background()
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+	reservation := buildReservationFromRequest(data, userId)
 
-	db, err := infra.InitializeDB()
+	reservationService, err := buildReservationService()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	reservationRepository := &repo.ReservationRepositoryDb{Conn: db.Conn}
-	reservationService := service.ReservationService{ReservationRepository: reservationRepository}
-
-	if err := reservationService.CreateReservationService(ctx, reservation); err != nil {
+	if err := reservationService.CreateReservationService(ctx, userId, reservation); err != nil {
 		pkg.HandleHTTPError(w, err)
 		return
 	}
@@ -42,10 +42,163 @@ func CreateReservationHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func buildReservationFromRequest(data domain.CreateReservation) domain.CreateReservation {
+func CreateReservationRangeHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.CreateReservationRange
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	reservationService, err := buildReservationService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := reservationService.CreateReservationRangeService(ctx, userId, data); err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Reservations created successfully",
+	})
+}
+
+func GetReservationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+	role, _ := utils.GetContextValue[string](ctx, utils.AuthRoleKey)
+
+	reservationService, err := buildReservationService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	reservation, err := reservationService.GetReservationService(ctx, userId, role, r.PathValue("id"))
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reservation)
+}
+
+func UpdateReservationHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.UpdateReservation
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+	role, _ := utils.GetContextValue[string](ctx, utils.AuthRoleKey)
+
+	reservationService, err := buildReservationService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = reservationService.UpdateReservationService(ctx, userId, role, r.PathValue("id"), data)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Reservation updated successfully",
+	})
+}
+
+func CancelReservationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+	role, _ := utils.GetContextValue[string](ctx, utils.AuthRoleKey)
+
+	reservationService, err := buildReservationService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = reservationService.CancelReservationService(ctx, userId, role, r.PathValue("id"))
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Reservation cancelled successfully",
+	})
+}
+
+func CheckInReservationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+
+	reservationService, err := buildReservationService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = reservationService.CheckInReservationService(ctx, userId, r.PathValue("id"))
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Reservation checked in successfully",
+	})
+}
+
+// Reservations are always booked for the authenticated caller.
+func buildReservationFromRequest(data domain.CreateReservation, userId string) domain.CreateReservation {
 	return domain.CreateReservation{
 		DeskId: data.DeskId,
-		UserId: data.UserId,
+		UserId: userId,
 		Date:   data.Date,
 	}
 }
+
+func buildReservationService() (*service.ReservationService, error) {
+	db, err := infra.InitializeDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.ReservationService{
+		ReservationRepository: &repo.ReservationRepositoryDb{Conn: db.Conn},
+		GuestRepository:       &repo.GuestRepositoryDb{Conn: db.Conn},
+		SwapOfferRepository:   &repo.SwapOfferRepositoryDb{Conn: db.Conn},
+		UserRepository:        &repo.UserRepositoryDb{Conn: db.Conn},
+		DeskRepository:        &repo.DeskRepositoryDb{Conn: db.Conn},
+		LotteryRepository:     &repo.LotteryRepositoryDb{Conn: db.Conn},
+		AuditLogRepository:    &repo.AuditLogRepositoryDb{Conn: db.Conn},
+		Notifier:              &notification.LogNotifier{},
+		Policy:                buildReservationPolicy(),
+	}, nil
+}
+
+func buildReservationPolicy() domain.ReservationPolicy {
+	return domain.ReservationPolicy{
+		MaxReservationsPerDay: pkg.GetEnvInt("MAX_RESERVATIONS_PER_DAY", 0),
+	}
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func TestBuildReservationFromRequest(t *testing.T) {
+	t.Run("should ignore a forged user_id and book for the caller", func(t *testing.T) {
+		body := `{"desk_id":"desk-1","user_id":"someone-else","date":"2025-07-08T00:00:00Z"}`
+		r := httptest.NewRequest("POST", "/reservation", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		var data domain.CreateReservation
+		if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+			t.Fatalf("expected request to be valid, got %v", err)
+		}
+
+		reservation := buildReservationFromRequest(data, "caller")
+
+		if reservation.UserId != "caller" {
+			t.Errorf("expected reservation for caller, got %s", reservation.UserId)
+		}
+		if reservation.DeskId != "desk-1" {
+			t.Errorf("expected desk-1, got %s", reservation.DeskId)
+		}
+	})
+}
@@ -3,18 +3,145 @@ package api
 import (
 	"net/http"
 
+	"github.com/tufee/desk-reservation-go/internal/domain"
 	"github.com/tufee/desk-reservation-go/internal/middleware"
 )
 
 func SetupRoutes() *http.ServeMux {
 	mux := http.NewServeMux()
 
+	writeReservations := domain.ScopeWriteReservations
+	readReservations := []string{domain.ScopeReadReservations, domain.ScopeWriteReservations}
+
 	fs := http.FileServer(http.Dir("web/static"))
 	mux.Handle("/static/", http.StripPrefix("/static/", fs))
 	mux.HandleFunc("GET /home", Home)
 
-	mux.HandleFunc("POST /user", CreateUserHandler)
-	mux.HandleFunc("POST /reservation", middleware.AuthMiddleware(CreateReservationHandler))
-	mux.HandleFunc("POST /login", LoginHandler)
+	mux.HandleFunc("POST /user", middleware.SystemScopeMiddleware(CreateUserHandler))
+	mux.HandleFunc("GET /users/me", middleware.AuthMiddleware(GetProfileHandler))
+	mux.HandleFunc("PATCH /users/me", middleware.AuthMiddleware(UpdateProfileHandler))
+	mux.HandleFunc("POST /users/me/password", middleware.AuthMiddleware(ChangePasswordHandler))
+	mux.HandleFunc("GET /users/me/export", middleware.AuthMiddleware(ExportUserDataHandler))
+	mux.HandleFunc("POST /users/me/erase", middleware.AuthMiddleware(EraseAccountHandler))
+	mux.HandleFunc("POST /users/me/erase/confirm", middleware.AuthMiddleware(ConfirmAccountErasureHandler))
+	mux.HandleFunc("POST /password/forgot", middleware.SystemScopeMiddleware(RequestPasswordResetHandler))
+	mux.HandleFunc("POST /password/reset", middleware.SystemScopeMiddleware(ResetPasswordHandler))
+	mux.HandleFunc("POST /email/verification", middleware.SystemScopeMiddleware(RequestEmailVerificationHandler))
+	mux.HandleFunc("POST /email/verify", middleware.SystemScopeMiddleware(VerifyEmailHandler))
+	mux.HandleFunc("POST /reservation", middleware.AuthMiddleware(CreateReservationHandler, writeReservations))
+	mux.HandleFunc("POST /reservation/range", middleware.AuthMiddleware(CreateReservationRangeHandler, writeReservations))
+	mux.HandleFunc("POST /reservation/guest", middleware.AuthMiddleware(CreateGuestReservationHandler, writeReservations))
+	mux.HandleFunc("GET /reservation/{id}", middleware.AuthMiddleware(GetReservationHandler, readReservations...))
+	mux.HandleFunc("PATCH /reservation/{id}", middleware.AuthMiddleware(UpdateReservationHandler, writeReservations))
+	mux.HandleFunc("POST /reservation/{id}/transfer", middleware.AuthMiddleware(
+		TransferReservationHandler,
+		writeReservations,
+	))
+	mux.HandleFunc("POST /reservation/{id}/cancel", middleware.AuthMiddleware(CancelReservationHandler, writeReservations))
+	mux.HandleFunc("POST /reservation/{id}/check-in", middleware.AuthMiddleware(
+		CheckInReservationHandler,
+		writeReservations,
+	))
+	mux.HandleFunc("POST /reservation/{id}/swap", middleware.AuthMiddleware(ProposeSwapHandler, writeReservations))
+	mux.HandleFunc("GET /swap-offers", middleware.AuthMiddleware(ListSwapOffersHandler, readReservations...))
+	mux.HandleFunc("POST /swap-offers/{id}/accept", middleware.AuthMiddleware(AcceptSwapOfferHandler, writeReservations))
+	mux.HandleFunc("POST /swap-offers/{id}/decline", middleware.AuthMiddleware(DeclineSwapOfferHandler, writeReservations))
+	mux.HandleFunc("GET /approvals", middleware.AuthMiddleware(
+		middleware.RequireRole(ListPendingApprovalsHandler, domain.RoleApprover, domain.RoleAdmin),
+	))
+	mux.HandleFunc("POST /approvals/{id}/approve", middleware.AuthMiddleware(
+		middleware.RequireRole(ApproveReservationHandler, domain.RoleApprover, domain.RoleAdmin),
+	))
+	mux.HandleFunc("POST /approvals/{id}/reject", middleware.AuthMiddleware(
+		middleware.RequireRole(RejectReservationHandler, domain.RoleApprover, domain.RoleAdmin),
+	))
+	mux.HandleFunc("PATCH /desks/{id}/approval", middleware.AuthMiddleware(
+		middleware.RequireRole(UpdateDeskApprovalHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("PATCH /zones/{id}/approval", middleware.AuthMiddleware(
+		middleware.RequireRole(UpdateZoneApprovalHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("PATCH /sites/{id}/time-zone", middleware.AuthMiddleware(
+		middleware.RequireRole(UpdateSiteTimeZoneHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("POST /sites/{id}/lottery", middleware.AuthMiddleware(RequestLotteryHandler, writeReservations))
+	mux.HandleFunc("GET /sites/{id}/lottery/{date}", middleware.AuthMiddleware(GetLotteryDrawHandler, readReservations...))
+	mux.HandleFunc("POST /sites/{id}/lottery/{date}/draw", middleware.AuthMiddleware(
+		middleware.RequireRole(DrawLotteryHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("GET /reception/guests", middleware.AuthMiddleware(
+		middleware.RequireRole(ListGuestVisitsHandler, domain.RoleReception, domain.RoleAdmin),
+	))
+	mux.HandleFunc("POST /login", middleware.SystemScopeMiddleware(LoginHandler))
+	mux.HandleFunc("POST /login/mfa", middleware.SystemScopeMiddleware(VerifyMFALoginHandler))
+	mux.HandleFunc("POST /mfa/enroll", middleware.AuthMiddleware(EnrollMFAHandler))
+	mux.HandleFunc("POST /mfa/enroll/confirm", middleware.AuthMiddleware(ConfirmMFAHandler))
+	mux.HandleFunc("DELETE /admin/users/{id}/mfa", middleware.AuthMiddleware(
+		middleware.RequireRole(ResetMFAHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("GET /admin/users", middleware.AuthMiddleware(
+		middleware.RequireRole(ListAdminUsersHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("GET /admin/users/{id}", middleware.AuthMiddleware(
+		middleware.RequireRole(GetAdminUserHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("PATCH /admin/users/{id}", middleware.AuthMiddleware(
+		middleware.RequireRole(UpdateAdminUserHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("DELETE /admin/users/{id}", middleware.AuthMiddleware(
+		middleware.RequireRole(DeleteAdminUserHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("POST /admin/users/{id}/deactivate", middleware.AuthMiddleware(
+		middleware.RequireRole(DeactivateAdminUserHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("POST /admin/users/{id}/reactivate", middleware.AuthMiddleware(
+		middleware.RequireRole(ReactivateAdminUserHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("GET /admin/users/{id}/reservations", middleware.AuthMiddleware(
+		middleware.RequireRole(ListAdminUserReservationsHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("POST /admin/users/{id}/erase", middleware.AuthMiddleware(
+		middleware.RequireRole(EraseAdminUserHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("GET /admin/reports/occupancy", middleware.AuthMiddleware(
+		middleware.RequireRole(OccupancyReportHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("GET /admin/reports/check-ins", middleware.AuthMiddleware(
+		middleware.RequireRole(CheckInReportHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("GET /admin/reports/weekdays", middleware.AuthMiddleware(
+		middleware.RequireRole(WeekdayReportHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("GET /admin/reports/lead-times", middleware.AuthMiddleware(
+		middleware.RequireRole(LeadTimeReportHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("GET /admin/audit-logs", middleware.AuthMiddleware(
+		middleware.RequireRole(ListAuditLogsHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("GET /admin/retention", middleware.AuthMiddleware(
+		middleware.RequireRole(GetReservationRetentionHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("PUT /admin/retention", middleware.AuthMiddleware(
+		middleware.RequireRole(UpdateReservationRetentionHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("POST /admin/retention/purge", middleware.AuthMiddleware(
+		middleware.RequireRole(PurgeExpiredReservationsHandler, domain.RoleAdmin),
+	))
+	mux.HandleFunc("GET /auth/oidc/login", OIDCLoginHandler)
+	mux.HandleFunc("GET /auth/oidc/callback", middleware.SystemScopeMiddleware(OIDCCallbackHandler))
+	mux.HandleFunc("POST /token/refresh", middleware.SystemScopeMiddleware(RefreshTokenHandler))
+	mux.HandleFunc("POST /logout", middleware.AuthMiddleware(LogoutHandler))
+	mux.HandleFunc("POST /api-keys", middleware.AuthMiddleware(CreateAPIKeyHandler))
+	mux.HandleFunc("GET /api-keys", middleware.AuthMiddleware(ListAPIKeysHandler))
+	mux.HandleFunc("DELETE /api-keys/{id}", middleware.AuthMiddleware(RevokeAPIKeyHandler))
+	mux.HandleFunc("GET /.well-known/jwks.json", JWKSHandler)
+	mux.HandleFunc("GET /scim/v2/Users", middleware.ScimAuthMiddleware(ListScimUsersHandler))
+	mux.HandleFunc("POST /scim/v2/Users", middleware.ScimAuthMiddleware(CreateScimUserHandler))
+	mux.HandleFunc("GET /scim/v2/Users/{id}", middleware.ScimAuthMiddleware(GetScimUserHandler))
+	mux.HandleFunc("PATCH /scim/v2/Users/{id}", middleware.ScimAuthMiddleware(PatchScimUserHandler))
+	mux.HandleFunc("DELETE /scim/v2/Users/{id}", middleware.ScimAuthMiddleware(DeleteScimUserHandler))
+	mux.HandleFunc("GET /scim/v2/Groups", middleware.ScimAuthMiddleware(ListScimGroupsHandler))
+	mux.HandleFunc("GET /scim/v2/Groups/{id}", middleware.ScimAuthMiddleware(GetScimGroupHandler))
+	mux.HandleFunc("PATCH /scim/v2/Groups/{id}", middleware.ScimAuthMiddleware(PatchScimGroupHandler))
 	return mux
 }
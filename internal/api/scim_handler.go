@@ -0,0 +1,228 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/infra"
+	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
+	"github.com/tufee/desk-reservation-go/internal/service"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func ListScimUsersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	startIndex, _ := strconv.Atoi(query.Get("startIndex"))
+	count, _ := strconv.Atoi(query.Get("count"))
+
+	scimService, err := buildScimService()
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	users, err := scimService.ListScimUsersService(ctx, domain.ScimListQuery{
+		Filter:     query.Get("filter"),
+		StartIndex: startIndex,
+		Count:      count,
+	})
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	writeScimResponse(w, http.StatusOK, users)
+}
+
+func GetScimUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	scimService, err := buildScimService()
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	user, err := scimService.GetScimUserService(ctx, r.PathValue("id"))
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	writeScimResponse(w, http.StatusOK, user)
+}
+
+func CreateScimUserHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.ScimUser
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+
+	scimService, err := buildScimService()
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	user, err := scimService.CreateScimUserService(ctx, data)
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", user.Meta.Location)
+	writeScimResponse(w, http.StatusCreated, user)
+}
+
+func PatchScimUserHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.ScimPatch
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+
+	scimService, err := buildScimService()
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	user, err := scimService.PatchScimUserService(ctx, r.PathValue("id"), data)
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	writeScimResponse(w, http.StatusOK, user)
+}
+
+func DeleteScimUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	scimService, err := buildScimService()
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	if err := scimService.DeactivateScimUserService(ctx, r.PathValue("id")); err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func ListScimGroupsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	scimService, err := buildScimService()
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	groups, err := scimService.ListScimGroupsService(ctx)
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	writeScimResponse(w, http.StatusOK, groups)
+}
+
+func GetScimGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	scimService, err := buildScimService()
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	group, err := scimService.GetScimGroupService(ctx, r.PathValue("id"))
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	writeScimResponse(w, http.StatusOK, group)
+}
+
+func PatchScimGroupHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.ScimPatch
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+
+	scimService, err := buildScimService()
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	group, err := scimService.PatchScimGroupService(ctx, r.PathValue("id"), data)
+	if err != nil {
+		writeScimError(w, err)
+		return
+	}
+
+	writeScimResponse(w, http.StatusOK, group)
+}
+
+func buildScimService() (*service.ScimService, error) {
+	db, err := infra.InitializeDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.ScimService{
+		UserRepository:     &repo.UserRepositoryDb{Conn: db.Conn},
+		AuditLogRepository: &repo.AuditLogRepositoryDb{Conn: db.Conn},
+	}, nil
+}
+
+func writeScimResponse(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeScimError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	detail := "Internal server error"
+	scimType := ""
+
+	var badRequest *pkg.BadRequestError
+	var notFound *pkg.NotFoundError
+	var conflict *pkg.ConflictError
+
+	switch {
+	case errors.As(err, &badRequest):
+		status, detail, scimType = http.StatusBadRequest, badRequest.Error(), "invalidValue"
+	case errors.As(err, &notFound):
+		status, detail = http.StatusNotFound, notFound.Error()
+	case errors.As(err, &conflict):
+		status, detail, scimType = http.StatusConflict, conflict.Error(), "uniqueness"
+	}
+
+	writeScimResponse(w, status, map[string]any{
+		"schemas":  []string{domain.ScimErrorSchema},
+		"status":   strconv.Itoa(status),
+		"scimType": scimType,
+		"detail":   detail,
+	})
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/infra"
+	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
+	"github.com/tufee/desk-reservation-go/internal/service"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func RefreshTokenHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.RefreshTokenRequest
+
+	if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+		return
+	}
+
+	ctx := r.Context()
+
+	tokenService, err := buildTokenService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	token, err := tokenService.RefreshTokenService(ctx, data)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(token)
+}
+
+func LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	var data domain.Logout
+
+	if r.ContentLength != 0 {
+		if err := pkg.ParseAndValidateRequest(r, &data, w); err != nil {
+			return
+		}
+	}
+
+	ctx := r.Context()
+	userId, _ := utils.GetContextValue[string](ctx, utils.AuthUserKey)
+	tokenId, _ := utils.GetContextValue[string](ctx, utils.AuthTokenIdKey)
+	expiresAt, _ := utils.GetContextValue[time.Time](ctx, utils.AuthTokenExpiryKey)
+
+	tokenService, err := buildTokenService()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := tokenService.LogoutService(ctx, userId, tokenId, expiresAt, data); err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message": "Logged out successfully",
+	})
+}
+
+func buildTokenService() (*service.TokenService, error) {
+	db, err := infra.InitializeDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.TokenService{
+		UserRepository:  &repo.UserRepositoryDb{Conn: db.Conn},
+		TokenRepository: &repo.TokenRepositoryDb{Conn: db.Conn},
+	}, nil
+}
@@ -1,12 +1,12 @@
 package api
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
 
 	"github.com/tufee/desk-reservation-go/internal/domain"
 	"github.com/tufee/desk-reservation-go/internal/infra"
+	"github.com/tufee/desk-reservation-go/internal/infra/breach"
 	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
 	"github.com/tufee/desk-reservation-go/internal/service"
 	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
@@ -20,7 +20,7 @@ func CreateUserHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	user := buildUserFromRequest(data)
-	ctx := context.Background()
+	ctx := r.Context()
 
 	db, err := infra.InitializeDB()
 	if err != nil {
@@ -28,17 +28,33 @@ func CreateUserHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	userRepository := &repo.UserRepositoryDb{Conn: db.Conn}
-	userService := service.UserService{UserRepository: userRepository}
+	userService := service.UserService{
+		UserRepository:     userRepository,
+		PasswordPolicy:     buildPasswordPolicy(),
+		AuditLogRepository: &repo.AuditLogRepositoryDb{Conn: db.Conn},
+	}
+
+	if breached := breach.NewRangeStoreFromEnv(); breached != nil {
+		userService.BreachedPasswords = breached
+	}
 
 	if err := userService.CreateUserService(ctx, user); err != nil {
 		pkg.HandleHTTPError(w, err)
 		return
 	}
 
+	accountService, err := buildAccountTokenService()
+	if err == nil {
+		err = accountService.SendEmailVerificationService(ctx, domain.RequestEmailVerification{Email: user.Email})
+	}
+	if err != nil {
+		pkg.GetLogger().Error("Error sending verification mail to %s: %v", user.Email, err)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(map[string]any{
-		"message": "User created successfully",
+		"message": "User created successfully, check your email to verify your account",
 	})
 }
 
@@ -50,3 +66,14 @@ func buildUserFromRequest(data domain.CreateUser) domain.CreateUser {
 		PasswordConfirmation: data.PasswordConfirmation,
 	}
 }
+
+func buildPasswordPolicy() domain.PasswordPolicy {
+	return domain.PasswordPolicy{
+		MinLength:            pkg.GetEnvInt("PASSWORD_MIN_LENGTH", 10),
+		RequireUppercase:     pkg.GetEnvBool("PASSWORD_REQUIRE_UPPERCASE", true),
+		RequireLowercase:     pkg.GetEnvBool("PASSWORD_REQUIRE_LOWERCASE", true),
+		RequireDigit:         pkg.GetEnvBool("PASSWORD_REQUIRE_DIGIT", true),
+		RequireSymbol:        pkg.GetEnvBool("PASSWORD_REQUIRE_SYMBOL", false),
+		DisallowPersonalInfo: pkg.GetEnvBool("PASSWORD_DISALLOW_PERSONAL_INFO", true),
+	}
+}
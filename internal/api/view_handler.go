@@ -3,15 +3,16 @@ package api
 import (
 	"html/template"
 	"net/http"
+	"sync"
 )
 
-var tmpl *template.Template
-
-func init() {
-	tmpl = template.Must(template.ParseGlob("web/templates/*.html"))
-}
+// Templates are parsed on first use so the package loads outside the repo
+// root, e.g. under go test.
+var tmpl = sync.OnceValue(func() *template.Template {
+	return template.Must(template.ParseGlob("web/templates/*.html"))
+})
 
 func Home(w http.ResponseWriter, r *http.Request) {
 	x := "Olá"
-	tmpl.ExecuteTemplate(w, "base.html", x)
+	tmpl().ExecuteTemplate(w, "base.html", x)
 }
@@ -0,0 +1,41 @@
+package domain
+
+const (
+	UserStatusActive      = "active"
+	UserStatusDeactivated = "deactivated"
+	UserStatusUnverified  = "unverified"
+	UserStatusDeleted     = "deleted"
+)
+
+var UserStatuses = []string{UserStatusActive, UserStatusDeactivated, UserStatusUnverified, UserStatusDeleted}
+
+// Deleted users are only returned when Status is UserStatusDeleted.
+type UserFilter struct {
+	Query  string
+	Team   string
+	Role   string
+	Status string
+	Offset int
+	Limit  int
+}
+
+type AdminUserQuery struct {
+	Query    string
+	Team     string
+	Role     string
+	Status   string
+	Page     int
+	PageSize int
+}
+
+type UpdateAdminUser struct {
+	Role *string `json:"role" validate:"omitempty,oneof=user approver reception admin"`
+	Team *string `json:"team" validate:"omitempty,max=100"`
+}
+
+type Page[T any] struct {
+	Items    []T `json:"items"`
+	Total    int `json:"total"`
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+}
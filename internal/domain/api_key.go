@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	ScopeReadReservations  = "reservations:read"
+	ScopeWriteReservations = "reservations:write"
+)
+
+type APIKeyRepositoryInterface interface {
+	SaveAPIKey(ctx context.Context, key CreateAPIKey) (*APIKey, error)
+	ListAPIKeys(ctx context.Context, userId string) ([]APIKey, error)
+	FindAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	TouchAPIKey(ctx context.Context, id string) error
+	RevokeAPIKey(ctx context.Context, userId string, id string) (bool, error)
+}
+
+type APIKey struct {
+	Id         string         `json:"id"           db:"id"`
+	UserId     string         `json:"user_id"      db:"user_id"`
+	Name       string         `json:"name"         db:"name"`
+	KeyPrefix  string         `json:"key_prefix"   db:"key_prefix"`
+	KeyHash    string         `json:"-"            db:"key_hash"`
+	Scopes     pq.StringArray `json:"scopes"       db:"scopes"`
+	ExpiresAt  *time.Time     `json:"expires_at"   db:"expires_at"`
+	LastUsedAt *time.Time     `json:"last_used_at" db:"last_used_at"`
+	RevokedAt  *time.Time     `json:"revoked_at"   db:"revoked_at"`
+	CreatedAt  time.Time      `json:"created_at"   db:"created_at"`
+}
+
+type CreateAPIKey struct {
+	UserId    string
+	Name      string
+	KeyPrefix string
+	KeyHash   string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"       validate:"required,max=100"`
+	Scopes    []string   `json:"scopes"     validate:"required,min=1,dive,oneof=reservations:read reservations:write"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
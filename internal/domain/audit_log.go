@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	AuditActionLoginLockout           = "auth.lockout"
+	AuditActionLogin                  = "auth.login"
+	AuditActionLoginFailed            = "auth.login_failed"
+	AuditActionUserCreated            = "user.created"
+	AuditActionUserUpdated            = "user.updated"
+	AuditActionUserDeactivated        = "user.deactivated"
+	AuditActionUserReactivated        = "user.reactivated"
+	AuditActionUserDeleted            = "user.deleted"
+	AuditActionUserErased             = "user.erased"
+	AuditActionUserRoleChanged        = "user.role_changed"
+	AuditActionUserMFAReset           = "user.mfa_reset"
+	AuditActionReservationCreated     = "reservation.created"
+	AuditActionReservationReviewed    = "reservation.reviewed"
+	AuditActionReservationCancelled   = "reservation.cancelled"
+	AuditActionReservationCheckedIn   = "reservation.checked_in"
+	AuditActionReservationMoved       = "reservation.moved"
+	AuditActionReservationTransferred = "reservation.transferred"
+	AuditActionReservationSwapped     = "reservation.swapped"
+	AuditActionReservationsPurged     = "reservation.purged"
+	AuditActionDeskUpdated            = "desk.updated"
+	AuditActionZoneUpdated            = "zone.updated"
+	AuditActionSiteUpdated            = "site.updated"
+	AuditActionLotteryDrawn           = "lottery.drawn"
+	AuditActionRetentionChanged       = "organisation.retention_changed"
+)
+
+const (
+	AuditTargetUser         = "user"
+	AuditTargetIPAddress    = "ip_address"
+	AuditTargetReservation  = "reservation"
+	AuditTargetDesk         = "desk"
+	AuditTargetZone         = "zone"
+	AuditTargetSite         = "site"
+	AuditTargetOrganisation = "organisation"
+)
+
+type AuditLogRepositoryInterface interface {
+	SaveAuditLog(ctx context.Context, entry CreateAuditLog) error
+	FindAuditLogsByUser(ctx context.Context, userId string) ([]AuditLog, error)
+	FindAuditLogs(ctx context.Context, filter AuditLogFilter) ([]AuditLog, int, error)
+}
+
+// RequestId, IpAddress and OrganisationId default to the values carried by
+// the request context when left empty. Before and After are stored as JSON.
+type CreateAuditLog struct {
+	ActorId        *string
+	Action         string
+	TargetType     string
+	TargetId       string
+	IpAddress      string
+	RequestId      string
+	OrganisationId string
+	Before         any
+	After          any
+	Details        map[string]any
+}
+
+type AuditLog struct {
+	Id             string          `json:"id"              db:"id"`
+	ActorId        *string         `json:"actor_id"        db:"actor_id"`
+	Action         string          `json:"action"          db:"action"`
+	TargetType     string          `json:"target_type"     db:"target_type"`
+	TargetId       *string         `json:"target_id"       db:"target_id"`
+	IpAddress      *string         `json:"ip_address"      db:"ip_address"`
+	RequestId      *string         `json:"request_id"      db:"request_id"`
+	OrganisationId *string         `json:"organisation_id" db:"organisation_id"`
+	Before         json.RawMessage `json:"before"          db:"before"`
+	After          json.RawMessage `json:"after"           db:"after"`
+	Details        AuditDetails    `json:"details"         db:"details"`
+	CreatedAt      time.Time       `json:"created_at"      db:"created_at"`
+}
+
+type AuditLogFilter struct {
+	ActorId    string
+	Action     string
+	TargetType string
+	TargetId   string
+	RequestId  string
+	From       *time.Time
+	To         *time.Time
+	Offset     int
+	Limit      int
+}
+
+type AuditLogQuery struct {
+	ActorId    string
+	Action     string
+	TargetType string
+	TargetId   string
+	RequestId  string
+	From       *time.Time
+	To         *time.Time
+	Page       int
+	PageSize   int
+}
+
+type AuditDetails map[string]any
+
+func (d AuditDetails) Value() (driver.Value, error) {
+	if d == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(d)
+}
+
+func (d *AuditDetails) Scan(src any) error {
+	switch value := src.(type) {
+	case []byte:
+		return json.Unmarshal(value, d)
+	case string:
+		return json.Unmarshal([]byte(value), d)
+	case nil:
+		*d = AuditDetails{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into AuditDetails", src)
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+)
+
+type CreateGuest struct {
+	Name   string `json:"name" db:"name"`
+	Email  string `json:"email" db:"email"`
+	HostId string `json:"host_id" db:"host_id"`
+}
+
+type CreateGuestReservation struct {
+	DeskId     string    `json:"desk_id" validate:"required"`
+	Date       time.Time `json:"date" validate:"required"`
+	GuestName  string    `json:"guest_name" validate:"required"`
+	GuestEmail string    `json:"guest_email" validate:"required,email"`
+}
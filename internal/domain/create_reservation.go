@@ -5,7 +5,9 @@ import (
 )
 
 type CreateReservation struct {
-	DeskId string    `json:"desk_id" db:"desk_id" validate:"required"`
-	UserId string    `json:"user_id" db:"user_id" validate:"required"`
-	Date   time.Time `json:"date" db:"date" validate:"required"`
+	DeskId     string    `json:"desk_id" db:"desk_id" validate:"required"`
+	UserId     string    `json:"-" db:"user_id"`
+	Date       time.Time `json:"date" db:"date" validate:"required"`
+	Status     string    `json:"-" db:"status"`
+	DailyLimit int       `json:"-" db:"-"`
 }
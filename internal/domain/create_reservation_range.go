@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"time"
+)
+
+type CreateReservationRange struct {
+	DeskId       string    `json:"desk_id" validate:"required"`
+	StartDate    time.Time `json:"start_date" validate:"required"`
+	EndDate      time.Time `json:"end_date" validate:"required,gtefield=StartDate"`
+	SkipWeekends bool      `json:"skip_weekends"`
+}
+
+type ReservationConflict struct {
+	Date   string `json:"date"`
+	Reason string `json:"reason"`
+}
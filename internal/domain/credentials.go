@@ -1,6 +1,7 @@
 package domain
 
 type Credentials struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required"`
+	Email     string `json:"email" validate:"required,email"`
+	Password  string `json:"password" validate:"required"`
+	IpAddress string `json:"-"`
 }
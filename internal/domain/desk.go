@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type DeskRepositoryInterface interface {
+	FindDeskById(ctx context.Context, id string) (*Desk, error)
+	UpdateDeskApproval(ctx context.Context, id string, requiresApproval bool) (*bool, error)
+	UpdateZoneApproval(ctx context.Context, id string, requiresApproval bool) (*bool, error)
+	FindAvailableDesksBySite(ctx context.Context, siteId string, date time.Time) ([]Desk, error)
+}
+
+type Desk struct {
+	Id               string  `json:"id"                db:"id"`
+	Number           int     `json:"number"            db:"number"`
+	ZoneId           *string `json:"zone_id"           db:"zone_id"`
+	SiteId           *string `json:"site_id"           db:"site_id"`
+	RequiresApproval bool    `json:"requires_approval" db:"requires_approval"`
+	LotteryEnabled   bool    `json:"lottery_enabled"   db:"lottery_enabled"`
+	TimeZone         string  `json:"time_zone"         db:"time_zone"`
+}
+
+func (d *Desk) Location() *time.Location {
+	return LoadLocation(d.TimeZone)
+}
+
+type UpdateDeskApproval struct {
+	RequiresApproval *bool `json:"requires_approval" validate:"required"`
+}
+
+type UpdateZoneApproval struct {
+	RequiresApproval *bool `json:"requires_approval" validate:"required"`
+}
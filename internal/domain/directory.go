@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+type DirectoryAuthenticatorInterface interface {
+	Authenticate(ctx context.Context, email, password string) (*DirectoryUser, error)
+}
+
+type DirectoryUser struct {
+	Email string
+	Name  string
+	Role  string
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type GuestRepositoryInterface interface {
+	SaveGuestReservation(ctx context.Context, guest CreateGuest, reservation CreateReservation) (string, error)
+	FindGuestVisitsByDate(ctx context.Context, date *time.Time) ([]GuestVisit, error)
+}
+
+type Guest struct {
+	Id        string    `json:"id"         db:"id"`
+	Name      string    `json:"name"       db:"name"`
+	Email     string    `json:"email"      db:"email"`
+	HostId    string    `json:"host_id"    db:"host_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type GuestVisit struct {
+	ReservationId string    `json:"reservation_id" db:"reservation_id"`
+	GuestName     string    `json:"guest_name"     db:"guest_name"`
+	GuestEmail    string    `json:"guest_email"    db:"guest_email"`
+	HostId        string    `json:"host_id"        db:"host_id"`
+	HostName      string    `json:"host_name"      db:"host_name"`
+	DeskNumber    int       `json:"desk_number"    db:"desk_number"`
+	Date          time.Time `json:"date"           db:"date"`
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type LoginAttemptRepositoryInterface interface {
+	SaveLoginAttempt(ctx context.Context, attempt LoginAttempt) error
+	CountAccountFailures(ctx context.Context, email string, since time.Time) (*LoginFailures, error)
+	CountIPFailures(ctx context.Context, ipAddress string, since time.Time) (*LoginFailures, error)
+}
+
+type LoginAttempt struct {
+	Email       string    `db:"email"`
+	IpAddress   string    `db:"ip_address"`
+	Succeeded   bool      `db:"succeeded"`
+	AttemptedAt time.Time `db:"attempted_at"`
+}
+
+type LoginFailures struct {
+	Count        int        `db:"count"`
+	LastFailedAt *time.Time `db:"last_failed_at"`
+}
+
+// A zero failure limit disables throttling for that scope. Each failure past
+// the limit doubles the lockout, starting at BaseLockout and capped at MaxLockout.
+type LoginThrottlePolicy struct {
+	MaxAccountFailures int
+	MaxIPFailures      int
+	Window             time.Duration
+	BaseLockout        time.Duration
+	MaxLockout         time.Duration
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type LotteryRepositoryInterface interface {
+	SaveLotteryRequest(ctx context.Context, request CreateLotteryRequest) (bool, error)
+	FindLotteryRequests(ctx context.Context, siteId string, date time.Time) ([]LotteryRequest, error)
+	CountRecentWins(ctx context.Context, siteId string, since time.Time) (map[string]int, error)
+	FindLotteryDraw(ctx context.Context, siteId string, date time.Time) (*LotteryDraw, error)
+	SaveLotteryDraw(ctx context.Context, draw CreateLotteryDraw) error
+	PromoteLotteryWaitlist(ctx context.Context, promotion LotteryPromotion) (*Reservation, error)
+}
+
+const (
+	LotteryStatusPending    = "pending"
+	LotteryStatusWon        = "won"
+	LotteryStatusWaitlisted = "waitlisted"
+	LotteryStatusForfeited  = "forfeited"
+)
+
+type LotteryRequest struct {
+	Id               string    `json:"id"                db:"id"`
+	SiteId           string    `json:"site_id"           db:"site_id"`
+	UserId           string    `json:"user_id"           db:"user_id"`
+	Date             time.Time `json:"date"              db:"date"`
+	Status           string    `json:"status"            db:"status"`
+	DeskId           *string   `json:"desk_id"           db:"desk_id"`
+	WaitlistPosition *int      `json:"waitlist_position" db:"waitlist_position"`
+	CreatedAt        time.Time `json:"created_at"        db:"created_at"`
+}
+
+type CreateLotteryRequest struct {
+	SiteId string
+	UserId string
+	Date   time.Time
+}
+
+type LotteryEntry struct {
+	Date time.Time `json:"date" validate:"required"`
+}
+
+type LotteryOutcome struct {
+	RequestId        string  `json:"request_id"`
+	UserId           string  `json:"user_id"`
+	Weight           float64 `json:"weight"`
+	Status           string  `json:"status"`
+	DeskId           *string `json:"desk_id,omitempty"`
+	WaitlistPosition *int    `json:"waitlist_position,omitempty"`
+}
+
+type LotteryDraw struct {
+	Id      string          `json:"id"       db:"id"`
+	SiteId  string          `json:"site_id"  db:"site_id"`
+	Date    time.Time       `json:"date"     db:"date"`
+	Seed    int64           `json:"seed"     db:"seed"`
+	Results json.RawMessage `json:"results"  db:"results"`
+	DrawnBy string          `json:"drawn_by" db:"drawn_by"`
+	DrawnAt time.Time       `json:"drawn_at" db:"drawn_at"`
+}
+
+// Outcomes are the raw draw, replayable from Seed. Winners already at
+// DailyLimit forfeit their desk to the waitlist when the draw is saved, so
+// lottery_requests holds the final allocation.
+type CreateLotteryDraw struct {
+	SiteId     string
+	Date       time.Time
+	Seed       int64
+	DrawnBy    string
+	DailyLimit int
+	Outcomes   []LotteryOutcome
+}
+
+// LotteryPromotion hands the desk FromUserId won back to the draw's waitlist.
+type LotteryPromotion struct {
+	SiteId     string
+	DeskId     string
+	FromUserId string
+	Date       time.Time
+	DailyLimit int
+	ActorId    string
+}
@@ -0,0 +1,7 @@
+package domain
+
+import "context"
+
+type MailSenderInterface interface {
+	SendMail(ctx context.Context, to string, subject string, body string) error
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type MFARepositoryInterface interface {
+	FindUserMFA(ctx context.Context, userId string) (*UserMFA, error)
+	SavePendingMFA(ctx context.Context, userId string, secret string) (bool, error)
+	EnableMFA(ctx context.Context, userId string, step int64, recoveryCodeHashes []string) (bool, error)
+	UpdateMFALastUsedStep(ctx context.Context, userId string, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userId string, codeHash string) (bool, error)
+	DeleteUserMFA(ctx context.Context, userId string) (bool, error)
+}
+
+type UserMFA struct {
+	UserId       string     `db:"user_id"`
+	Secret       string     `db:"secret"`
+	EnabledAt    *time.Time `db:"enabled_at"`
+	LastUsedStep int64      `db:"last_used_step"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+type MFAEnrollment struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+	QRCode     string `json:"qr_code"`
+}
+
+type ConfirmMFA struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type MFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type VerifyMFALogin struct {
+	MfaToken     string `json:"mfa_token"     validate:"required"`
+	Code         string `json:"code"          validate:"required_without=RecoveryCode"`
+	RecoveryCode string `json:"recovery_code" validate:"required_without=Code"`
+	IpAddress    string `json:"-"`
+}
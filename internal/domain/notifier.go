@@ -0,0 +1,9 @@
+package domain
+
+import (
+	"context"
+)
+
+type NotifierInterface interface {
+	Notify(ctx context.Context, recipients []string, subject string, body string) error
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "context"
+
+type OIDCProviderInterface interface {
+	Issuer() string
+	AuthCodeURL(state, nonce, verifier string) string
+	Exchange(ctx context.Context, code, verifier, nonce string) (*OIDCIdentity, error)
+}
+
+type UserIdentityRepositoryInterface interface {
+	FindUserByIdentity(ctx context.Context, provider, subject string) (*User, error)
+	LinkIdentity(ctx context.Context, identity CreateUserIdentity) error
+	SaveUserWithIdentity(ctx context.Context, user CreateUser, identity CreateUserIdentity) (*User, error)
+}
+
+type OIDCIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type CreateUserIdentity struct {
+	UserId   string `db:"user_id"`
+	Provider string `db:"provider"`
+	Subject  string `db:"subject"`
+}
+
+type OIDCAuthRequest struct {
+	URL      string
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+type OIDCCallback struct {
+	Code          string
+	State         string
+	ExpectedState string
+	Nonce         string
+	Verifier      string
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const DefaultOrganisationId = "00000000-0000-0000-0000-000000000001"
+
+type OrganisationRepositoryInterface interface {
+	FindOrganisations(ctx context.Context) ([]Organisation, error)
+	FindOrganisation(ctx context.Context) (*Organisation, error)
+	UpdateReservationRetention(ctx context.Context, days *int) (bool, error)
+}
+
+type Organisation struct {
+	Id                       string    `json:"id"                         db:"id"`
+	Name                     string    `json:"name"                       db:"name"`
+	ReservationRetentionDays *int      `json:"reservation_retention_days" db:"reservation_retention_days"`
+	SignupDomain             *string   `json:"signup_domain"              db:"signup_domain"`
+	CreatedAt                time.Time `json:"created_at"                 db:"created_at"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "context"
+
+// A zero value only requires a non-empty password.
+type PasswordPolicy struct {
+	MinLength            int
+	RequireUppercase     bool
+	RequireLowercase     bool
+	RequireDigit         bool
+	RequireSymbol        bool
+	DisallowPersonalInfo bool
+}
+
+type BreachedPasswordCheckerInterface interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// Guests the erased user hosted are pseudonymised too; their reservations stay
+// for occupancy reports, so they get GuestName and a per-guest address at
+// GuestEmailDomain.
+type UserPseudonym struct {
+	Name             string
+	Email            string
+	GuestName        string
+	GuestEmailDomain string
+}
+
+type UserDataExport struct {
+	ExportedAt   time.Time     `json:"exported_at"`
+	Profile      *User         `json:"profile"`
+	Reservations []Reservation `json:"reservations"`
+	AuditLogs    []AuditLog    `json:"audit_logs"`
+}
+
+// Password is required for accounts that have one; accounts signed in through
+// OIDC or LDAP confirm erasure through an emailed token instead.
+type EraseAccount struct {
+	Password string `json:"password"`
+}
+
+type ConfirmAccountErasure struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// Zero ReservationRetention keeps past reservations forever.
+type RetentionPolicy struct {
+	ReservationRetention time.Duration
+}
+
+// A nil RetentionDays drops the organisation override and restores the
+// server default.
+type UpdateReservationRetention struct {
+	RetentionDays *int `json:"retention_days" validate:"omitempty,min=0"`
+}
+
+type ReservationRetention struct {
+	RetentionDays        int  `json:"retention_days"`
+	DefaultRetentionDays int  `json:"default_retention_days"`
+	Overridden           bool `json:"overridden"`
+}
+
+type ReservationPurge struct {
+	RetentionDays       int `json:"retention_days"`
+	DeletedReservations int `json:"deleted_reservations"`
+}
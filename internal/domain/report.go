@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type ReportRepositoryInterface interface {
+	FindOccupancy(ctx context.Context, query ReportQuery) ([]OccupancyRow, error)
+	FindCheckInRates(ctx context.Context, query ReportQuery) ([]CheckInRateRow, error)
+	FindWeekdayPeaks(ctx context.Context, query ReportQuery) ([]WeekdayPeakRow, error)
+	FindLeadTimes(ctx context.Context, query ReportQuery, thresholds []int) ([]LeadTimeRow, error)
+}
+
+const (
+	ReportGroupDesk = "desk"
+	ReportGroupZone = "zone"
+	ReportGroupSite = "site"
+
+	ReportPeriodDay  = "day"
+	ReportPeriodWeek = "week"
+
+	MaxReportDays = 366
+)
+
+// Lower bounds, in days, of each lead time bucket after "same day".
+var LeadTimeThresholds = []int{1, 2, 4, 8, 15, 31}
+
+// Reports only count confirmed reservations. From and To are site-local days.
+type ReportQuery struct {
+	From    time.Time
+	To      time.Time
+	SiteId  string
+	GroupBy string
+	Period  string
+}
+
+type OccupancyRow struct {
+	Period         time.Time `json:"period"           db:"period"`
+	GroupId        *string   `json:"group_id"         db:"group_id"`
+	GroupName      *string   `json:"group_name"       db:"group_name"`
+	Desks          int       `json:"desks"            db:"desks"`
+	DeskDays       int       `json:"desk_days"        db:"desk_days"`
+	BookedDeskDays int       `json:"booked_desk_days" db:"booked_desk_days"`
+	Occupancy      float64   `json:"occupancy"        db:"occupancy"`
+}
+
+type CheckInRateRow struct {
+	Period      time.Time `json:"period"       db:"period"`
+	GroupId     *string   `json:"group_id"     db:"group_id"`
+	GroupName   *string   `json:"group_name"   db:"group_name"`
+	Bookings    int       `json:"bookings"     db:"bookings"`
+	CheckIns    int       `json:"check_ins"    db:"check_ins"`
+	NoShows     int       `json:"no_shows"     db:"no_shows"`
+	CheckInRate float64   `json:"check_in_rate" db:"check_in_rate"`
+	NoShowRate  float64   `json:"no_show_rate" db:"no_show_rate"`
+}
+
+type WeekdayPeakRow struct {
+	Weekday         int     `json:"weekday"          db:"weekday"`
+	Name            string  `json:"name"             db:"-"`
+	Days            int     `json:"days"             db:"days"`
+	Bookings        int     `json:"bookings"         db:"bookings"`
+	AverageBookings float64 `json:"average_bookings" db:"average_bookings"`
+}
+
+type LeadTimeRow struct {
+	Bucket   int     `json:"-"        db:"bucket"`
+	Label    string  `json:"label"    db:"-"`
+	MinDays  int     `json:"min_days" db:"-"`
+	MaxDays  *int    `json:"max_days" db:"-"`
+	Bookings int     `json:"bookings" db:"bookings"`
+	Share    float64 `json:"share"    db:"-"`
+}
@@ -7,15 +7,34 @@ import (
 
 type ReservationRepositoryInterface interface {
 	FindReservation(ctx context.Context, reservation CreateReservation) (*Reservation, error)
-	SaveReservation(ctx context.Context, reservation CreateReservation) error
+	SaveReservation(ctx context.Context, reservation CreateReservation) (string, error)
+	SaveReservations(ctx context.Context, reservations []CreateReservation) ([]string, []time.Time, error)
+	CountUserReservationsByDate(ctx context.Context, userId string, date time.Time) (int, error)
+	FindReservationById(ctx context.Context, id string) (*Reservation, error)
+	TransferReservation(ctx context.Context, transfer ReservationTransfer) (bool, error)
+	SwapReservationOwners(ctx context.Context, swap ReservationSwap) (bool, error)
+	FindPendingApprovals(ctx context.Context) ([]Reservation, error)
+	ReviewReservation(ctx context.Context, id string, status string, reviewerId string) (bool, error)
+	FindReservationsByUser(ctx context.Context, userId string, offset int, limit int) ([]Reservation, int, error)
+	DeleteReservationsBefore(ctx context.Context, before time.Time) (int, error)
+	CancelReservation(ctx context.Context, id string, actorId string) (bool, error)
+	CheckInReservation(ctx context.Context, id string) (bool, error)
+	FindReservationHistory(ctx context.Context, id string) ([]ReservationStatusChange, error)
+	MoveReservation(ctx context.Context, move MoveReservation) (bool, error)
 }
 
 type Reservation struct {
-	Id        string    `json:"id"         db:"id"`
-	DeskId    string    `json:"desk_id"    db:"desk_id"`
-	UserId    string    `json:"user_id"    db:"user_id"`
-	Date      time.Time `json:"date"       db:"date"`
-	Status    string    `json:"status"     db:"status"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	Id              string     `json:"id"              db:"id"`
+	DeskId          string     `json:"desk_id"         db:"desk_id"`
+	UserId          string     `json:"user_id"         db:"user_id"`
+	GuestId         *string    `json:"guest_id"        db:"guest_id"`
+	Date            time.Time  `json:"date"            db:"date"`
+	Status          string     `json:"status"          db:"status"`
+	ReviewedBy      *string    `json:"reviewed_by"     db:"reviewed_by"`
+	ReviewedAt      *time.Time `json:"reviewed_at"     db:"reviewed_at"`
+	CreatedAt       time.Time  `json:"created_at"      db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"      db:"updated_at"`
+	OrganisationId  string     `json:"organisation_id" db:"organisation_id"`
+	CheckedInAt     *time.Time `json:"checked_in_at"   db:"checked_in_at"`
+	StatusChangedBy *string    `json:"status_changed_by" db:"status_changed_by"`
 }
@@ -0,0 +1,6 @@
+package domain
+
+// A zero value disables the corresponding limit.
+type ReservationPolicy struct {
+	MaxReservationsPerDay int
+}
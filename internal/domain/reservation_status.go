@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+const (
+	ReservationStatusPending   = "pending"
+	ReservationStatusConfirmed = "confirmed"
+	ReservationStatusCancelled = "cancelled"
+	ReservationStatusRejected  = "rejected"
+)
+
+// Cancelled and rejected reservations are final. A confirmed reservation only
+// goes back to pending when it is moved to a desk that needs approval.
+var reservationTransitions = map[string][]string{
+	ReservationStatusPending:   {ReservationStatusConfirmed, ReservationStatusRejected, ReservationStatusCancelled},
+	ReservationStatusConfirmed: {ReservationStatusPending, ReservationStatusCancelled},
+}
+
+func CanTransitionReservation(from string, to string) bool {
+	for _, allowed := range reservationTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+type ReservationStatusChange struct {
+	Id            string    `json:"id"             db:"id"`
+	ReservationId string    `json:"reservation_id" db:"reservation_id"`
+	FromStatus    *string   `json:"from_status"    db:"from_status"`
+	ToStatus      string    `json:"to_status"      db:"to_status"`
+	ActorId       *string   `json:"actor_id"       db:"actor_id"`
+	ChangedAt     time.Time `json:"changed_at"     db:"changed_at"`
+}
+
+type ReservationDetails struct {
+	Reservation
+	History []ReservationStatusChange `json:"history"`
+}
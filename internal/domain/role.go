@@ -0,0 +1,10 @@
+package domain
+
+const (
+	RoleUser      = "user"
+	RoleApprover  = "approver"
+	RoleReception = "reception"
+	RoleAdmin     = "admin"
+)
+
+var Roles = []string{RoleUser, RoleApprover, RoleReception, RoleAdmin}
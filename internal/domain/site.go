@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type SiteRepositoryInterface interface {
+	FindSiteById(ctx context.Context, id string) (*Site, error)
+	UpdateSiteTimeZone(ctx context.Context, id string, timeZone string) (bool, error)
+}
+
+type Site struct {
+	Id                 string `json:"id"                   db:"id"`
+	Name               string `json:"name"                 db:"name"`
+	LotteryEnabled     bool   `json:"lottery_enabled"      db:"lottery_enabled"`
+	LotteryCutoffHours int    `json:"lottery_cutoff_hours" db:"lottery_cutoff_hours"`
+	OrganisationId     string `json:"organisation_id"      db:"organisation_id"`
+	TimeZone           string `json:"time_zone"            db:"time_zone"`
+}
+
+type UpdateSiteTimeZone struct {
+	TimeZone string `json:"time_zone" validate:"required"`
+}
+
+func (s *Site) Location() *time.Location {
+	return LoadLocation(s.TimeZone)
+}
+
+// LoadLocation resolves an IANA time zone name, falling back to UTC when the
+// name is empty. Unknown names also fall back to UTC but are logged, since
+// they can only come from rows written before site time zones were validated.
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		pkg.GetLogger().Warn("Unknown time zone %q, using UTC: %v", name, err)
+		return time.UTC
+	}
+
+	return loc
+}
+
+// LocalDate returns the calendar day of t at loc as midnight UTC, which is how
+// reservation days are stored and compared.
+func LocalDate(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// StartOfLocalDay returns the instant the given reservation day begins at loc.
+func StartOfLocalDay(date time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type SwapOfferRepositoryInterface interface {
+	SaveSwapOffer(ctx context.Context, offer CreateSwapOffer) (*SwapOffer, error)
+	FindSwapOfferById(ctx context.Context, id string) (*SwapOffer, error)
+	FindPendingSwapOffersByUser(ctx context.Context, userId string) ([]SwapOffer, error)
+	UpdateSwapOfferStatus(ctx context.Context, id string, status string) (bool, error)
+}
+
+type SwapOffer struct {
+	Id                   string    `json:"id"                     db:"id"`
+	ReservationId        string    `json:"reservation_id"         db:"reservation_id"`
+	CounterReservationId string    `json:"counter_reservation_id" db:"counter_reservation_id"`
+	FromUserId           string    `json:"from_user_id"           db:"from_user_id"`
+	ToUserId             string    `json:"to_user_id"             db:"to_user_id"`
+	Status               string    `json:"status"                 db:"status"`
+	ExpiresAt            time.Time `json:"expires_at"             db:"expires_at"`
+	CreatedAt            time.Time `json:"created_at"             db:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"             db:"updated_at"`
+}
+
+type CreateSwapOffer struct {
+	ReservationId        string    `db:"reservation_id"`
+	CounterReservationId string    `db:"counter_reservation_id"`
+	FromUserId           string    `db:"from_user_id"`
+	ToUserId             string    `db:"to_user_id"`
+	ExpiresAt            time.Time `db:"expires_at"`
+}
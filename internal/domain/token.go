@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type TokenRepositoryInterface interface {
+	SaveRefreshToken(ctx context.Context, token CreateRefreshToken) error
+	FindRefreshToken(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, currentId string, token CreateRefreshToken) (bool, error)
+	RevokeTokenFamily(ctx context.Context, familyId string) error
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+type RefreshToken struct {
+	Id        string     `json:"id"         db:"id"`
+	UserId    string     `json:"user_id"    db:"user_id"`
+	FamilyId  string     `json:"family_id"  db:"family_id"`
+	TokenHash string     `json:"-"          db:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at" db:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+type CreateRefreshToken struct {
+	UserId    string    `db:"user_id"`
+	FamilyId  string    `db:"family_id"`
+	TokenHash string    `db:"token_hash"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type Logout struct {
+	RefreshToken string `json:"refresh_token"`
+}
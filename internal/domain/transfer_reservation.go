@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+type TransferReservation struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ProposeSwap struct {
+	CounterReservationId string `json:"counter_reservation_id" validate:"required"`
+}
+
+// ReservationTransfer only applies while FromUserId still holds the
+// reservation on Date.
+type ReservationTransfer struct {
+	Id         string
+	FromUserId string
+	ToUserId   string
+	Date       time.Time
+	DailyLimit int
+}
+
+// ReservationSwap only applies while both reservations of Offer are on Date.
+type ReservationSwap struct {
+	Offer      SwapOffer
+	Date       time.Time
+	DailyLimit int
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"time"
+)
+
+type UpdateReservation struct {
+	DeskId *string    `json:"desk_id" validate:"required_without=Date,omitempty,min=1"`
+	Date   *time.Time `json:"date" validate:"required_without=DeskId"`
+}
+
+// MoveReservation only applies while the reservation is still in FromStatus.
+type MoveReservation struct {
+	Id         string
+	UserId     string
+	DeskId     string
+	Date       time.Time
+	FromStatus string
+	Status     string
+	ActorId    string
+	DailyLimit int
+}
@@ -7,14 +7,48 @@ import (
 
 type UserRepositoryInterface interface {
 	FindUserByEmail(ctx context.Context, email string) (*User, error)
-	SaveUser(ctx context.Context, user CreateUser) error
+	FindUserById(ctx context.Context, id string) (*User, error)
+	SaveUser(ctx context.Context, user CreateUser) (*User, error)
+	UpsertDirectoryUser(ctx context.Context, user DirectoryUser) (*User, error)
+	FindUsersByRole(ctx context.Context, role string) ([]User, error)
+	ListUsers(ctx context.Context, offset int, limit int) ([]User, int, error)
+	SaveProvisionedUser(ctx context.Context, user ProvisionUser) (*User, error)
+	UpdateProvisionedUser(ctx context.Context, id string, user ProvisionUser) (*User, error)
+	UpdateUserRole(ctx context.Context, id string, role string) (bool, error)
+	DeactivateUser(ctx context.Context, id string) (int, error)
+	ReactivateUser(ctx context.Context, id string) (bool, error)
+	UpdateUserPassword(ctx context.Context, id string, password string) error
+	MarkEmailVerified(ctx context.Context, id string) error
+	UpdateUserProfile(ctx context.Context, id string, profile UserProfile) (*User, error)
+	SearchUsers(ctx context.Context, filter UserFilter) ([]User, int, error)
+	UpdateUserTeam(ctx context.Context, id string, team *string) (bool, error)
+	SoftDeleteUser(ctx context.Context, id string) (int, error)
+	AnonymizeUser(ctx context.Context, id string, pseudonym UserPseudonym) (bool, error)
 }
 
 type User struct {
-	Id         string    `json:"id"`
-	Name       string    `json:"name"`
-	Email      string    `json:"email"`
-	Password   string    `json:"password"`
-	Created_at time.Time `json:"created_at"`
-	Updated_at time.Time `json:"updated_at"`
+	Id                      string                  `json:"id"`
+	Name                    string                  `json:"name"`
+	Email                   string                  `json:"email"`
+	Password                string                  `json:"-"`
+	Role                    string                  `json:"role"`
+	ExternalId              *string                 `json:"external_id"              db:"external_id"`
+	DeactivatedAt           *time.Time              `json:"deactivated_at"           db:"deactivated_at"`
+	EmailVerifiedAt         *time.Time              `json:"email_verified_at"        db:"email_verified_at"`
+	PreferredSiteId         *string                 `json:"preferred_site_id"        db:"preferred_site_id"`
+	DefaultDeskId           *string                 `json:"default_desk_id"          db:"default_desk_id"`
+	TimeZone                string                  `json:"time_zone"                db:"time_zone"`
+	NotificationPreferences NotificationPreferences `json:"notification_preferences" db:"notification_preferences"`
+	Team                    *string                 `json:"team"                     db:"team"`
+	DeletedAt               *time.Time              `json:"deleted_at"               db:"deleted_at"`
+	AnonymizedAt            *time.Time              `json:"anonymized_at"            db:"anonymized_at"`
+	OrganisationId          string                  `json:"organisation_id"          db:"organisation_id"`
+	Created_at              time.Time               `json:"created_at"`
+	Updated_at              time.Time               `json:"updated_at"`
+}
+
+type ProvisionUser struct {
+	Name       string
+	Email      string
+	ExternalId *string
 }
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+type NotificationPreferences struct {
+	ReservationUpdates bool `json:"reservation_updates"`
+	ApprovalRequests   bool `json:"approval_requests"`
+	Reminders          bool `json:"reminders"`
+}
+
+func (p NotificationPreferences) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+func (p *NotificationPreferences) Scan(src any) error {
+	switch value := src.(type) {
+	case []byte:
+		return json.Unmarshal(value, p)
+	case string:
+		return json.Unmarshal([]byte(value), p)
+	case nil:
+		*p = NotificationPreferences{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into NotificationPreferences", src)
+	}
+}
+
+type UserProfile struct {
+	Name                    string
+	PreferredSiteId         *string
+	DefaultDeskId           *string
+	TimeZone                string
+	NotificationPreferences NotificationPreferences
+}
+
+// Empty strings clear the preferred site and default desk.
+type UpdateProfile struct {
+	Name                    *string                  `json:"name"                     validate:"omitempty,min=1,max=200"`
+	PreferredSiteId         *string                  `json:"preferred_site_id"        validate:"omitempty,uuid"`
+	DefaultDeskId           *string                  `json:"default_desk_id"          validate:"omitempty,uuid"`
+	TimeZone                *string                  `json:"time_zone"                validate:"omitempty"`
+	NotificationPreferences *NotificationPreferences `json:"notification_preferences"`
+}
+
+type ChangePassword struct {
+	CurrentPassword      string `json:"currentPassword"      validate:"required"`
+	Password             string `json:"password"             validate:"required"`
+	PasswordConfirmation string `json:"passwordConfirmation" validate:"required,eqfield=Password"`
+}
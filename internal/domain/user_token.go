@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	UserTokenPurposePasswordReset     = "password_reset"
+	UserTokenPurposeEmailVerification = "email_verification"
+	UserTokenPurposeAccountErasure    = "account_erasure"
+)
+
+type UserTokenRepositoryInterface interface {
+	SaveUserToken(ctx context.Context, token CreateUserToken) error
+	FindUserToken(ctx context.Context, purpose string, tokenHash string) (*UserToken, error)
+	ConsumeUserToken(ctx context.Context, purpose string, tokenHash string) (*UserToken, error)
+}
+
+type UserToken struct {
+	Id        string     `json:"id"         db:"id"`
+	UserId    string     `json:"user_id"    db:"user_id"`
+	Purpose   string     `json:"purpose"    db:"purpose"`
+	TokenHash string     `json:"-"          db:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"    db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+type CreateUserToken struct {
+	UserId    string    `db:"user_id"`
+	Purpose   string    `db:"purpose"`
+	TokenHash string    `db:"token_hash"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+type RequestPasswordReset struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPassword struct {
+	Token                string `json:"token"                validate:"required"`
+	Password             string `json:"password"             validate:"required"`
+	PasswordConfirmation string `json:"passwordConfirmation" validate:"required,eqfield=Password"`
+}
+
+type RequestEmailVerification struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type VerifyEmail struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -0,0 +1,76 @@
+package breach
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const prefixLength = 5
+
+// RangeStore looks up passwords in a local copy of a k-anonymity range list:
+// one file per five character SHA-1 prefix, each line holding the remaining
+// hash suffix and a breach count ("SUFFIX:COUNT"). Only the file for the
+// password's prefix is read on every check.
+type RangeStore struct {
+	Dir string
+}
+
+func NewRangeStore(dir string) *RangeStore {
+	return &RangeStore{Dir: dir}
+}
+
+func NewRangeStoreFromEnv() *RangeStore {
+	dir := os.Getenv("BREACHED_PASSWORDS_DIR")
+	if dir == "" {
+		return nil
+	}
+
+	return NewRangeStore(dir)
+}
+
+func (s *RangeStore) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+
+	file, err := openRange(s.Dir, prefix)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		candidate, count, _ := strings.Cut(line, ":")
+
+		if strings.EqualFold(candidate, suffix) && count != "0" {
+			return true, nil
+		}
+	}
+
+	return false, scanner.Err()
+}
+
+func openRange(dir string, prefix string) (*os.File, error) {
+	file, err := os.Open(filepath.Join(dir, prefix))
+	if errors.Is(err, fs.ErrNotExist) {
+		return os.Open(filepath.Join(dir, prefix+".txt"))
+	}
+
+	return file, err
+}
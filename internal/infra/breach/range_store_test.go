@@ -0,0 +1,41 @@
+package breach
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRangeStore(t *testing.T) {
+	dir := t.TempDir()
+
+	// SHA-1("password") = 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	rangeFile := "1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\n0018A45C4D1DEF81644B54AB7F969B88D65:1\n"
+	if err := os.WriteFile(filepath.Join(dir, "5BAA6"), []byte(rangeFile), 0o600); err != nil {
+		t.Fatalf("failed to write range file: %v", err)
+	}
+
+	store := NewRangeStore(dir)
+	ctx := context.Background()
+
+	t.Run("should detect breached password", func(t *testing.T) {
+		breached, err := store.IsBreached(ctx, "password")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !breached {
+			t.Error("expected password to be breached")
+		}
+	})
+
+	t.Run("should accept password missing from range", func(t *testing.T) {
+		breached, err := store.IsBreached(ctx, "correct horse battery staple")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if breached {
+			t.Error("expected password not to be breached")
+		}
+	})
+}
@@ -1,10 +1,12 @@
 package infra
 
 import (
+	"database/sql"
 	"fmt"
 	"os"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type Db struct {
@@ -18,10 +20,16 @@ func InitializeDB() (*Db, error) {
 		return nil, fmt.Errorf("missing DATABASE_URL in environment")
 	}
 
-	db, err := sqlx.Connect("postgres", connection)
+	connector, err := pq.NewConnector(connection)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to DB: %w", err)
 	}
 
+	db := sqlx.NewDb(sql.OpenDB(tenantConnector{connector}), "postgres")
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to DB: %w", err)
+	}
+
 	return &Db{Conn: db}, nil
 }
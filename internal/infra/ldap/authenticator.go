@@ -0,0 +1,193 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+type Config struct {
+	URL          string
+	BindDN       string
+	BindPassword string
+	BaseDN       string
+	UserFilter   string
+	StartTLS     bool
+	// GroupRoles maps a group DN to an application role, e.g.
+	// "cn=desk-admins,ou=groups,dc=example,dc=com" -> "admin".
+	GroupRoles map[string]string
+}
+
+type Authenticator struct {
+	config Config
+}
+
+var rolePriority = []string{domain.RoleAdmin, domain.RoleApprover, domain.RoleReception, domain.RoleUser}
+
+func NewAuthenticator(config Config) *Authenticator {
+	if config.UserFilter == "" {
+		config.UserFilter = "(mail=%s)"
+	}
+
+	groupRoles := make(map[string]string, len(config.GroupRoles))
+	for group, role := range config.GroupRoles {
+		groupRoles[normalizeDN(group)] = role
+	}
+	config.GroupRoles = groupRoles
+
+	return &Authenticator{config: config}
+}
+
+func NewAuthenticatorFromEnv() *Authenticator {
+	serverURL := os.Getenv("LDAP_URL")
+	if serverURL == "" {
+		return nil
+	}
+
+	return NewAuthenticator(Config{
+		URL:          serverURL,
+		BindDN:       os.Getenv("LDAP_BIND_DN"),
+		BindPassword: os.Getenv("LDAP_BIND_PASSWORD"),
+		BaseDN:       os.Getenv("LDAP_BASE_DN"),
+		UserFilter:   os.Getenv("LDAP_USER_FILTER"),
+		StartTLS:     os.Getenv("LDAP_START_TLS") == "true",
+		GroupRoles:   ParseGroupRoles(os.Getenv("LDAP_GROUP_ROLES")),
+	})
+}
+
+// ParseGroupRoles reads "role=groupDN" pairs separated by ";".
+func ParseGroupRoles(value string) map[string]string {
+	groupRoles := map[string]string{}
+
+	for _, pair := range strings.Split(value, ";") {
+		role, group, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || role == "" || group == "" {
+			continue
+		}
+		groupRoles[group] = role
+	}
+
+	return groupRoles
+}
+
+func (a *Authenticator) Authenticate(
+	ctx context.Context,
+	email string,
+	password string,
+) (*domain.DirectoryUser, error) {
+	if email == "" || password == "" {
+		return nil, nil
+	}
+
+	conn, err := ldap.DialURL(a.config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to LDAP server: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetTimeout(time.Until(deadline))
+	}
+
+	if a.config.StartTLS {
+		serverURL, err := url.Parse(a.config.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LDAP URL: %w", err)
+		}
+		if err := conn.StartTLS(&tls.Config{ServerName: serverURL.Hostname()}); err != nil {
+			return nil, fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	if err := conn.Bind(a.config.BindDN, a.config.BindPassword); err != nil {
+		return nil, fmt.Errorf("failed to bind service account: %w", err)
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		a.config.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2,
+		0,
+		false,
+		fmt.Sprintf(a.config.UserFilter, ldap.EscapeFilter(email)),
+		[]string{"mail", "cn", "displayName", "memberOf"},
+		nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search directory: %w", err)
+	}
+
+	if len(result.Entries) != 1 {
+		return nil, nil
+	}
+
+	entry := result.Entries[0]
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to bind user: %w", err)
+	}
+
+	name := entry.GetAttributeValue("displayName")
+	if name == "" {
+		name = entry.GetAttributeValue("cn")
+	}
+
+	mail := entry.GetAttributeValue("mail")
+	if mail == "" {
+		return nil, errors.New("directory entry has no mail attribute")
+	}
+
+	return &domain.DirectoryUser{
+		Email: mail,
+		Name:  name,
+		Role:  a.resolveRole(entry.GetAttributeValues("memberOf")),
+	}, nil
+}
+
+func (a *Authenticator) resolveRole(groups []string) string {
+	roles := map[string]bool{}
+	for _, group := range groups {
+		if role, ok := a.config.GroupRoles[normalizeDN(group)]; ok {
+			roles[role] = true
+		}
+	}
+
+	for _, role := range rolePriority {
+		if roles[role] {
+			return role
+		}
+	}
+
+	return domain.RoleUser
+}
+
+func normalizeDN(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(dn))
+	}
+
+	parts := make([]string, 0, len(parsed.RDNs))
+	for _, rdn := range parsed.RDNs {
+		attributes := make([]string, 0, len(rdn.Attributes))
+		for _, attribute := range rdn.Attributes {
+			attributes = append(attributes, strings.ToLower(attribute.Type)+"="+strings.ToLower(attribute.Value))
+		}
+		parts = append(parts, strings.Join(attributes, "+"))
+	}
+
+	return strings.Join(parts, ",")
+}
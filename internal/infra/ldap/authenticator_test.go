@@ -0,0 +1,277 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+const (
+	serviceDN       = "cn=service,dc=example,dc=com"
+	servicePassword = "service-secret"
+)
+
+type directoryEntry struct {
+	dn         string
+	password   string
+	attributes map[string][]string
+}
+
+// stubDirectory is a minimal in-process LDAP server that understands simple
+// binds and equality searches, which is all the authenticator needs.
+type stubDirectory struct {
+	listener net.Listener
+	entries  []directoryEntry
+}
+
+func newStubDirectory(t *testing.T, entries ...directoryEntry) *stubDirectory {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start LDAP stub: %v", err)
+	}
+
+	directory := &stubDirectory{listener: listener, entries: entries}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go directory.serve(conn)
+		}
+	}()
+
+	return directory
+}
+
+func (d *stubDirectory) URL() string {
+	return "ldap://" + d.listener.Addr().String()
+}
+
+func (d *stubDirectory) serve(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+
+		messageId := packet.Children[0].Value.(int64)
+		operation := packet.Children[1]
+
+		switch operation.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := operation.Children[1].Data.String()
+			password := operation.Children[2].Data.String()
+			conn.Write(ldapResult(messageId, ldap.ApplicationBindResponse, d.bind(dn, password)).Bytes())
+		case ldap.ApplicationSearchRequest:
+			filter, _ := ldap.DecompileFilter(operation.Children[6])
+			for _, entry := range d.search(filter) {
+				conn.Write(searchEntry(messageId, entry).Bytes())
+			}
+			conn.Write(ldapResult(messageId, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess).Bytes())
+		default:
+			return
+		}
+	}
+}
+
+func (d *stubDirectory) bind(dn, password string) int {
+	if dn == serviceDN && password == servicePassword {
+		return ldap.LDAPResultSuccess
+	}
+
+	for _, entry := range d.entries {
+		if entry.dn == dn && entry.password == password {
+			return ldap.LDAPResultSuccess
+		}
+	}
+
+	return ldap.LDAPResultInvalidCredentials
+}
+
+func (d *stubDirectory) search(filter string) []directoryEntry {
+	matches := []directoryEntry{}
+
+	for _, entry := range d.entries {
+		for _, mail := range entry.attributes["mail"] {
+			if filter == fmt.Sprintf("(mail=%s)", ldap.EscapeFilter(mail)) {
+				matches = append(matches, entry)
+			}
+		}
+	}
+
+	return matches
+}
+
+func ldapResult(messageId int64, tag ber.Tag, code int) *ber.Packet {
+	operation := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	operation.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+	operation.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	operation.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+
+	return ldapMessage(messageId, operation)
+}
+
+func searchEntry(messageId int64, entry directoryEntry) *ber.Packet {
+	operation := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+	operation.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.dn, ""))
+
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	for name, values := range entry.attributes {
+		attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, value := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, ""))
+		}
+
+		attribute.AppendChild(set)
+		attributes.AppendChild(attribute)
+	}
+	operation.AppendChild(attributes)
+
+	return ldapMessage(messageId, operation)
+}
+
+func ldapMessage(messageId int64, operation *ber.Packet) *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageId, ""))
+	packet.AppendChild(operation)
+
+	return packet
+}
+
+func TestAuthenticator(t *testing.T) {
+	directory := newStubDirectory(t,
+		directoryEntry{
+			dn:       "uid=jane,ou=people,dc=example,dc=com",
+			password: "jane-secret",
+			attributes: map[string][]string{
+				"mail":        {"jane@example.com"},
+				"cn":          {"jane"},
+				"displayName": {"Jane Doe"},
+				"memberOf": {
+					"cn=Staff,ou=groups,dc=example,dc=com",
+					"CN=Desk-Admins,OU=Groups,DC=example,DC=com",
+				},
+			},
+		},
+		directoryEntry{
+			dn:       "uid=john,ou=people,dc=example,dc=com",
+			password: "john-secret",
+			attributes: map[string][]string{
+				"mail":     {"john@example.com"},
+				"cn":       {"John Smith"},
+				"memberOf": {"cn=staff,ou=groups,dc=example,dc=com"},
+			},
+		},
+	)
+
+	config := Config{
+		URL:          directory.URL(),
+		BindDN:       serviceDN,
+		BindPassword: servicePassword,
+		BaseDN:       "ou=people,dc=example,dc=com",
+		GroupRoles: ParseGroupRoles(
+			"admin=cn=desk-admins,ou=groups,dc=example,dc=com;" +
+				"approver=cn=desk-approvers,ou=groups,dc=example,dc=com",
+		),
+	}
+	ctx := context.Background()
+
+	t.Run("should authenticate and map groups to role", func(t *testing.T) {
+		user, err := NewAuthenticator(config).Authenticate(ctx, "jane@example.com", "jane-secret")
+
+		if err != nil {
+			t.Fatalf("Authenticate failed: %v", err)
+		}
+		if user == nil {
+			t.Fatal("expected directory user")
+		}
+		if user.Email != "jane@example.com" || user.Name != "Jane Doe" {
+			t.Errorf("Unexpected user: %+v", user)
+		}
+		if user.Role != domain.RoleAdmin {
+			t.Errorf("Expected role %s, got %s", domain.RoleAdmin, user.Role)
+		}
+	})
+
+	t.Run("should default to user role without mapped groups", func(t *testing.T) {
+		user, err := NewAuthenticator(config).Authenticate(ctx, "john@example.com", "john-secret")
+
+		if err != nil {
+			t.Fatalf("Authenticate failed: %v", err)
+		}
+		if user.Role != domain.RoleUser {
+			t.Errorf("Expected role %s, got %s", domain.RoleUser, user.Role)
+		}
+		if user.Name != "John Smith" {
+			t.Errorf("Expected name John Smith, got %s", user.Name)
+		}
+	})
+
+	t.Run("should reject wrong password", func(t *testing.T) {
+		user, err := NewAuthenticator(config).Authenticate(ctx, "jane@example.com", "wrong")
+
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if user != nil {
+			t.Error("user should be nil for wrong password")
+		}
+	})
+
+	t.Run("should reject empty password without binding", func(t *testing.T) {
+		user, err := NewAuthenticator(config).Authenticate(ctx, "jane@example.com", "")
+
+		if err != nil || user != nil {
+			t.Errorf("expected no user and no error, got %+v, %v", user, err)
+		}
+	})
+
+	t.Run("should return nil for unknown email", func(t *testing.T) {
+		user, err := NewAuthenticator(config).Authenticate(ctx, "nobody@example.com", "secret")
+
+		if err != nil || user != nil {
+			t.Errorf("expected no user and no error, got %+v, %v", user, err)
+		}
+	})
+
+	t.Run("should fail when service account cannot bind", func(t *testing.T) {
+		badConfig := config
+		badConfig.BindPassword = "wrong"
+
+		_, err := NewAuthenticator(badConfig).Authenticate(ctx, "jane@example.com", "jane-secret")
+
+		if err == nil {
+			t.Error("expected error for service bind failure")
+		}
+	})
+}
+
+func TestParseGroupRoles(t *testing.T) {
+	t.Run("should parse role and group pairs", func(t *testing.T) {
+		groupRoles := ParseGroupRoles("admin=cn=admins,dc=example,dc=com; approver=cn=approvers,dc=example,dc=com;invalid")
+
+		if len(groupRoles) != 2 {
+			t.Fatalf("Expected 2 mappings, got %d", len(groupRoles))
+		}
+		if groupRoles["cn=admins,dc=example,dc=com"] != domain.RoleAdmin {
+			t.Errorf("Expected admin mapping, got %v", groupRoles)
+		}
+		if groupRoles["cn=approvers,dc=example,dc=com"] != domain.RoleApprover {
+			t.Errorf("Expected approver mapping, got %v", groupRoles)
+		}
+	})
+}
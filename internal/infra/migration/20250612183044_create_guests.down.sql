@@ -0,0 +1,3 @@
+ALTER TABLE reservations DROP COLUMN IF EXISTS guest_id;
+
+DROP TABLE IF EXISTS guests;
@@ -0,0 +1,11 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE guests (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	name TEXT NOT NULL,
+	email TEXT NOT NULL,
+	host_id UUID NOT NULL REFERENCES users(id),
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE reservations ADD COLUMN guest_id UUID REFERENCES guests(id);
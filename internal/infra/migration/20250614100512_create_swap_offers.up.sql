@@ -0,0 +1,13 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE swap_offers (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	reservation_id UUID NOT NULL REFERENCES reservations(id),
+	counter_reservation_id UUID NOT NULL REFERENCES reservations(id),
+	from_user_id UUID NOT NULL REFERENCES users(id),
+	to_user_id UUID NOT NULL REFERENCES users(id),
+	status TEXT NOT NULL CHECK (status IN ('pending', 'accepted', 'declined', 'expired')) DEFAULT 'pending',
+	expires_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+ALTER TABLE users
+	ADD COLUMN role TEXT NOT NULL CHECK (role IN ('user', 'approver', 'admin')) DEFAULT 'user';
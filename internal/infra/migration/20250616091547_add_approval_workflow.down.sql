@@ -0,0 +1,16 @@
+ALTER TABLE reservations
+	DROP COLUMN IF EXISTS reviewed_at,
+	DROP COLUMN IF EXISTS reviewed_by;
+
+UPDATE reservations SET status = 'cancelled' WHERE status = 'rejected';
+
+ALTER TABLE reservations DROP CONSTRAINT IF EXISTS reservations_status_check;
+ALTER TABLE reservations
+	ADD CONSTRAINT reservations_status_check
+	CHECK (status IN ('pending', 'confirmed', 'cancelled'));
+
+ALTER TABLE desks
+	DROP COLUMN IF EXISTS requires_approval,
+	DROP COLUMN IF EXISTS zone_id;
+
+DROP TABLE IF EXISTS zones;
@@ -0,0 +1,20 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE zones (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	name TEXT NOT NULL UNIQUE,
+	requires_approval BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+ALTER TABLE desks
+	ADD COLUMN zone_id UUID REFERENCES zones(id),
+	ADD COLUMN requires_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE reservations DROP CONSTRAINT IF EXISTS reservations_status_check;
+ALTER TABLE reservations
+	ADD CONSTRAINT reservations_status_check
+	CHECK (status IN ('pending', 'confirmed', 'cancelled', 'rejected'));
+
+ALTER TABLE reservations
+	ADD COLUMN reviewed_by UUID REFERENCES users(id),
+	ADD COLUMN reviewed_at TIMESTAMP;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS lottery_draws;
+
+DROP TABLE IF EXISTS lottery_requests;
+
+ALTER TABLE desks DROP COLUMN IF EXISTS site_id;
+
+DROP TABLE IF EXISTS sites;
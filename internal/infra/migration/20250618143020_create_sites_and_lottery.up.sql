@@ -0,0 +1,37 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE sites (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	name TEXT NOT NULL UNIQUE,
+	lottery_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+	lottery_cutoff_hours INTEGER NOT NULL DEFAULT 18 CHECK (lottery_cutoff_hours >= 0)
+);
+
+ALTER TABLE desks ADD COLUMN site_id UUID REFERENCES sites(id);
+
+-- Seed data
+INSERT INTO sites (name) VALUES ('Headquarters');
+UPDATE desks SET site_id = (SELECT id FROM sites WHERE name = 'Headquarters');
+
+CREATE TABLE lottery_requests (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	site_id UUID NOT NULL REFERENCES sites(id),
+	user_id UUID NOT NULL REFERENCES users(id),
+	date DATE NOT NULL,
+	status TEXT NOT NULL CHECK (status IN ('pending', 'won', 'waitlisted')) DEFAULT 'pending',
+	desk_id UUID REFERENCES desks(id),
+	waitlist_position INTEGER,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	UNIQUE (site_id, user_id, date)
+);
+
+CREATE TABLE lottery_draws (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	site_id UUID NOT NULL REFERENCES sites(id),
+	date DATE NOT NULL,
+	seed BIGINT NOT NULL,
+	results JSONB NOT NULL,
+	drawn_by UUID NOT NULL REFERENCES users(id),
+	drawn_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	UNIQUE (site_id, date)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE users
+	DROP COLUMN IF EXISTS deactivated_at,
+	DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,3 @@
+ALTER TABLE users
+	ADD COLUMN external_id TEXT UNIQUE,
+	ADD COLUMN deactivated_at TIMESTAMP;
@@ -0,0 +1,25 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE login_attempts (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	email TEXT NOT NULL,
+	ip_address TEXT NOT NULL,
+	succeeded BOOLEAN NOT NULL,
+	attempted_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX login_attempts_email_idx ON login_attempts (email, attempted_at);
+CREATE INDEX login_attempts_ip_address_idx ON login_attempts (ip_address, attempted_at);
+
+CREATE TABLE audit_logs (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	actor_id UUID REFERENCES users(id),
+	action TEXT NOT NULL,
+	target_type TEXT NOT NULL,
+	target_id TEXT,
+	ip_address TEXT,
+	details JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX audit_logs_action_idx ON audit_logs (action, created_at);
@@ -0,0 +1,4 @@
+ALTER TABLE users DROP COLUMN IF EXISTS notification_preferences;
+ALTER TABLE users DROP COLUMN IF EXISTS time_zone;
+ALTER TABLE users DROP COLUMN IF EXISTS default_desk_id;
+ALTER TABLE users DROP COLUMN IF EXISTS preferred_site_id;
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN preferred_site_id UUID REFERENCES sites(id);
+ALTER TABLE users ADD COLUMN default_desk_id UUID REFERENCES desks(id);
+ALTER TABLE users ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'UTC';
+ALTER TABLE users ADD COLUMN notification_preferences JSONB NOT NULL
+	DEFAULT '{"reservation_updates": true, "approval_requests": true, "reminders": true}';
@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN team TEXT;
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX users_team_idx ON users (team);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS reservations_date_idx;
+
+ALTER TABLE users DROP COLUMN IF EXISTS anonymized_at;
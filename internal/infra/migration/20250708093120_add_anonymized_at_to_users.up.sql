@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN anonymized_at TIMESTAMP;
+
+CREATE INDEX reservations_date_idx ON reservations (date);
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS reservations_organisation_id_date_idx;
+DROP INDEX IF EXISTS desks_organisation_id_idx;
+DROP INDEX IF EXISTS users_organisation_id_idx;
+
+ALTER TABLE sites DROP CONSTRAINT IF EXISTS sites_organisation_id_name_key;
+ALTER TABLE sites ADD CONSTRAINT sites_name_key UNIQUE (name);
+
+ALTER TABLE reservations DROP COLUMN IF EXISTS organisation_id;
+ALTER TABLE desks DROP COLUMN IF EXISTS organisation_id;
+ALTER TABLE sites DROP COLUMN IF EXISTS organisation_id;
+ALTER TABLE users DROP COLUMN IF EXISTS organisation_id;
+
+DROP TABLE IF EXISTS organisations;
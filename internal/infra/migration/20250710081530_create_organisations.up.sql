@@ -0,0 +1,32 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE organisations (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	name TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Seed data
+INSERT INTO organisations (id, name) VALUES ('00000000-0000-0000-0000-000000000001', 'Default');
+
+ALTER TABLE users ADD COLUMN organisation_id UUID REFERENCES organisations(id);
+ALTER TABLE sites ADD COLUMN organisation_id UUID REFERENCES organisations(id);
+ALTER TABLE desks ADD COLUMN organisation_id UUID REFERENCES organisations(id);
+ALTER TABLE reservations ADD COLUMN organisation_id UUID REFERENCES organisations(id);
+
+UPDATE users SET organisation_id = '00000000-0000-0000-0000-000000000001';
+UPDATE sites SET organisation_id = '00000000-0000-0000-0000-000000000001';
+UPDATE desks SET organisation_id = '00000000-0000-0000-0000-000000000001';
+UPDATE reservations SET organisation_id = '00000000-0000-0000-0000-000000000001';
+
+ALTER TABLE users ALTER COLUMN organisation_id SET NOT NULL;
+ALTER TABLE sites ALTER COLUMN organisation_id SET NOT NULL;
+ALTER TABLE desks ALTER COLUMN organisation_id SET NOT NULL;
+ALTER TABLE reservations ALTER COLUMN organisation_id SET NOT NULL;
+
+ALTER TABLE sites DROP CONSTRAINT sites_name_key;
+ALTER TABLE sites ADD CONSTRAINT sites_organisation_id_name_key UNIQUE (organisation_id, name);
+
+CREATE INDEX users_organisation_id_idx ON users (organisation_id);
+CREATE INDEX desks_organisation_id_idx ON desks (organisation_id);
+CREATE INDEX reservations_organisation_id_date_idx ON reservations (organisation_id, date);
@@ -0,0 +1,10 @@
+DROP POLICY IF EXISTS reservations_tenant_isolation ON reservations;
+DROP POLICY IF EXISTS desks_tenant_isolation ON desks;
+DROP POLICY IF EXISTS users_tenant_isolation ON users;
+
+ALTER TABLE reservations NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE reservations DISABLE ROW LEVEL SECURITY;
+ALTER TABLE desks NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE desks DISABLE ROW LEVEL SECURITY;
+ALTER TABLE users NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE users DISABLE ROW LEVEL SECURITY;
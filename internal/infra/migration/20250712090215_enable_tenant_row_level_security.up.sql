@@ -0,0 +1,28 @@
+-- Policies are keyed on app.tenant_id, which the connection sets from the
+-- authenticated request. An empty setting (login, SCIM, retention jobs) is
+-- unrestricted. Superusers and BYPASSRLS roles skip these policies, so the
+-- API must connect as an ordinary role for them to apply.
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users FORCE ROW LEVEL SECURITY;
+ALTER TABLE desks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE desks FORCE ROW LEVEL SECURITY;
+ALTER TABLE reservations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE reservations FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY users_tenant_isolation ON users
+	USING (
+		NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+		OR organisation_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid
+	);
+
+CREATE POLICY desks_tenant_isolation ON desks
+	USING (
+		NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+		OR organisation_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid
+	);
+
+CREATE POLICY reservations_tenant_isolation ON reservations
+	USING (
+		NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+		OR organisation_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid
+	);
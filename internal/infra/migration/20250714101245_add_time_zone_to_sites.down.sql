@@ -0,0 +1,3 @@
+ALTER TABLE reservations ALTER COLUMN date TYPE TIMESTAMP USING date::timestamp;
+
+ALTER TABLE sites DROP COLUMN time_zone;
@@ -0,0 +1,4 @@
+ALTER TABLE sites ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'UTC';
+
+-- Reservation days are calendar dates local to the desk's site.
+ALTER TABLE reservations ALTER COLUMN date TYPE DATE USING date::date;
@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs;
+DROP FUNCTION IF EXISTS audit_logs_append_only();
+
+ALTER TABLE reservations DROP COLUMN checked_in_at;
+
+DROP INDEX IF EXISTS audit_logs_target_idx;
+DROP INDEX IF EXISTS audit_logs_actor_id_idx;
+DROP INDEX IF EXISTS audit_logs_organisation_id_idx;
+
+ALTER TABLE audit_logs DROP COLUMN after;
+ALTER TABLE audit_logs DROP COLUMN before;
+ALTER TABLE audit_logs DROP COLUMN request_id;
+ALTER TABLE audit_logs DROP COLUMN organisation_id;
@@ -0,0 +1,25 @@
+ALTER TABLE audit_logs ADD COLUMN organisation_id UUID REFERENCES organisations(id);
+ALTER TABLE audit_logs ADD COLUMN request_id TEXT;
+ALTER TABLE audit_logs ADD COLUMN before JSONB;
+ALTER TABLE audit_logs ADD COLUMN after JSONB;
+
+CREATE INDEX audit_logs_organisation_id_idx ON audit_logs (organisation_id, created_at);
+CREATE INDEX audit_logs_actor_id_idx ON audit_logs (actor_id, created_at);
+CREATE INDEX audit_logs_target_idx ON audit_logs (target_type, target_id, created_at);
+
+ALTER TABLE reservations ADD COLUMN checked_in_at TIMESTAMP;
+
+-- Audit entries are append-only. Erasure is the one exception and has to opt
+-- in with app.audit_redaction inside its transaction.
+CREATE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'UPDATE' AND current_setting('app.audit_redaction', true) = 'on' THEN
+		RETURN NEW;
+	END IF;
+	RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_logs_append_only
+BEFORE UPDATE OR DELETE ON audit_logs
+FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
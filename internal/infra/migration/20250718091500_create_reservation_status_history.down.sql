@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS reservations_record_status ON reservations;
+DROP FUNCTION IF EXISTS reservations_record_status();
+
+ALTER TABLE reservations DROP COLUMN status_changed_by;
+
+DROP TABLE IF EXISTS reservation_status_history;
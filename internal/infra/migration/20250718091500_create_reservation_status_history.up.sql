@@ -0,0 +1,75 @@
+CREATE TABLE reservation_status_history (
+	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+	reservation_id UUID NOT NULL REFERENCES reservations(id) ON DELETE CASCADE,
+	organisation_id UUID REFERENCES organisations(id),
+	from_status TEXT,
+	to_status TEXT NOT NULL,
+	actor_id UUID REFERENCES users(id),
+	changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX reservation_status_history_reservation_id_idx ON reservation_status_history (reservation_id, changed_at);
+
+ALTER TABLE reservations ADD COLUMN status_changed_by UUID REFERENCES users(id);
+
+-- Every status write goes through this trigger so the history cannot drift
+-- from the reservation. Writers set status_changed_by alongside status; new
+-- reservations default to their owner.
+CREATE FUNCTION reservations_record_status() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'UPDATE' AND OLD.status IS NOT DISTINCT FROM NEW.status THEN
+		RETURN NEW;
+	END IF;
+
+	INSERT INTO reservation_status_history (reservation_id, organisation_id, from_status, to_status, actor_id)
+	VALUES (
+		NEW.id,
+		NEW.organisation_id,
+		CASE WHEN TG_OP = 'UPDATE' THEN OLD.status END,
+		NEW.status,
+		CASE WHEN TG_OP = 'INSERT' THEN COALESCE(NEW.status_changed_by, NEW.user_id) ELSE NEW.status_changed_by END
+	);
+
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER reservations_record_status
+AFTER INSERT OR UPDATE OF status ON reservations
+FOR EACH ROW EXECUTE FUNCTION reservations_record_status();
+
+-- Existing reservations get a best-effort history. Cancellations were not
+-- attributed before, so they are recorded without an actor, and unreviewed
+-- cancelled reservations are assumed to have been confirmed first.
+INSERT INTO reservation_status_history (reservation_id, organisation_id, from_status, to_status, actor_id, changed_at)
+SELECT
+	id,
+	organisation_id,
+	NULL,
+	CASE
+		WHEN reviewed_at IS NOT NULL THEN 'pending'
+		WHEN status = 'cancelled' THEN 'confirmed'
+		ELSE status
+	END,
+	user_id,
+	created_at
+FROM reservations;
+
+INSERT INTO reservation_status_history (reservation_id, organisation_id, from_status, to_status, actor_id, changed_at)
+SELECT id, organisation_id, 'pending', CASE WHEN status = 'cancelled' THEN 'confirmed' ELSE status END, reviewed_by, reviewed_at
+FROM reservations
+WHERE reviewed_at IS NOT NULL;
+
+INSERT INTO reservation_status_history (reservation_id, organisation_id, from_status, to_status, actor_id, changed_at)
+SELECT id, organisation_id, 'confirmed', 'cancelled', NULL, updated_at
+FROM reservations
+WHERE status = 'cancelled';
+
+ALTER TABLE reservation_status_history ENABLE ROW LEVEL SECURITY;
+ALTER TABLE reservation_status_history FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY reservation_status_history_tenant_isolation ON reservation_status_history
+	USING (
+		NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+		OR organisation_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid
+	);
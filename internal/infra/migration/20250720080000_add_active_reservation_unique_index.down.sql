@@ -0,0 +1 @@
+DROP INDEX IF EXISTS reservations_active_desk_date_key;
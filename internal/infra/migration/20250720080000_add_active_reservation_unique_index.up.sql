@@ -0,0 +1,17 @@
+-- Earlier releases could double book a desk under concurrent requests. Keep
+-- the oldest active booking of each desk and day and cancel the rest so the
+-- index can be built.
+UPDATE reservations r
+SET status = 'cancelled', updated_at = NOW()
+WHERE r.status IN ('pending', 'confirmed')
+AND EXISTS (
+	SELECT 1 FROM reservations o
+	WHERE o.desk_id = r.desk_id
+	AND o.date = r.date
+	AND o.status IN ('pending', 'confirmed')
+	AND (o.created_at, o.id) < (r.created_at, r.id)
+);
+
+CREATE UNIQUE INDEX reservations_active_desk_date_key
+	ON reservations (desk_id, date)
+	WHERE status IN ('pending', 'confirmed');
@@ -0,0 +1,27 @@
+DROP POLICY IF EXISTS users_tenant_isolation ON users;
+CREATE POLICY users_tenant_isolation ON users
+	USING (
+		NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+		OR organisation_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid
+	);
+
+DROP POLICY IF EXISTS desks_tenant_isolation ON desks;
+CREATE POLICY desks_tenant_isolation ON desks
+	USING (
+		NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+		OR organisation_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid
+	);
+
+DROP POLICY IF EXISTS reservations_tenant_isolation ON reservations;
+CREATE POLICY reservations_tenant_isolation ON reservations
+	USING (
+		NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+		OR organisation_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid
+	);
+
+DROP POLICY IF EXISTS reservation_status_history_tenant_isolation ON reservation_status_history;
+CREATE POLICY reservation_status_history_tenant_isolation ON reservation_status_history
+	USING (
+		NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+		OR organisation_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid
+	);
@@ -0,0 +1,32 @@
+-- Policies now fail closed: a connection without app.tenant_id sees no rows.
+-- Work that must span tenants (sign-up, login and other pre-authentication
+-- lookups) sets app.bypass_rls explicitly. Data migrations touching these
+-- tables must run SET app.bypass_rls = 'on' first.
+
+DROP POLICY IF EXISTS users_tenant_isolation ON users;
+CREATE POLICY users_tenant_isolation ON users
+	USING (
+		current_setting('app.bypass_rls', true) = 'on'
+		OR organisation_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid
+	);
+
+DROP POLICY IF EXISTS desks_tenant_isolation ON desks;
+CREATE POLICY desks_tenant_isolation ON desks
+	USING (
+		current_setting('app.bypass_rls', true) = 'on'
+		OR organisation_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid
+	);
+
+DROP POLICY IF EXISTS reservations_tenant_isolation ON reservations;
+CREATE POLICY reservations_tenant_isolation ON reservations
+	USING (
+		current_setting('app.bypass_rls', true) = 'on'
+		OR organisation_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid
+	);
+
+DROP POLICY IF EXISTS reservation_status_history_tenant_isolation ON reservation_status_history;
+CREATE POLICY reservation_status_history_tenant_isolation ON reservation_status_history
+	USING (
+		current_setting('app.bypass_rls', true) = 'on'
+		OR organisation_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid
+	);
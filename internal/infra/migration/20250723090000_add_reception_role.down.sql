@@ -0,0 +1,6 @@
+SET app.bypass_rls = 'on';
+
+UPDATE users SET role = 'user' WHERE role = 'reception';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users
+	ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'approver', 'admin'));
@@ -0,0 +1 @@
+ALTER TABLE organisations DROP COLUMN IF EXISTS reservation_retention_days;
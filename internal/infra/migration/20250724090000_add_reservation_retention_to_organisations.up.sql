@@ -0,0 +1,3 @@
+-- NULL falls back to RESERVATION_RETENTION_DAYS; 0 keeps reservations forever.
+ALTER TABLE organisations
+	ADD COLUMN reservation_retention_days INTEGER CHECK (reservation_retention_days >= 0);
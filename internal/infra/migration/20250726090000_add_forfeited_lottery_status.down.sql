@@ -0,0 +1,6 @@
+UPDATE lottery_requests
+SET status = CASE WHEN desk_id IS NULL THEN 'waitlisted' ELSE 'won' END
+WHERE status = 'forfeited';
+ALTER TABLE lottery_requests DROP CONSTRAINT IF EXISTS lottery_requests_status_check;
+ALTER TABLE lottery_requests
+	ADD CONSTRAINT lottery_requests_status_check CHECK (status IN ('pending', 'won', 'waitlisted'));
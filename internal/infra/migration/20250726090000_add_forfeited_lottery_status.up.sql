@@ -0,0 +1,6 @@
+-- Winners who cancel or are already at their daily limit forfeit their desk to
+-- the waitlist.
+ALTER TABLE lottery_requests DROP CONSTRAINT IF EXISTS lottery_requests_status_check;
+ALTER TABLE lottery_requests
+	ADD CONSTRAINT lottery_requests_status_check
+	CHECK (status IN ('pending', 'won', 'waitlisted', 'forfeited'));
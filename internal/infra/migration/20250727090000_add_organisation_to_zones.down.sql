@@ -0,0 +1,3 @@
+ALTER TABLE zones DROP CONSTRAINT IF EXISTS zones_organisation_id_name_key;
+ALTER TABLE zones ADD CONSTRAINT zones_name_key UNIQUE (name);
+ALTER TABLE zones DROP COLUMN IF EXISTS organisation_id;
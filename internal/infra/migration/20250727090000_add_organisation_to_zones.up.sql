@@ -0,0 +1,14 @@
+SET app.bypass_rls = 'on';
+
+ALTER TABLE zones ADD COLUMN organisation_id UUID REFERENCES organisations(id);
+
+UPDATE zones z
+SET organisation_id = COALESCE(
+	(SELECT d.organisation_id FROM desks d WHERE d.zone_id = z.id LIMIT 1),
+	'00000000-0000-0000-0000-000000000001'
+);
+
+ALTER TABLE zones ALTER COLUMN organisation_id SET NOT NULL;
+
+ALTER TABLE zones DROP CONSTRAINT zones_name_key;
+ALTER TABLE zones ADD CONSTRAINT zones_organisation_id_name_key UNIQUE (organisation_id, name);
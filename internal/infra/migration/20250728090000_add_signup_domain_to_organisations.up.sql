@@ -0,0 +1,6 @@
+-- Sign-ups, OIDC and LDAP logins arrive without a tenant and join the
+-- organisation claiming their email domain, or the default organisation when
+-- none does. Domains are claimed by operators, not tenant admins, so one
+-- tenant cannot capture another's sign-ups.
+ALTER TABLE organisations
+	ADD COLUMN signup_domain TEXT UNIQUE CHECK (signup_domain = LOWER(signup_domain));
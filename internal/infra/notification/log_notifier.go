@@ -0,0 +1,22 @@
+package notification
+
+import (
+	"context"
+	"strings"
+
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type LogNotifier struct{}
+
+func (n *LogNotifier) Notify(
+	ctx context.Context,
+	recipients []string,
+	subject string,
+	body string,
+) error {
+	log := pkg.GetLogger()
+
+	log.Info("Notification to [%s]: %s - %s", strings.Join(recipients, ", "), subject, body)
+	return nil
+}
@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type LogMailSender struct{}
+
+func (s *LogMailSender) SendMail(ctx context.Context, to string, subject string, body string) error {
+	log := pkg.GetLogger()
+
+	log.Info("Mail to %s: %s - %s", to, subject, body)
+	return nil
+}
+
+type SMTPMailSender struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func NewMailSenderFromEnv() domain.MailSenderInterface {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return &LogMailSender{}
+	}
+
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+
+	return &SMTPMailSender{
+		Host:     host,
+		Port:     port,
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+	}
+}
+
+func (s *SMTPMailSender) SendMail(ctx context.Context, to string, subject string, body string) error {
+	if strings.ContainsAny(to+subject, "\r\n") {
+		return fmt.Errorf("invalid mail header value")
+	}
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+
+	message := strings.Join([]string{
+		"From: " + s.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	address := net.JoinHostPort(s.Host, s.Port)
+	if err := smtp.SendMail(address, auth, s.From, []string{to}, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+type Config struct {
+	IssuerURL    string
+	ClientId     string
+	ClientSecret string
+	RedirectURL  string
+}
+
+type Provider struct {
+	issuer   string
+	oauth    oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+type idTokenClaims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+func NewProvider(ctx context.Context, config Config) (*Provider, error) {
+	provider, err := gooidc.NewProvider(ctx, config.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC provider: %w", err)
+	}
+
+	return &Provider{
+		issuer: config.IssuerURL,
+		oauth: oauth2.Config{
+			ClientID:     config.ClientId,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       []string{gooidc.ScopeOpenID, "email", "profile"},
+		},
+		verifier: provider.Verifier(&gooidc.Config{ClientID: config.ClientId}),
+	}, nil
+}
+
+func (p *Provider) Issuer() string {
+	return p.issuer
+}
+
+func (p *Provider) AuthCodeURL(state, nonce, verifier string) string {
+	return p.oauth.AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier))
+}
+
+func (p *Provider) Exchange(
+	ctx context.Context,
+	code string,
+	verifier string,
+	nonce string,
+) (*domain.OIDCIdentity, error) {
+	token, err := p.oauth.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify id_token: %w", err)
+	}
+
+	if idToken.Nonce != nonce {
+		return nil, errors.New("id_token nonce mismatch")
+	}
+
+	var claims idTokenClaims
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to parse id_token claims: %w", err)
+	}
+
+	return &domain.OIDCIdentity{
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+var (
+	defaultProvider   *Provider
+	defaultProviderMu sync.Mutex
+)
+
+func DefaultProvider(ctx context.Context) (*Provider, error) {
+	defaultProviderMu.Lock()
+	defer defaultProviderMu.Unlock()
+
+	if defaultProvider != nil {
+		return defaultProvider, nil
+	}
+
+	config := Config{
+		IssuerURL:    os.Getenv("OIDC_ISSUER_URL"),
+		ClientId:     os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+	}
+
+	if config.IssuerURL == "" || config.ClientId == "" {
+		return nil, errors.New("missing OIDC_ISSUER_URL or OIDC_CLIENT_ID in environment")
+	}
+
+	provider, err := NewProvider(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultProvider = provider
+	return defaultProvider, nil
+}
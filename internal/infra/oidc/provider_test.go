@@ -0,0 +1,162 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type mockProvider struct {
+	server    *httptest.Server
+	keys      *pkg.KeyManager
+	challenge string
+	nonce     string
+	email     string
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	keys, err := pkg.NewKeyManager(pkg.AlgorithmRS256, 0)
+	if err != nil {
+		t.Fatalf("failed to create signing keys: %v", err)
+	}
+
+	mock := &mockProvider{keys: keys, email: "jane@example.com"}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                mock.server.URL,
+			"authorization_endpoint":                mock.server.URL + "/authorize",
+			"token_endpoint":                        mock.server.URL + "/token",
+			"jwks_uri":                              mock.server.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{pkg.AlgorithmRS256},
+		})
+	})
+
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(mock.keys.JWKS())
+	})
+
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if r.PostForm.Get("code") != "valid-code" ||
+			base64.RawURLEncoding.EncodeToString(sum[:]) != mock.challenge {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		idToken, _ := mock.keys.Sign(jwt.MapClaims{
+			"iss":            mock.server.URL,
+			"aud":            "desk-reservation",
+			"sub":            "subject-1",
+			"nonce":          mock.nonce,
+			"email":          mock.email,
+			"email_verified": true,
+			"name":           "Jane Doe",
+			"iat":            time.Now().Unix(),
+			"exp":            time.Now().Add(time.Minute).Unix(),
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   60,
+			"id_token":     idToken,
+		})
+	})
+
+	mock.server = httptest.NewServer(mux)
+	t.Cleanup(mock.server.Close)
+
+	return mock
+}
+
+func (m *mockProvider) authorize(t *testing.T, provider *Provider, state, nonce, verifier string) {
+	authURL, err := url.Parse(provider.AuthCodeURL(state, nonce, verifier))
+	if err != nil {
+		t.Fatalf("invalid authorization URL: %v", err)
+	}
+
+	query := authURL.Query()
+	if query.Get("code_challenge_method") != "S256" {
+		t.Errorf("Expected code_challenge_method S256, got %s", query.Get("code_challenge_method"))
+	}
+	if query.Get("state") != state {
+		t.Errorf("Expected state %s, got %s", state, query.Get("state"))
+	}
+
+	m.challenge = query.Get("code_challenge")
+	m.nonce = query.Get("nonce")
+}
+
+func TestProvider(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*mockProvider, *Provider) {
+		mock := newMockProvider(t)
+
+		provider, err := NewProvider(ctx, Config{
+			IssuerURL:   mock.server.URL,
+			ClientId:    "desk-reservation",
+			RedirectURL: "http://localhost:8080/auth/oidc/callback",
+		})
+		if err != nil {
+			t.Fatalf("NewProvider failed: %v", err)
+		}
+
+		return mock, provider
+	}
+
+	t.Run("should exchange code for a verified identity", func(t *testing.T) {
+		mock, provider := setup(t)
+		mock.authorize(t, provider, "state", "nonce", "verifier-with-enough-entropy-0123456789abcdef")
+
+		identity, err := provider.Exchange(ctx, "valid-code", "verifier-with-enough-entropy-0123456789abcdef", "nonce")
+		if err != nil {
+			t.Fatalf("Exchange failed: %v", err)
+		}
+
+		if identity.Subject != "subject-1" {
+			t.Errorf("Expected subject subject-1, got %s", identity.Subject)
+		}
+		if identity.Email != "jane@example.com" || !identity.EmailVerified {
+			t.Errorf("Expected verified email jane@example.com, got %s (%v)", identity.Email, identity.EmailVerified)
+		}
+		if provider.Issuer() != mock.server.URL {
+			t.Errorf("Expected issuer %s, got %s", mock.server.URL, provider.Issuer())
+		}
+	})
+
+	t.Run("should reject wrong PKCE verifier", func(t *testing.T) {
+		mock, provider := setup(t)
+		mock.authorize(t, provider, "state", "nonce", "verifier-with-enough-entropy-0123456789abcdef")
+
+		if _, err := provider.Exchange(ctx, "valid-code", "another-verifier", "nonce"); err == nil {
+			t.Error("expected error for wrong code verifier")
+		}
+	})
+
+	t.Run("should reject nonce mismatch", func(t *testing.T) {
+		mock, provider := setup(t)
+		mock.authorize(t, provider, "state", "nonce", "verifier-with-enough-entropy-0123456789abcdef")
+
+		if _, err := provider.Exchange(ctx, "valid-code", "verifier-with-enough-entropy-0123456789abcdef", "other"); err == nil {
+			t.Error("expected error for nonce mismatch")
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type APIKeyRepositoryDb struct {
+	Conn *sqlx.DB
+}
+
+func (db *APIKeyRepositoryDb) SaveAPIKey(ctx context.Context, key domain.CreateAPIKey) (*domain.APIKey, error) {
+	var saved domain.APIKey
+	query := `
+	INSERT INTO api_keys (user_id, name, key_prefix, key_hash, scopes, expires_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING *
+	`
+
+	err := db.Conn.GetContext(
+		ctx,
+		&saved,
+		query,
+		key.UserId,
+		key.Name,
+		key.KeyPrefix,
+		key.KeyHash,
+		pq.Array(key.Scopes),
+		key.ExpiresAt,
+	)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to save api key", err)
+	}
+
+	return &saved, nil
+}
+
+func (db *APIKeyRepositoryDb) ListAPIKeys(ctx context.Context, userId string) ([]domain.APIKey, error) {
+	keys := []domain.APIKey{}
+	query := `
+	SELECT * FROM api_keys
+	WHERE user_id = $1 AND revoked_at IS NULL
+	ORDER BY created_at DESC
+	`
+
+	if err := db.Conn.SelectContext(ctx, &keys, query, userId); err != nil {
+		return nil, pkg.NewInternalServerError("failed to list api keys", err)
+	}
+
+	return keys, nil
+}
+
+func (db *APIKeyRepositoryDb) FindAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	query := "SELECT * FROM api_keys WHERE key_hash = $1"
+
+	err := db.Conn.GetContext(ctx, &key, query, keyHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to find api key", err)
+	}
+
+	return &key, nil
+}
+
+func (db *APIKeyRepositoryDb) TouchAPIKey(ctx context.Context, id string) error {
+	query := "UPDATE api_keys SET last_used_at = NOW() WHERE id = $1"
+
+	if _, err := db.Conn.ExecContext(ctx, query, id); err != nil {
+		return pkg.NewInternalServerError("failed to update api key usage", err)
+	}
+
+	return nil
+}
+
+func (db *APIKeyRepositoryDb) RevokeAPIKey(ctx context.Context, userId string, id string) (bool, error) {
+	query := `
+	UPDATE api_keys
+	SET revoked_at = NOW()
+	WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := db.Conn.ExecContext(ctx, query, id, userId)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to revoke api key", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to revoke api key", err)
+	}
+
+	return rows > 0, nil
+}
@@ -0,0 +1,36 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestRevokeAPIKey(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+
+	db := &APIKeyRepositoryDb{Conn: sqlx.NewDb(mockDB, "sqlmock")}
+	ctx := context.Background()
+
+	t.Run("should only revoke keys owned by the user", func(t *testing.T) {
+		mock.ExpectExec("UPDATE api_keys (.+) WHERE id = \\$1 AND user_id = \\$2").
+			WithArgs("key-1", "user-2").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		revoked, err := db.RevokeAPIKey(ctx, "user-2", "key-1")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if revoked {
+			t.Error("expected key not to be revoked")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
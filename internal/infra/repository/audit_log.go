@@ -0,0 +1,186 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const auditLogColumns = `
+	id, actor_id, action, target_type, target_id, ip_address, request_id,
+	organisation_id, before, after, details, created_at
+`
+
+type AuditLogRepositoryDb struct {
+	Conn *sqlx.DB
+}
+
+func (db *AuditLogRepositoryDb) SaveAuditLog(ctx context.Context, entry domain.CreateAuditLog) error {
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to encode audit details", err)
+	}
+
+	if entry.Details == nil {
+		details = []byte("{}")
+	}
+
+	before, err := encodeAuditSnapshot(entry.Before)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to encode audit snapshot", err)
+	}
+
+	after, err := encodeAuditSnapshot(entry.After)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to encode audit snapshot", err)
+	}
+
+	query := `
+	INSERT INTO audit_logs (
+		actor_id, action, target_type, target_id, ip_address, details,
+		request_id, organisation_id, before, after
+	)
+	VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, NULLIF($7, ''), NULLIF($8, '')::uuid, $9, $10)
+	`
+
+	_, err = db.Conn.ExecContext(
+		ctx,
+		query,
+		entry.ActorId,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetId,
+		contextOr(ctx, utils.ClientIPKey, entry.IpAddress),
+		details,
+		contextOr(ctx, utils.RequestIdKey, entry.RequestId),
+		contextOr(ctx, utils.AuthTenantKey, entry.OrganisationId),
+		before,
+		after,
+	)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to save audit log", err)
+	}
+
+	return nil
+}
+
+func (db *AuditLogRepositoryDb) FindAuditLogsByUser(ctx context.Context, userId string) ([]domain.AuditLog, error) {
+	query := `
+	SELECT ` + auditLogColumns + `
+	FROM audit_logs
+	WHERE actor_id = $1 OR (target_type = 'user' AND target_id = $1::text)
+	ORDER BY created_at
+	`
+
+	logs := []domain.AuditLog{}
+
+	if err := db.Conn.SelectContext(ctx, &logs, query, userId); err != nil {
+		return nil, pkg.NewInternalServerError("failed to find audit logs", err)
+	}
+
+	return logs, nil
+}
+
+func (db *AuditLogRepositoryDb) FindAuditLogs(
+	ctx context.Context,
+	filter domain.AuditLogFilter,
+) ([]domain.AuditLog, int, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	conditions, args := auditLogFilterConditions(filter)
+	args = append(args, tenantId)
+	conditions = append(conditions, fmt.Sprintf("organisation_id = $%d", len(args)))
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := db.Conn.GetContext(ctx, &total, "SELECT COUNT(*) FROM audit_logs "+where, args...); err != nil {
+		return nil, 0, pkg.NewInternalServerError("failed to count audit logs", err)
+	}
+
+	args = append(args, filter.Offset, filter.Limit)
+	query := fmt.Sprintf(
+		"SELECT %s FROM audit_logs %s ORDER BY created_at DESC, id OFFSET $%d LIMIT $%d",
+		auditLogColumns,
+		where,
+		len(args)-1,
+		len(args),
+	)
+
+	logs := []domain.AuditLog{}
+
+	if err := db.Conn.SelectContext(ctx, &logs, query, args...); err != nil {
+		return nil, 0, pkg.NewInternalServerError("failed to find audit logs", err)
+	}
+
+	return logs, total, nil
+}
+
+func auditLogFilterConditions(filter domain.AuditLogFilter) ([]string, []any) {
+	conditions := []string{}
+	args := []any{}
+
+	if filter.ActorId != "" {
+		args = append(args, filter.ActorId)
+		conditions = append(conditions, fmt.Sprintf("actor_id = $%d", len(args)))
+	}
+
+	if filter.Action != "" {
+		args = append(args, filter.Action)
+		conditions = append(conditions, fmt.Sprintf("action = $%d", len(args)))
+	}
+
+	if filter.TargetType != "" {
+		args = append(args, filter.TargetType)
+		conditions = append(conditions, fmt.Sprintf("target_type = $%d", len(args)))
+	}
+
+	if filter.TargetId != "" {
+		args = append(args, filter.TargetId)
+		conditions = append(conditions, fmt.Sprintf("target_id = $%d", len(args)))
+	}
+
+	if filter.RequestId != "" {
+		args = append(args, filter.RequestId)
+		conditions = append(conditions, fmt.Sprintf("request_id = $%d", len(args)))
+	}
+
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	return conditions, args
+}
+
+func encodeAuditSnapshot(snapshot any) ([]byte, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(snapshot)
+}
+
+func contextOr(ctx context.Context, key any, value string) string {
+	if value != "" {
+		return value
+	}
+
+	fromContext, _ := ctx.Value(key).(string)
+	return fromContext
+}
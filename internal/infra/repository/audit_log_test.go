@@ -0,0 +1,98 @@
+package infra
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+)
+
+func setupAuditLogRepositoryTestDB(t *testing.T) (*AuditLogRepositoryDb, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+
+	return &AuditLogRepositoryDb{Conn: sqlx.NewDb(mockDB, "sqlmock")}, mock
+}
+
+func TestSaveAuditLog(t *testing.T) {
+	db, mock := setupAuditLogRepositoryTestDB(t)
+	actorId := "user-1"
+
+	t.Run("should take request id, ip and tenant from context", func(t *testing.T) {
+		ctx := utils.SetContextValue(tenantContext(tenantA), utils.RequestIdKey, "req-1")
+		ctx = utils.SetContextValue(ctx, utils.ClientIPKey, "10.0.0.5")
+
+		mock.ExpectExec("INSERT INTO audit_logs").
+			WithArgs(
+				&actorId,
+				domain.AuditActionReservationCancelled,
+				domain.AuditTargetReservation,
+				"res-1",
+				"10.0.0.5",
+				[]byte("{}"),
+				"req-1",
+				tenantA,
+				[]byte(`{"status":"confirmed"}`),
+				[]byte(`{"status":"cancelled"}`),
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := db.SaveAuditLog(ctx, domain.CreateAuditLog{
+			ActorId:    &actorId,
+			Action:     domain.AuditActionReservationCancelled,
+			TargetType: domain.AuditTargetReservation,
+			TargetId:   "res-1",
+			Before:     map[string]any{"status": "confirmed"},
+			After:      map[string]any{"status": "cancelled"},
+		})
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestFindAuditLogs(t *testing.T) {
+	db, mock := setupAuditLogRepositoryTestDB(t)
+	from := time.Now().Add(-time.Hour)
+
+	t.Run("should filter within the caller's tenant", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT(.+) FROM audit_logs WHERE action = \\$1 AND created_at >= \\$2 AND organisation_id = \\$3").
+			WithArgs(domain.AuditActionLogin, from, tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("SELECT (.+) FROM audit_logs WHERE (.+) ORDER BY created_at DESC, id OFFSET \\$4 LIMIT \\$5").
+			WithArgs(domain.AuditActionLogin, from, tenantA, 0, 25).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "action", "target_type"}).
+				AddRow("log-1", domain.AuditActionLogin, domain.AuditTargetUser))
+
+		logs, total, err := db.FindAuditLogs(tenantContext(tenantA), domain.AuditLogFilter{
+			Action: domain.AuditActionLogin,
+			From:   &from,
+			Limit:  25,
+		})
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if total != 1 || len(logs) != 1 {
+			t.Errorf("expected 1 log, got %d of %d", len(logs), total)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should require a tenant", func(t *testing.T) {
+		_, _, err := db.FindAuditLogs(tenantContext(""), domain.AuditLogFilter{Limit: 25})
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type DeskRepositoryDb struct {
+	Conn *sqlx.DB
+}
+
+func (db *DeskRepositoryDb) FindDeskById(ctx context.Context, id string) (*domain.Desk, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var desk domain.Desk
+	query := `
+	SELECT
+		d.id,
+		d.number,
+		d.zone_id,
+		d.site_id,
+		d.requires_approval OR COALESCE(z.requires_approval, FALSE) AS requires_approval,
+		COALESCE(s.lottery_enabled, FALSE) AS lottery_enabled,
+		COALESCE(s.time_zone, 'UTC') AS time_zone
+	FROM desks d
+	LEFT JOIN zones z ON z.id = d.zone_id
+	LEFT JOIN sites s ON s.id = d.site_id
+	WHERE d.id = $1
+	AND d.organisation_id = $2
+	LIMIT 1
+	`
+
+	err = db.Conn.GetContext(ctx, &desk, query, id, tenantId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to find desk", err)
+	}
+
+	return &desk, nil
+}
+
+// UpdateDeskApproval returns the desk's previous flag, or nil when the desk
+// is not in the request tenant.
+func (db *DeskRepositoryDb) UpdateDeskApproval(
+	ctx context.Context,
+	id string,
+	requiresApproval bool,
+) (*bool, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+	UPDATE desks d
+	SET requires_approval = $2
+	FROM desks previous
+	WHERE previous.id = d.id
+	AND d.id = $1
+	AND d.organisation_id = $3
+	RETURNING previous.requires_approval
+	`
+
+	var previous bool
+	err = db.Conn.GetContext(ctx, &previous, query, id, requiresApproval, tenantId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to update desk", err)
+	}
+
+	return &previous, nil
+}
+
+// UpdateZoneApproval returns the zone's previous flag, or nil when the zone
+// is not in the request tenant.
+func (db *DeskRepositoryDb) UpdateZoneApproval(
+	ctx context.Context,
+	id string,
+	requiresApproval bool,
+) (*bool, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+	UPDATE zones z
+	SET requires_approval = $2
+	FROM zones previous
+	WHERE previous.id = z.id
+	AND z.id = $1
+	AND z.organisation_id = $3
+	RETURNING previous.requires_approval
+	`
+
+	var previous bool
+	err = db.Conn.GetContext(ctx, &previous, query, id, requiresApproval, tenantId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to update zone", err)
+	}
+
+	return &previous, nil
+}
+
+func (db *DeskRepositoryDb) FindAvailableDesksBySite(
+	ctx context.Context,
+	siteId string,
+	date time.Time,
+) ([]domain.Desk, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+	SELECT d.id, d.number, d.zone_id, d.site_id
+	FROM desks d
+	LEFT JOIN zones z ON z.id = d.zone_id
+	WHERE d.site_id = $1
+	AND d.organisation_id = $3
+	AND NOT d.requires_approval
+	AND NOT COALESCE(z.requires_approval, FALSE)
+	AND NOT EXISTS (
+		SELECT 1 FROM reservations r
+		WHERE r.desk_id = d.id
+		AND r.date = $2
+		AND (r.status = 'pending' OR r.status = 'confirmed')
+	)
+	ORDER BY d.number
+	`
+
+	desks := []domain.Desk{}
+
+	err = db.Conn.SelectContext(ctx, &desks, query, siteId, date.Format("2006-01-02"), tenantId)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to find available desks", err)
+	}
+
+	return desks, nil
+}
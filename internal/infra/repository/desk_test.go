@@ -0,0 +1,121 @@
+package infra
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func setupDeskRepositoryTestDB(t *testing.T) (*DeskRepositoryDb, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+	db := &DeskRepositoryDb{Conn: sqlxDB}
+
+	return db, mock
+}
+
+func TestFindDeskById(t *testing.T) {
+	db, mock := setupDeskRepositoryTestDB(t)
+	ctx := tenantContext(tenantA)
+
+	t.Run("should find desk with approval flag and site time zone", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "number", "zone_id", "requires_approval", "time_zone"}).
+			AddRow("123", 7, "zone", true, "Europe/Lisbon")
+
+		mock.ExpectQuery("SELECT (.+) FROM desks d").
+			WithArgs("123", tenantA).
+			WillReturnRows(rows)
+
+		desk, err := db.FindDeskById(ctx, "123")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if desk == nil {
+			t.Fatal("expected desk to not be nil")
+		}
+		if !desk.RequiresApproval {
+			t.Error("expected desk to require approval")
+		}
+		if desk.Location().String() != "Europe/Lisbon" {
+			t.Errorf("expected Europe/Lisbon, got %s", desk.Location())
+		}
+	})
+
+	t.Run("should return nil when desk not found", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM desks d").
+			WithArgs("123", tenantA).
+			WillReturnError(sql.ErrNoRows)
+
+		desk, err := db.FindDeskById(ctx, "123")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if desk != nil {
+			t.Error("expected desk to be nil")
+		}
+	})
+}
+
+func TestUpdateDeskApproval(t *testing.T) {
+	db, mock := setupDeskRepositoryTestDB(t)
+	ctx := tenantContext(tenantA)
+
+	t.Run("should return the previous flag", func(t *testing.T) {
+		mock.ExpectQuery("UPDATE desks d\\s+SET requires_approval = \\$2").
+			WithArgs("123", true, tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"requires_approval"}).AddRow(false))
+
+		previous, err := db.UpdateDeskApproval(ctx, "123", true)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if previous == nil || *previous {
+			t.Errorf("expected previous flag false, got %v", previous)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestUpdateZoneApproval(t *testing.T) {
+	db, mock := setupDeskRepositoryTestDB(t)
+	ctx := tenantContext(tenantA)
+
+	t.Run("should update the request tenant's zone", func(t *testing.T) {
+		mock.ExpectQuery("UPDATE zones z\\s+SET requires_approval = \\$2(.+)AND z.organisation_id = \\$3").
+			WithArgs("zone-1", true, tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"requires_approval"}).AddRow(false))
+
+		previous, err := db.UpdateZoneApproval(ctx, "zone-1", true)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if previous == nil || *previous {
+			t.Errorf("expected previous flag false, got %v", previous)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should not update another tenant's zone", func(t *testing.T) {
+		mock.ExpectQuery("UPDATE zones z").
+			WithArgs("zone-1", true, tenantA).
+			WillReturnError(sql.ErrNoRows)
+
+		previous, err := db.UpdateZoneApproval(ctx, "zone-1", true)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if previous != nil {
+			t.Errorf("expected nil, got %v", *previous)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type GuestRepositoryDb struct {
+	Conn *sqlx.DB
+}
+
+func (db *GuestRepositoryDb) SaveGuestReservation(
+	ctx context.Context,
+	guest domain.CreateGuest,
+	reservation domain.CreateReservation,
+) (string, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	reached, err := dailyLimitReached(
+		ctx,
+		tx,
+		tenantId,
+		reservation.UserId,
+		reservation.Date,
+		reservation.DailyLimit,
+		"",
+	)
+	if err != nil {
+		return "", err
+	}
+	if reached {
+		return "", pkg.NewBadRequestError("daily reservation limit reached")
+	}
+
+	var guestId string
+
+	guestQuery := `
+	INSERT INTO guests (name, email, host_id)
+	VALUES ($1, $2, $3)
+	RETURNING id
+	`
+	err = tx.GetContext(ctx, &guestId, guestQuery, guest.Name, guest.Email, guest.HostId)
+	if err != nil {
+		return "", pkg.NewInternalServerError("failed to save guest", err)
+	}
+
+	var reservationId string
+
+	reservationQuery := `
+	INSERT INTO reservations (desk_id, user_id, guest_id, date, status, organisation_id)
+	SELECT id, $2, $3, $4, $5, organisation_id FROM desks
+	WHERE id = $1 AND organisation_id = $6
+	RETURNING id
+	`
+	err = tx.GetContext(
+		ctx,
+		&reservationId,
+		reservationQuery,
+		reservation.DeskId,
+		reservation.UserId,
+		guestId,
+		reservation.Date.Format("2006-01-02"),
+		reservation.Status,
+		tenantId,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", pkg.NewNotFoundError("desk not found")
+		}
+		if isUniqueViolation(err) {
+			return "", pkg.NewConflictError("desk is unavailable", nil)
+		}
+		return "", pkg.NewInternalServerError("failed to save guest reservation", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", pkg.NewInternalServerError("failed to commit guest reservation", err)
+	}
+
+	return reservationId, nil
+}
+
+// FindGuestVisitsByDate lists guests expected on date, or on each desk's
+// site-local today when date is nil.
+func (db *GuestRepositoryDb) FindGuestVisitsByDate(
+	ctx context.Context,
+	date *time.Time,
+) ([]domain.GuestVisit, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+	SELECT
+		r.id AS reservation_id,
+		g.name AS guest_name,
+		g.email AS guest_email,
+		u.id AS host_id,
+		u.name AS host_name,
+		d.number AS desk_number,
+		r.date
+	FROM reservations r
+	JOIN guests g ON g.id = r.guest_id
+	JOIN users u ON u.id = g.host_id
+	JOIN desks d ON d.id = r.desk_id
+	LEFT JOIN sites s ON s.id = d.site_id
+	WHERE r.date = COALESCE($1::date, (NOW() AT TIME ZONE COALESCE(s.time_zone, 'UTC'))::date)
+	AND (r.status = 'pending' OR r.status = 'confirmed')
+	AND r.organisation_id = $2
+	ORDER BY g.name
+	`
+
+	var day any
+	if date != nil {
+		day = date.Format("2006-01-02")
+	}
+
+	visits := []domain.GuestVisit{}
+
+	err = db.Conn.SelectContext(ctx, &visits, query, day, tenantId)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to find guest visits", err)
+	}
+
+	return visits, nil
+}
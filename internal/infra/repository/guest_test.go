@@ -0,0 +1,127 @@
+package infra
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+func setupGuestRepositoryTestDB(t *testing.T) (*GuestRepositoryDb, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+	db := &GuestRepositoryDb{Conn: sqlxDB}
+
+	return db, mock
+}
+
+func TestSaveGuestReservation(t *testing.T) {
+	db, mock := setupGuestRepositoryTestDB(t)
+	ctx := tenantContext(tenantA)
+	guest := domain.CreateGuest{
+		Name:   "Guest User",
+		Email:  "guest@example.com",
+		HostId: "456",
+	}
+	reservation := domain.CreateReservation{
+		DeskId: "123",
+		UserId: "456",
+		Date:   time.Now(),
+		Status: "pending",
+	}
+
+	t.Run("should save guest and reservation in one transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectOwnerLock(mock, reservation.UserId, tenantA)
+		mock.ExpectQuery("INSERT INTO guests").
+			WithArgs(guest.Name, guest.Email, guest.HostId).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("789"))
+		mock.ExpectQuery("INSERT INTO reservations").
+			WithArgs(reservation.DeskId, reservation.UserId, "789", reservation.Date.Format("2006-01-02"), reservation.Status, tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("321"))
+		mock.ExpectCommit()
+
+		id, err := db.SaveGuestReservation(ctx, guest, reservation)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if id != "321" {
+			t.Errorf("expected reservation id 321, got %s", id)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should rollback when reservation fails", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectOwnerLock(mock, reservation.UserId, tenantA)
+		mock.ExpectQuery("INSERT INTO guests").
+			WithArgs(guest.Name, guest.Email, guest.HostId).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("789"))
+		mock.ExpectQuery("INSERT INTO reservations").
+			WithArgs(reservation.DeskId, reservation.UserId, "789", reservation.Date.Format("2006-01-02"), reservation.Status, tenantA).
+			WillReturnError(fmt.Errorf("db error"))
+		mock.ExpectRollback()
+
+		_, err := db.SaveGuestReservation(ctx, guest, reservation)
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestFindGuestVisitsByDate(t *testing.T) {
+	db, mock := setupGuestRepositoryTestDB(t)
+	ctx := tenantContext(tenantA)
+	date := time.Now()
+
+	t.Run("should list guest visits for the date", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{
+			"reservation_id", "guest_name", "guest_email", "host_id", "host_name", "desk_number", "date",
+		}).AddRow("789", "Guest User", "guest@example.com", "456", "Host User", 3, date)
+
+		mock.ExpectQuery("SELECT (.+) FROM reservations r").
+			WithArgs(date.Format("2006-01-02"), tenantA).
+			WillReturnRows(rows)
+
+		visits, err := db.FindGuestVisitsByDate(ctx, &date)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(visits) != 1 {
+			t.Fatalf("expected 1 visit, got %d", len(visits))
+		}
+		if visits[0].HostName != "Host User" {
+			t.Errorf("expected host Host User, got %s", visits[0].HostName)
+		}
+	})
+
+	t.Run("should default to each site's local day", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{
+			"reservation_id", "guest_name", "guest_email", "host_id", "host_name", "desk_number", "date",
+		})
+
+		mock.ExpectQuery("LEFT JOIN sites s ON s.id = d.site_id\\s+WHERE r.date = COALESCE\\(\\$1::date").
+			WithArgs(nil, tenantA).
+			WillReturnRows(rows)
+
+		if _, err := db.FindGuestVisitsByDate(ctx, nil); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type LoginAttemptRepositoryDb struct {
+	Conn *sqlx.DB
+}
+
+func (db *LoginAttemptRepositoryDb) SaveLoginAttempt(ctx context.Context, attempt domain.LoginAttempt) error {
+	query := `
+	INSERT INTO login_attempts (email, ip_address, succeeded, attempted_at)
+	VALUES (:email, :ip_address, :succeeded, :attempted_at)
+	`
+
+	_, err := db.Conn.NamedExecContext(ctx, query, attempt)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to save login attempt", err)
+	}
+
+	return nil
+}
+
+// Failures before the last successful login do not count towards the account limit.
+func (db *LoginAttemptRepositoryDb) CountAccountFailures(
+	ctx context.Context,
+	email string,
+	since time.Time,
+) (*domain.LoginFailures, error) {
+	var failures domain.LoginFailures
+	query := `
+	SELECT COUNT(*) AS count, MAX(attempted_at) AS last_failed_at
+	FROM login_attempts
+	WHERE email = $1
+	AND NOT succeeded
+	AND attempted_at > GREATEST($2::timestamp, COALESCE(
+		(SELECT MAX(attempted_at) FROM login_attempts WHERE email = $1 AND succeeded),
+		$2
+	))
+	`
+
+	err := db.Conn.GetContext(ctx, &failures, query, email, since)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to count login failures", err)
+	}
+
+	return &failures, nil
+}
+
+func (db *LoginAttemptRepositoryDb) CountIPFailures(
+	ctx context.Context,
+	ipAddress string,
+	since time.Time,
+) (*domain.LoginFailures, error) {
+	var failures domain.LoginFailures
+	query := `
+	SELECT COUNT(*) AS count, MAX(attempted_at) AS last_failed_at
+	FROM login_attempts
+	WHERE ip_address = $1
+	AND NOT succeeded
+	AND attempted_at > $2
+	`
+
+	err := db.Conn.GetContext(ctx, &failures, query, ipAddress, since)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to count login failures", err)
+	}
+
+	return &failures, nil
+}
@@ -0,0 +1,41 @@
+package infra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestCountAccountFailures(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+
+	db := &LoginAttemptRepositoryDb{Conn: sqlx.NewDb(mockDB, "sqlmock")}
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+	lastFailedAt := time.Now()
+
+	t.Run("should count failures since last success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"count", "last_failed_at"}).AddRow(3, lastFailedAt)
+
+		mock.ExpectQuery("SELECT COUNT(.+) FROM login_attempts (.+) succeeded").
+			WithArgs("test@test.com", since).
+			WillReturnRows(rows)
+
+		failures, err := db.CountAccountFailures(ctx, "test@test.com", since)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if failures.Count != 3 || failures.LastFailedAt == nil {
+			t.Errorf("expected 3 failures with timestamp, got %+v", failures)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
@@ -0,0 +1,387 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type LotteryRepositoryDb struct {
+	Conn *sqlx.DB
+}
+
+func (db *LotteryRepositoryDb) SaveLotteryRequest(
+	ctx context.Context,
+	request domain.CreateLotteryRequest,
+) (bool, error) {
+	query := `
+	INSERT INTO lottery_requests (site_id, user_id, date)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (site_id, user_id, date) DO NOTHING
+	`
+
+	result, err := db.Conn.ExecContext(
+		ctx,
+		query,
+		request.SiteId,
+		request.UserId,
+		request.Date.Format("2006-01-02"),
+	)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to save lottery request", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to save lottery request", err)
+	}
+
+	return rows == 1, nil
+}
+
+func (db *LotteryRepositoryDb) FindLotteryRequests(
+	ctx context.Context,
+	siteId string,
+	date time.Time,
+) ([]domain.LotteryRequest, error) {
+	query := `
+	SELECT * FROM lottery_requests
+	WHERE site_id = $1
+	AND date = $2
+	ORDER BY user_id
+	`
+
+	requests := []domain.LotteryRequest{}
+
+	err := db.Conn.SelectContext(ctx, &requests, query, siteId, date.Format("2006-01-02"))
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to find lottery requests", err)
+	}
+
+	return requests, nil
+}
+
+func (db *LotteryRepositoryDb) CountRecentWins(
+	ctx context.Context,
+	siteId string,
+	since time.Time,
+) (map[string]int, error) {
+	query := `
+	SELECT user_id, COUNT(*) AS wins
+	FROM lottery_requests
+	WHERE site_id = $1
+	AND status = 'won'
+	AND date >= $2
+	GROUP BY user_id
+	`
+
+	rows := []struct {
+		UserId string `db:"user_id"`
+		Wins   int    `db:"wins"`
+	}{}
+
+	err := db.Conn.SelectContext(ctx, &rows, query, siteId, since.Format("2006-01-02"))
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to count lottery wins", err)
+	}
+
+	wins := map[string]int{}
+	for _, row := range rows {
+		wins[row.UserId] = row.Wins
+	}
+
+	return wins, nil
+}
+
+func (db *LotteryRepositoryDb) FindLotteryDraw(
+	ctx context.Context,
+	siteId string,
+	date time.Time,
+) (*domain.LotteryDraw, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var draw domain.LotteryDraw
+	query := `
+	SELECT lottery_draws.* FROM lottery_draws
+	JOIN sites ON sites.id = lottery_draws.site_id
+	WHERE site_id = $1 AND date = $2 AND sites.organisation_id = $3
+	LIMIT 1
+	`
+
+	err = db.Conn.GetContext(ctx, &draw, query, siteId, date.Format("2006-01-02"), tenantId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to find lottery draw", err)
+	}
+
+	return &draw, nil
+}
+
+func (db *LotteryRepositoryDb) SaveLotteryDraw(
+	ctx context.Context,
+	draw domain.CreateLotteryDraw,
+) error {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	results, err := json.Marshal(draw.Outcomes)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to encode lottery results", err)
+	}
+
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	drawQuery := `
+	INSERT INTO lottery_draws (site_id, date, seed, results, drawn_by)
+	VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err = tx.ExecContext(
+		ctx,
+		drawQuery,
+		draw.SiteId,
+		draw.Date.Format("2006-01-02"),
+		draw.Seed,
+		results,
+		draw.DrawnBy,
+	)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to save lottery draw", err)
+	}
+
+	requestQuery := `
+	UPDATE lottery_requests
+	SET status = $2, desk_id = $3, waitlist_position = $4
+	WHERE id = $1
+	`
+	reservationQuery := `
+	INSERT INTO reservations (desk_id, user_id, date, status, organisation_id, status_changed_by)
+	VALUES ($1, $2, $3, 'confirmed', $4, $5)
+	`
+
+	freed := []string{}
+	for _, outcome := range draw.Outcomes {
+		status, deskId := outcome.Status, outcome.DeskId
+
+		if status == domain.LotteryStatusWon {
+			reached, err := dailyLimitReached(ctx, tx, tenantId, outcome.UserId, draw.Date, draw.DailyLimit, "")
+			if err != nil {
+				return err
+			}
+
+			if reached {
+				freed = append(freed, *outcome.DeskId)
+				status, deskId = domain.LotteryStatusForfeited, nil
+			}
+		}
+
+		_, err := tx.ExecContext(
+			ctx,
+			requestQuery,
+			outcome.RequestId,
+			status,
+			deskId,
+			outcome.WaitlistPosition,
+		)
+		if err != nil {
+			return pkg.NewInternalServerError("failed to update lottery request", err)
+		}
+
+		if status != domain.LotteryStatusWon {
+			continue
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			reservationQuery,
+			*deskId,
+			outcome.UserId,
+			draw.Date.Format("2006-01-02"),
+			tenantId,
+			draw.DrawnBy,
+		)
+		if isUniqueViolation(err) {
+			return pkg.NewConflictError("a drawn desk was booked during the draw", nil)
+		}
+		if err != nil {
+			return pkg.NewInternalServerError("failed to save lottery reservation", err)
+		}
+	}
+
+	for _, deskId := range freed {
+		promotion := domain.LotteryPromotion{
+			SiteId:     draw.SiteId,
+			DeskId:     deskId,
+			Date:       draw.Date,
+			DailyLimit: draw.DailyLimit,
+			ActorId:    draw.DrawnBy,
+		}
+		if _, err := promoteLotteryWaitlist(ctx, tx, tenantId, promotion); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return pkg.NewInternalServerError("failed to commit lottery draw", err)
+	}
+
+	return nil
+}
+
+func (db *LotteryRepositoryDb) PromoteLotteryWaitlist(
+	ctx context.Context,
+	promotion domain.LotteryPromotion,
+) (*domain.Reservation, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	forfeitQuery := `
+	UPDATE lottery_requests
+	SET status = 'forfeited'
+	WHERE site_id = $1
+	AND date = $2
+	AND user_id = $3
+	AND desk_id = $4
+	AND status = 'won'
+	`
+	result, err := tx.ExecContext(
+		ctx,
+		forfeitQuery,
+		promotion.SiteId,
+		promotion.Date.Format("2006-01-02"),
+		promotion.FromUserId,
+		promotion.DeskId,
+	)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to forfeit lottery win", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to forfeit lottery win", err)
+	}
+
+	if rows == 0 {
+		return nil, nil
+	}
+
+	reservation, err := promoteLotteryWaitlist(ctx, tx, tenantId, promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, pkg.NewInternalServerError("failed to commit transaction", err)
+	}
+
+	return reservation, nil
+}
+
+// promoteLotteryWaitlist books the desk for the first waitlisted request of
+// the draw whose user is still active and under the daily limit. It returns
+// nil when nobody qualifies or the desk has been booked since the draw.
+func promoteLotteryWaitlist(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	tenantId string,
+	promotion domain.LotteryPromotion,
+) (*domain.Reservation, error) {
+	date := promotion.Date.Format("2006-01-02")
+
+	candidates := []domain.LotteryRequest{}
+	candidateQuery := `
+	SELECT lr.* FROM lottery_requests lr
+	JOIN users u ON u.id = lr.user_id
+	WHERE lr.site_id = $1
+	AND lr.date = $2
+	AND lr.status = 'waitlisted'
+	AND u.deactivated_at IS NULL
+	AND u.deleted_at IS NULL
+	ORDER BY lr.waitlist_position
+	FOR UPDATE OF lr
+	`
+	err := tx.SelectContext(ctx, &candidates, candidateQuery, promotion.SiteId, date)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to find lottery waitlist", err)
+	}
+
+	for _, candidate := range candidates {
+		reached, err := dailyLimitReached(ctx, tx, tenantId, candidate.UserId, promotion.Date, promotion.DailyLimit, "")
+		if err != nil {
+			return nil, err
+		}
+
+		if reached {
+			continue
+		}
+
+		var reservationId string
+		reservationQuery := `
+		INSERT INTO reservations (desk_id, user_id, date, status, organisation_id, status_changed_by)
+		VALUES ($1, $2, $3, 'confirmed', $4, $5)
+		ON CONFLICT DO NOTHING
+		RETURNING id
+		`
+		err = tx.GetContext(
+			ctx,
+			&reservationId,
+			reservationQuery,
+			promotion.DeskId,
+			candidate.UserId,
+			date,
+			tenantId,
+			promotion.ActorId,
+		)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, pkg.NewInternalServerError("failed to save lottery reservation", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+		UPDATE lottery_requests
+		SET status = 'won', desk_id = $2, waitlist_position = NULL
+		WHERE id = $1
+		`, candidate.Id, promotion.DeskId)
+		if err != nil {
+			return nil, pkg.NewInternalServerError("failed to update lottery request", err)
+		}
+
+		return &domain.Reservation{
+			Id:     reservationId,
+			DeskId: promotion.DeskId,
+			UserId: candidate.UserId,
+			Date:   promotion.Date,
+			Status: domain.ReservationStatusConfirmed,
+		}, nil
+	}
+
+	return nil, nil
+}
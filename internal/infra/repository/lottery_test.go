@@ -0,0 +1,191 @@
+package infra
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+func setupLotteryRepositoryTestDB(t *testing.T) (*LotteryRepositoryDb, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+	db := &LotteryRepositoryDb{Conn: sqlxDB}
+
+	return db, mock
+}
+
+func TestSaveLotteryDraw(t *testing.T) {
+	db, mock := setupLotteryRepositoryTestDB(t)
+	ctx := tenantContext(tenantA)
+	deskId := "desk-1"
+	position := 1
+	draw := domain.CreateLotteryDraw{
+		SiteId:  "site-1",
+		Date:    time.Now(),
+		Seed:    42,
+		DrawnBy: "admin",
+		Outcomes: []domain.LotteryOutcome{
+			{RequestId: "req-a", UserId: "a", Weight: 1, Status: "won", DeskId: &deskId},
+			{RequestId: "req-b", UserId: "b", Weight: 0.5, Status: "waitlisted", WaitlistPosition: &position},
+		},
+	}
+
+	t.Run("should save draw, outcomes and winner reservations", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO lottery_draws").
+			WithArgs(draw.SiteId, draw.Date.Format("2006-01-02"), draw.Seed, sqlmock.AnyArg(), draw.DrawnBy).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectOwnerLock(mock, "a", tenantA)
+		mock.ExpectExec("UPDATE lottery_requests").
+			WithArgs("req-a", "won", &deskId, nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO reservations").
+			WithArgs(deskId, "a", draw.Date.Format("2006-01-02"), tenantA, draw.DrawnBy).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("UPDATE lottery_requests").
+			WithArgs("req-b", "waitlisted", nil, &position).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := db.SaveLotteryDraw(ctx, draw)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should pass the desk of a winner at the daily limit to the waitlist", func(t *testing.T) {
+		limited := draw
+		limited.DailyLimit = 1
+		day := draw.Date.Format("2006-01-02")
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO lottery_draws").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectOwnerLock(mock, "a", tenantA)
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM reservations").
+			WithArgs("a", day, "", tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectExec("UPDATE lottery_requests").
+			WithArgs("req-a", domain.LotteryStatusForfeited, nil, nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE lottery_requests").
+			WithArgs("req-b", "waitlisted", nil, &position).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT lr.\\* FROM lottery_requests lr").
+			WithArgs("site-1", day).
+			WillReturnRows(lotteryWaitlistRows("req-b", "b"))
+		expectOwnerLock(mock, "b", tenantA)
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM reservations").
+			WithArgs("b", day, "", tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery("INSERT INTO reservations").
+			WithArgs(deskId, "b", day, tenantA, draw.DrawnBy).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("res-b"))
+		mock.ExpectExec("UPDATE lottery_requests").
+			WithArgs("req-b", deskId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := db.SaveLotteryDraw(ctx, limited)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should rollback when draw already exists", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO lottery_draws").
+			WillReturnError(fmt.Errorf("duplicate key"))
+		mock.ExpectRollback()
+
+		err := db.SaveLotteryDraw(ctx, draw)
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func lotteryWaitlistRows(requestId string, userId string) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{
+		"id", "site_id", "user_id", "date", "status", "desk_id", "waitlist_position", "created_at",
+	}).AddRow(requestId, "site-1", userId, time.Now(), "waitlisted", nil, 1, time.Now())
+}
+
+func TestPromoteLotteryWaitlist(t *testing.T) {
+	db, mock := setupLotteryRepositoryTestDB(t)
+	ctx := tenantContext(tenantA)
+	promotion := domain.LotteryPromotion{
+		SiteId:     "site-1",
+		DeskId:     "desk-1",
+		FromUserId: "a",
+		Date:       time.Now(),
+		ActorId:    "a",
+	}
+	day := promotion.Date.Format("2006-01-02")
+
+	t.Run("should give a cancelled win to the first waitlisted user", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE lottery_requests\\s+SET status = 'forfeited'").
+			WithArgs("site-1", day, "a", "desk-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT lr.\\* FROM lottery_requests lr").
+			WithArgs("site-1", day).
+			WillReturnRows(lotteryWaitlistRows("req-b", "b"))
+		expectOwnerLock(mock, "b", tenantA)
+		mock.ExpectQuery("INSERT INTO reservations").
+			WithArgs("desk-1", "b", day, tenantA, "a").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("res-b"))
+		mock.ExpectExec("UPDATE lottery_requests").
+			WithArgs("req-b", "desk-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		reservation, err := db.PromoteLotteryWaitlist(ctx, promotion)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if reservation == nil || reservation.UserId != "b" || reservation.Id != "res-b" {
+			t.Errorf("expected reservation res-b for b, got %v", reservation)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should ignore cancellations that were not lottery wins", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE lottery_requests\\s+SET status = 'forfeited'").
+			WithArgs("site-1", day, "a", "desk-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		reservation, err := db.PromoteLotteryWaitlist(ctx, promotion)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if reservation != nil {
+			t.Errorf("expected no promotion, got %v", reservation)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type MFARepositoryDb struct {
+	Conn *sqlx.DB
+}
+
+func (db *MFARepositoryDb) FindUserMFA(ctx context.Context, userId string) (*domain.UserMFA, error) {
+	var mfa domain.UserMFA
+	query := "SELECT * FROM user_mfa WHERE user_id = $1"
+
+	err := db.Conn.GetContext(ctx, &mfa, query, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to find user mfa", err)
+	}
+
+	return &mfa, nil
+}
+
+func (db *MFARepositoryDb) SavePendingMFA(ctx context.Context, userId string, secret string) (bool, error) {
+	query := `
+	INSERT INTO user_mfa (user_id, secret)
+	VALUES ($1, $2)
+	ON CONFLICT (user_id) DO UPDATE
+	SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+	WHERE user_mfa.enabled_at IS NULL
+	`
+
+	result, err := db.Conn.ExecContext(ctx, query, userId, secret)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to save user mfa", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to save user mfa", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (db *MFARepositoryDb) EnableMFA(
+	ctx context.Context,
+	userId string,
+	step int64,
+	recoveryCodeHashes []string,
+) (bool, error) {
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+	UPDATE user_mfa
+	SET enabled_at = NOW(), last_used_step = $2
+	WHERE user_id = $1 AND enabled_at IS NULL
+	`, userId, step)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to enable user mfa", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to enable user mfa", err)
+	}
+
+	if rows == 0 {
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM mfa_recovery_codes WHERE user_id = $1", userId)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to clear recovery codes", err)
+	}
+
+	for _, codeHash := range recoveryCodeHashes {
+		_, err = tx.ExecContext(ctx, `
+		INSERT INTO mfa_recovery_codes (user_id, code_hash)
+		VALUES ($1, $2)
+		`, userId, codeHash)
+		if err != nil {
+			return false, pkg.NewInternalServerError("failed to save recovery code", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, pkg.NewInternalServerError("failed to commit transaction", err)
+	}
+
+	return true, nil
+}
+
+func (db *MFARepositoryDb) UpdateMFALastUsedStep(ctx context.Context, userId string, step int64) (bool, error) {
+	query := `
+	UPDATE user_mfa
+	SET last_used_step = $2
+	WHERE user_id = $1 AND enabled_at IS NOT NULL AND last_used_step < $2
+	`
+
+	result, err := db.Conn.ExecContext(ctx, query, userId, step)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to update mfa step", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to update mfa step", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (db *MFARepositoryDb) UseRecoveryCode(ctx context.Context, userId string, codeHash string) (bool, error) {
+	query := `
+	UPDATE mfa_recovery_codes
+	SET used_at = NOW()
+	WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	result, err := db.Conn.ExecContext(ctx, query, userId, codeHash)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to use recovery code", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to use recovery code", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (db *MFARepositoryDb) DeleteUserMFA(ctx context.Context, userId string) (bool, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+	DELETE FROM mfa_recovery_codes c
+	USING users u
+	WHERE c.user_id = u.id AND u.id = $1 AND u.organisation_id = $2
+	`, userId, tenantId)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to delete recovery codes", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+	DELETE FROM user_mfa m
+	USING users u
+	WHERE m.user_id = u.id AND u.id = $1 AND u.organisation_id = $2
+	`, userId, tenantId)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to delete user mfa", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to delete user mfa", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, pkg.NewInternalServerError("failed to commit transaction", err)
+	}
+
+	return rows > 0, nil
+}
@@ -0,0 +1,125 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func setupMFARepositoryTestDB(t *testing.T) (*MFARepositoryDb, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+	db := &MFARepositoryDb{Conn: sqlxDB}
+
+	return db, mock
+}
+
+func TestEnableMFA(t *testing.T) {
+	db, mock := setupMFARepositoryTestDB(t)
+	ctx := context.Background()
+
+	t.Run("should enable mfa and store recovery codes", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE user_mfa").
+			WithArgs("123", int64(42)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM mfa_recovery_codes").
+			WithArgs("123").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO mfa_recovery_codes").
+			WithArgs("123", "hash-1").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO mfa_recovery_codes").
+			WithArgs("123", "hash-2").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		enabled, err := db.EnableMFA(ctx, "123", 42, []string{"hash-1", "hash-2"})
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if !enabled {
+			t.Error("expected mfa to be enabled")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should not enable mfa that is already enabled", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE user_mfa").
+			WithArgs("123", int64(42)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		enabled, err := db.EnableMFA(ctx, "123", 42, []string{"hash-1"})
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if enabled {
+			t.Error("expected mfa not to be enabled")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestUpdateMFALastUsedStep(t *testing.T) {
+	db, mock := setupMFARepositoryTestDB(t)
+	ctx := context.Background()
+
+	t.Run("should reject replayed step", func(t *testing.T) {
+		mock.ExpectExec("UPDATE user_mfa (.+) last_used_step < \\$2").
+			WithArgs("123", int64(42)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		updated, err := db.UpdateMFALastUsedStep(ctx, "123", 42)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if updated {
+			t.Error("expected replayed step to be rejected")
+		}
+	})
+}
+
+func TestDeleteUserMFA(t *testing.T) {
+	db, mock := setupMFARepositoryTestDB(t)
+
+	t.Run("should require a tenant", func(t *testing.T) {
+		_, err := db.DeleteUserMFA(context.Background(), "123")
+		if err == nil {
+			t.Error("expected error without tenant")
+		}
+	})
+
+	t.Run("should only delete within the caller's tenant", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM mfa_recovery_codes (.+) u.organisation_id = \\$2").
+			WithArgs("123", tenantA).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM user_mfa (.+) u.organisation_id = \\$2").
+			WithArgs("123", tenantA).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		deleted, err := db.DeleteUserMFA(tenantContext(tenantA), "123")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if deleted {
+			t.Error("expected nothing deleted for user outside tenant")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
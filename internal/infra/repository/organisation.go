@@ -0,0 +1,68 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type OrganisationRepositoryDb struct {
+	Conn *sqlx.DB
+}
+
+func (db *OrganisationRepositoryDb) FindOrganisations(ctx context.Context) ([]domain.Organisation, error) {
+	organisations := []domain.Organisation{}
+
+	err := db.Conn.SelectContext(ctx, &organisations, `SELECT * FROM organisations ORDER BY created_at`)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to find organisations", err)
+	}
+
+	return organisations, nil
+}
+
+func (db *OrganisationRepositoryDb) FindOrganisation(ctx context.Context) (*domain.Organisation, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var organisation domain.Organisation
+
+	err = db.Conn.GetContext(ctx, &organisation, `SELECT * FROM organisations WHERE id = $1 LIMIT 1`, tenantId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to find organisation", err)
+	}
+
+	return &organisation, nil
+}
+
+func (db *OrganisationRepositoryDb) UpdateReservationRetention(ctx context.Context, days *int) (bool, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	query := `UPDATE organisations SET reservation_retention_days = $1 WHERE id = $2`
+
+	result, err := db.Conn.ExecContext(ctx, query, days, tenantId)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to update organisation", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to update organisation", err)
+	}
+
+	return rows == 1, nil
+}
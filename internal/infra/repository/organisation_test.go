@@ -0,0 +1,91 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func setupOrganisationRepositoryTestDB(t *testing.T) (*OrganisationRepositoryDb, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+	db := &OrganisationRepositoryDb{Conn: sqlxDB}
+
+	return db, mock
+}
+
+func TestFindOrganisation(t *testing.T) {
+	t.Run("should find the request tenant's organisation", func(t *testing.T) {
+		db, mock := setupOrganisationRepositoryTestDB(t)
+
+		rows := sqlmock.NewRows([]string{"id", "name", "reservation_retention_days"}).
+			AddRow(tenantA, "Acme", 30)
+		mock.ExpectQuery("SELECT \\* FROM organisations WHERE id = \\$1 LIMIT 1").
+			WithArgs(tenantA).
+			WillReturnRows(rows)
+
+		organisation, err := db.FindOrganisation(tenantContext(tenantA))
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if organisation == nil || organisation.ReservationRetentionDays == nil || *organisation.ReservationRetentionDays != 30 {
+			t.Errorf("expected retention of 30 days, got %v", organisation)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should refuse without a tenant", func(t *testing.T) {
+		db, _ := setupOrganisationRepositoryTestDB(t)
+
+		_, err := db.FindOrganisation(context.Background())
+		if !errors.Is(err, ErrTenantRequired) {
+			t.Errorf("expected tenant required error, got %v", err)
+		}
+	})
+}
+
+func TestUpdateReservationRetention(t *testing.T) {
+	t.Run("should update the request tenant's organisation", func(t *testing.T) {
+		db, mock := setupOrganisationRepositoryTestDB(t)
+		days := 90
+
+		mock.ExpectExec("UPDATE organisations SET reservation_retention_days = \\$1 WHERE id = \\$2").
+			WithArgs(90, tenantA).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		updated, err := db.UpdateReservationRetention(tenantContext(tenantA), &days)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if !updated {
+			t.Error("expected organisation to be updated")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should clear the override", func(t *testing.T) {
+		db, mock := setupOrganisationRepositoryTestDB(t)
+
+		mock.ExpectExec("UPDATE organisations SET reservation_retention_days = \\$1 WHERE id = \\$2").
+			WithArgs(nil, tenantA).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		if _, err := db.UpdateReservationRetention(tenantContext(tenantA), nil); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
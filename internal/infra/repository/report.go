@@ -0,0 +1,241 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type ReportRepositoryDb struct {
+	Conn *sqlx.DB
+}
+
+// Group id and label expressions over desks d, zones z and sites s.
+var reportGroups = map[string][2]string{
+	domain.ReportGroupDesk: {"d.id::text", "d.number::text"},
+	domain.ReportGroupZone: {"z.id::text", "z.name"},
+	domain.ReportGroupSite: {"s.id::text", "s.name"},
+}
+
+const reportDeskJoins = `
+	LEFT JOIN zones z ON z.id = d.zone_id
+	LEFT JOIN sites s ON s.id = d.site_id
+	`
+
+const reportDays = `
+	SELECT day::date FROM generate_series($1::date, $2::date, interval '1 day') AS day
+	`
+
+func (db *ReportRepositoryDb) FindOccupancy(
+	ctx context.Context,
+	query domain.ReportQuery,
+) ([]domain.OccupancyRow, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := reportGroup(query.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery := fmt.Sprintf(`
+	WITH days AS (`+reportDays+`),
+	desk_days AS (
+		SELECT
+			%s AS group_id,
+			%s AS group_name,
+			d.id AS desk_id,
+			days.day,
+			EXISTS (
+				SELECT 1 FROM reservations
+				WHERE reservations.desk_id = d.id
+				AND reservations.date = days.day
+				AND reservations.status = 'confirmed'
+			) AS booked
+		FROM desks d`+reportDeskJoins+`
+		CROSS JOIN days
+		WHERE d.organisation_id = $3
+		AND ($5 = '' OR d.site_id::text = $5)
+	)
+	SELECT
+		date_trunc($4, day)::date AS period,
+		group_id,
+		group_name,
+		COUNT(DISTINCT desk_id) AS desks,
+		COUNT(*) AS desk_days,
+		COUNT(*) FILTER (WHERE booked) AS booked_desk_days,
+		ROUND(COUNT(*) FILTER (WHERE booked)::numeric / COUNT(*), 4)::float8 AS occupancy
+	FROM desk_days
+	GROUP BY 1, 2, 3
+	ORDER BY 1, 3, 2
+	`, group[0], group[1])
+
+	rows := []domain.OccupancyRow{}
+
+	err = db.Conn.SelectContext(ctx, &rows, sqlQuery, reportArgs(query, tenantId, query.Period)...)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to build occupancy report", err)
+	}
+
+	return rows, nil
+}
+
+func (db *ReportRepositoryDb) FindCheckInRates(
+	ctx context.Context,
+	query domain.ReportQuery,
+) ([]domain.CheckInRateRow, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := reportGroup(query.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery := fmt.Sprintf(`
+	WITH bookings AS (
+		SELECT
+			date_trunc($4, reservations.date)::date AS period,
+			%s AS group_id,
+			%s AS group_name,
+			reservations.checked_in_at IS NOT NULL AS checked_in,
+			reservations.date <= (NOW() AT TIME ZONE COALESCE(s.time_zone, 'UTC'))::date AS due,
+			reservations.date < (NOW() AT TIME ZONE COALESCE(s.time_zone, 'UTC'))::date AS past
+		FROM reservations
+		JOIN desks d ON d.id = reservations.desk_id`+reportDeskJoins+`
+		WHERE reservations.status = 'confirmed'
+		AND reservations.date BETWEEN $1::date AND $2::date
+		AND reservations.organisation_id = $3
+		AND ($5 = '' OR d.site_id::text = $5)
+	)
+	SELECT
+		period,
+		group_id,
+		group_name,
+		COUNT(*) AS bookings,
+		COUNT(*) FILTER (WHERE checked_in) AS check_ins,
+		COUNT(*) FILTER (WHERE past AND NOT checked_in) AS no_shows,
+		COALESCE(ROUND(
+			COUNT(*) FILTER (WHERE checked_in)::numeric / NULLIF(COUNT(*) FILTER (WHERE due), 0), 4
+		), 0)::float8 AS check_in_rate,
+		COALESCE(ROUND(
+			COUNT(*) FILTER (WHERE past AND NOT checked_in)::numeric / NULLIF(COUNT(*) FILTER (WHERE past), 0), 4
+		), 0)::float8 AS no_show_rate
+	FROM bookings
+	GROUP BY 1, 2, 3
+	ORDER BY 1, 3, 2
+	`, group[0], group[1])
+
+	rows := []domain.CheckInRateRow{}
+
+	err = db.Conn.SelectContext(ctx, &rows, sqlQuery, reportArgs(query, tenantId, query.Period)...)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to build check-in report", err)
+	}
+
+	return rows, nil
+}
+
+func (db *ReportRepositoryDb) FindWeekdayPeaks(
+	ctx context.Context,
+	query domain.ReportQuery,
+) ([]domain.WeekdayPeakRow, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery := `
+	WITH days AS (` + reportDays + `),
+	bookings AS (
+		SELECT reservations.id, reservations.date
+		FROM reservations
+		JOIN desks d ON d.id = reservations.desk_id
+		WHERE reservations.status = 'confirmed'
+		AND reservations.date BETWEEN $1::date AND $2::date
+		AND reservations.organisation_id = $3
+		AND ($4 = '' OR d.site_id::text = $4)
+	)
+	SELECT
+		EXTRACT(ISODOW FROM days.day)::int AS weekday,
+		COUNT(DISTINCT days.day) AS days,
+		COUNT(bookings.id) AS bookings,
+		ROUND(COUNT(bookings.id)::numeric / COUNT(DISTINCT days.day), 2)::float8 AS average_bookings
+	FROM days
+	LEFT JOIN bookings ON bookings.date = days.day
+	GROUP BY 1
+	ORDER BY 1
+	`
+
+	rows := []domain.WeekdayPeakRow{}
+
+	err = db.Conn.SelectContext(ctx, &rows, sqlQuery, reportArgs(query, tenantId)...)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to build weekday report", err)
+	}
+
+	return rows, nil
+}
+
+// Lead time runs from the site-local day the booking was made to the day booked.
+func (db *ReportRepositoryDb) FindLeadTimes(
+	ctx context.Context,
+	query domain.ReportQuery,
+	thresholds []int,
+) ([]domain.LeadTimeRow, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery := `
+	SELECT
+		width_bucket(
+			reservations.date - (reservations.created_at AT TIME ZONE 'UTC' AT TIME ZONE COALESCE(s.time_zone, 'UTC'))::date,
+			$4::int[]
+		) AS bucket,
+		COUNT(*) AS bookings
+	FROM reservations
+	JOIN desks d ON d.id = reservations.desk_id` + reportDeskJoins + `
+	WHERE reservations.status = 'confirmed'
+	AND reservations.date BETWEEN $1::date AND $2::date
+	AND reservations.organisation_id = $3
+	AND ($5 = '' OR d.site_id::text = $5)
+	GROUP BY 1
+	ORDER BY 1
+	`
+
+	rows := []domain.LeadTimeRow{}
+
+	err = db.Conn.SelectContext(ctx, &rows, sqlQuery, reportArgs(query, tenantId, pq.Array(thresholds))...)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to build lead time report", err)
+	}
+
+	return rows, nil
+}
+
+func reportGroup(groupBy string) ([2]string, error) {
+	group, ok := reportGroups[groupBy]
+	if !ok {
+		return group, pkg.NewBadRequestError("invalid group_by")
+	}
+
+	return group, nil
+}
+
+// reportArgs binds from, to and tenant as $1-$3, then extra, then the site.
+func reportArgs(query domain.ReportQuery, tenantId string, extra ...any) []any {
+	args := []any{query.From.Format("2006-01-02"), query.To.Format("2006-01-02"), tenantId}
+	args = append(args, extra...)
+	return append(args, query.SiteId)
+}
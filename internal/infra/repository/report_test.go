@@ -0,0 +1,91 @@
+package infra
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+func setupReportRepositoryTestDB(t *testing.T) (*ReportRepositoryDb, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+
+	return &ReportRepositoryDb{Conn: sqlx.NewDb(mockDB, "sqlmock")}, mock
+}
+
+func TestFindOccupancy(t *testing.T) {
+	db, mock := setupReportRepositoryTestDB(t)
+	from, _ := time.Parse(time.DateOnly, "2025-07-07")
+	query := domain.ReportQuery{
+		From:    from,
+		To:      from.AddDate(0, 0, 6),
+		GroupBy: domain.ReportGroupZone,
+		Period:  domain.ReportPeriodWeek,
+	}
+
+	t.Run("should aggregate desk days per zone and week", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{
+			"period", "group_id", "group_name", "desks", "desk_days", "booked_desk_days", "occupancy",
+		}).AddRow(from, "zone-1", "North", 4, 28, 21, 0.75)
+
+		mock.ExpectQuery("SELECT z.id::text AS group_id, z.name AS group_name(.+) FROM desks d").
+			WithArgs("2025-07-07", "2025-07-13", tenantA, domain.ReportPeriodWeek, "").
+			WillReturnRows(rows)
+
+		report, err := db.FindOccupancy(tenantContext(tenantA), query)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(report) != 1 || report[0].Occupancy != 0.75 {
+			t.Errorf("expected 75%% occupancy, got %+v", report)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should reject unknown grouping", func(t *testing.T) {
+		invalid := query
+		invalid.GroupBy = "floor; DROP TABLE desks"
+
+		_, err := db.FindOccupancy(tenantContext(tenantA), invalid)
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("should require a tenant", func(t *testing.T) {
+		_, err := db.FindOccupancy(tenantContext(""), query)
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestFindLeadTimes(t *testing.T) {
+	db, mock := setupReportRepositoryTestDB(t)
+	from, _ := time.Parse(time.DateOnly, "2025-07-01")
+	query := domain.ReportQuery{From: from, To: from.AddDate(0, 0, 30), SiteId: "site-1"}
+
+	mock.ExpectQuery("SELECT width_bucket\\((.+)\\$4::int\\[\\]").
+		WithArgs("2025-07-01", "2025-07-31", tenantA, pq.Array(domain.LeadTimeThresholds), "site-1").
+		WillReturnRows(sqlmock.NewRows([]string{"bucket", "bookings"}).AddRow(0, 5).AddRow(2, 7))
+
+	rows, err := db.FindLeadTimes(tenantContext(tenantA), query, domain.LeadTimeThresholds)
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if len(rows) != 2 || rows[1].Bucket != 2 || rows[1].Bookings != 7 {
+		t.Errorf("expected bucketed counts, got %+v", rows)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
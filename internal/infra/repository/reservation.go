@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"slices"
+	"time"
 
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 
 	"github.com/tufee/desk-reservation-go/internal/domain"
 	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
@@ -16,22 +18,89 @@ type ReservationRepositoryDb struct {
 	Conn *sqlx.DB
 }
 
+// Reservation days are local to the desk's site, and so is "today".
+const siteToday = `(
+		SELECT (NOW() AT TIME ZONE COALESCE(s.time_zone, 'UTC'))::date
+		FROM desks d
+		LEFT JOIN sites s ON s.id = d.site_id
+		WHERE d.id = reservations.desk_id
+	)`
+
+// reservations_active_desk_date_key rejects a second active booking of a desk
+// for the same day.
+const uniqueViolation = "23505"
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
+
+// dailyLimitReached locks the owner's row so concurrent bookings by the same
+// user are counted one after the other. The lock also proves the owner belongs
+// to the tenant, so it runs even when no limit is configured.
+func dailyLimitReached(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	tenantId string,
+	userId string,
+	date time.Time,
+	limit int,
+	excludeId string,
+) (bool, error) {
+	var lockedId string
+	lockQuery := `SELECT id FROM users WHERE id = $1 AND organisation_id = $2 FOR UPDATE`
+
+	err := tx.GetContext(ctx, &lockedId, lockQuery, userId, tenantId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, pkg.NewNotFoundError("user not found")
+	}
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to lock user", err)
+	}
+
+	if limit <= 0 {
+		return false, nil
+	}
+
+	var count int
+	countQuery := `
+	SELECT COUNT(*) FROM reservations
+	WHERE user_id = $1
+	AND date = $2
+	AND id::text <> $3
+	AND (status = 'pending' OR status = 'confirmed')
+	AND organisation_id = $4
+	`
+
+	err = tx.GetContext(ctx, &count, countQuery, userId, date.Format("2006-01-02"), excludeId, tenantId)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to count reservations", err)
+	}
+
+	return count >= limit, nil
+}
+
 func (db *ReservationRepositoryDb) FindReservation(
 	ctx context.Context,
 	reservation domain.CreateReservation,
 ) (*domain.Reservation, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
         SELECT * FROM reservations
         WHERE desk_id = :desk_id
-        AND DATE(date) = :date
+        AND date = :date
         AND (status = 'pending' OR status = 'confirmed')
+        AND organisation_id = :organisation_id
     `
 
 	params := map[string]any{
-		"desk_id": reservation.DeskId,
-		"date": reservation.Date.Format(
-			"2006-01-02",
-		),
+		"desk_id":         reservation.DeskId,
+		"date":            reservation.Date.Format("2006-01-02"),
+		"organisation_id": tenantId,
 	}
 
 	stmt, err := db.Conn.PrepareNamedContext(ctx, query)
@@ -56,14 +125,736 @@ func (db *ReservationRepositoryDb) FindReservation(
 func (db *ReservationRepositoryDb) SaveReservation(
 	ctx context.Context,
 	reservation domain.CreateReservation,
-) error {
+) (string, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	reached, err := dailyLimitReached(
+		ctx,
+		tx,
+		tenantId,
+		reservation.UserId,
+		reservation.Date,
+		reservation.DailyLimit,
+		"",
+	)
+	if err != nil {
+		return "", err
+	}
+	if reached {
+		return "", pkg.NewBadRequestError("daily reservation limit reached")
+	}
+
+	var id string
 	query := `
-	INSERT INTO reservations (desk_id, user_id, date)
-	VALUES (:desk_id, :user_id, :date)
+	INSERT INTO reservations (desk_id, user_id, date, status, organisation_id)
+	SELECT id, $2, $3, $4, organisation_id FROM desks
+	WHERE id = $1 AND organisation_id = $5
+	RETURNING id
 	`
-	_, err := db.Conn.NamedExecContext(ctx, query, reservation)
+	err = tx.GetContext(
+		ctx,
+		&id,
+		query,
+		reservation.DeskId,
+		reservation.UserId,
+		reservation.Date.Format("2006-01-02"),
+		reservation.Status,
+		tenantId,
+	)
 	if err != nil {
-		return pkg.NewInternalServerError("failed to save reservation", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", pkg.NewNotFoundError("desk not found")
+		}
+		if isUniqueViolation(err) {
+			return "", pkg.NewConflictError("desk is unavailable", nil)
+		}
+		return "", pkg.NewInternalServerError("failed to save reservation", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", pkg.NewInternalServerError("failed to commit reservation", err)
+	}
+
+	return id, nil
+}
+
+func (db *ReservationRepositoryDb) SaveReservations(
+	ctx context.Context,
+	reservations []domain.CreateReservation,
+) ([]string, []time.Time, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	deskIds := []string{}
+	for _, reservation := range reservations {
+		if !slices.Contains(deskIds, reservation.DeskId) {
+			deskIds = append(deskIds, reservation.DeskId)
+		}
+	}
+	slices.Sort(deskIds)
+
+	for _, deskId := range deskIds {
+		var lockedId string
+		lockQuery := `SELECT id FROM desks WHERE id = $1 AND organisation_id = $2 FOR UPDATE`
+
+		err := tx.GetContext(ctx, &lockedId, lockQuery, deskId, tenantId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, pkg.NewNotFoundError("desk not found")
+		}
+		if err != nil {
+			return nil, nil, pkg.NewInternalServerError("failed to lock desk", err)
+		}
+	}
+
+	conflicts := []time.Time{}
+
+	availabilityQuery := `
+	SELECT COUNT(*) FROM reservations
+	WHERE desk_id = $1
+	AND date = $2
+	AND (status = 'pending' OR status = 'confirmed')
+	`
+	for _, reservation := range reservations {
+		var count int
+
+		err := tx.GetContext(
+			ctx,
+			&count,
+			availabilityQuery,
+			reservation.DeskId,
+			reservation.Date.Format("2006-01-02"),
+		)
+		if err != nil {
+			return nil, nil, pkg.NewInternalServerError("failed to check desk availability", err)
+		}
+
+		if count > 0 {
+			conflicts = append(conflicts, reservation.Date)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return nil, conflicts, nil
+	}
+
+	limitConflicts := []domain.ReservationConflict{}
+	for _, reservation := range reservations {
+		reached, err := dailyLimitReached(
+			ctx,
+			tx,
+			tenantId,
+			reservation.UserId,
+			reservation.Date,
+			reservation.DailyLimit,
+			"",
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if reached {
+			limitConflicts = append(limitConflicts, domain.ReservationConflict{
+				Date:   reservation.Date.Format("2006-01-02"),
+				Reason: "daily reservation limit reached",
+			})
+		}
+	}
+
+	if len(limitConflicts) > 0 {
+		return nil, nil, pkg.NewConflictError("some days could not be booked", limitConflicts)
+	}
+
+	insertQuery := `
+	INSERT INTO reservations (desk_id, user_id, date, status, organisation_id)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id
+	`
+	ids := []string{}
+	for _, reservation := range reservations {
+		var id string
+		err := tx.GetContext(
+			ctx,
+			&id,
+			insertQuery,
+			reservation.DeskId,
+			reservation.UserId,
+			reservation.Date.Format("2006-01-02"),
+			reservation.Status,
+			tenantId,
+		)
+		if isUniqueViolation(err) {
+			return nil, []time.Time{reservation.Date}, nil
+		}
+		if err != nil {
+			return nil, nil, pkg.NewInternalServerError("failed to save reservation", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, pkg.NewInternalServerError("failed to commit reservations", err)
 	}
-	return nil
+
+	return ids, nil, nil
+}
+
+func (db *ReservationRepositoryDb) CountUserReservationsByDate(
+	ctx context.Context,
+	userId string,
+	date time.Time,
+) (int, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+	SELECT COUNT(*) FROM reservations
+	WHERE user_id = $1
+	AND date = $2
+	AND (status = 'pending' OR status = 'confirmed')
+	AND organisation_id = $3
+	`
+
+	var count int
+
+	err = db.Conn.GetContext(ctx, &count, query, userId, date.Format("2006-01-02"), tenantId)
+	if err != nil {
+		return 0, pkg.NewInternalServerError("failed to count reservations", err)
+	}
+
+	return count, nil
+}
+
+func (db *ReservationRepositoryDb) FindReservationById(
+	ctx context.Context,
+	id string,
+) (*domain.Reservation, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var reservation domain.Reservation
+	query := `SELECT * FROM reservations WHERE id = $1 AND organisation_id = $2 LIMIT 1`
+
+	err = db.Conn.GetContext(ctx, &reservation, query, id, tenantId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to find reservation", err)
+	}
+
+	return &reservation, nil
+}
+
+func (db *ReservationRepositoryDb) TransferReservation(
+	ctx context.Context,
+	transfer domain.ReservationTransfer,
+) (bool, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	reached, err := dailyLimitReached(
+		ctx,
+		tx,
+		tenantId,
+		transfer.ToUserId,
+		transfer.Date,
+		transfer.DailyLimit,
+		"",
+	)
+	if err != nil {
+		return false, err
+	}
+	if reached {
+		return false, pkg.NewBadRequestError("daily reservation limit reached")
+	}
+
+	query := `
+	UPDATE reservations
+	SET user_id = $3, updated_at = NOW()
+	WHERE id = $1
+	AND user_id = $2
+	AND date = $4
+	AND guest_id IS NULL
+	AND (status = 'pending' OR status = 'confirmed')
+	AND organisation_id = $5
+	`
+
+	result, err := tx.ExecContext(
+		ctx,
+		query,
+		transfer.Id,
+		transfer.FromUserId,
+		transfer.ToUserId,
+		transfer.Date.Format("2006-01-02"),
+		tenantId,
+	)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to transfer reservation", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to transfer reservation", err)
+	}
+
+	if rows != 1 {
+		return false, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, pkg.NewInternalServerError("failed to commit reservation transfer", err)
+	}
+
+	return true, nil
+}
+
+func (db *ReservationRepositoryDb) SwapReservationOwners(
+	ctx context.Context,
+	swap domain.ReservationSwap,
+) (bool, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	offer := swap.Offer
+
+	offerQuery := `
+	UPDATE swap_offers
+	SET status = 'accepted', updated_at = NOW()
+	WHERE id = $1
+	AND status = 'pending'
+	AND expires_at > NOW()
+	`
+	result, err := tx.ExecContext(ctx, offerQuery, offer.Id)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to swap reservations", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to swap reservations", err)
+	}
+
+	if rows != 1 {
+		return false, nil
+	}
+
+	// Each owner gives up one reservation for the other, so it is left out of
+	// their count. Owners are locked in id order to avoid deadlocks.
+	owners := []struct{ userId, givenUpId string }{
+		{offer.FromUserId, offer.ReservationId},
+		{offer.ToUserId, offer.CounterReservationId},
+	}
+	if owners[1].userId < owners[0].userId {
+		owners[0], owners[1] = owners[1], owners[0]
+	}
+
+	for _, owner := range owners {
+		reached, err := dailyLimitReached(ctx, tx, tenantId, owner.userId, swap.Date, swap.DailyLimit, owner.givenUpId)
+		if err != nil {
+			return false, err
+		}
+		if reached {
+			return false, pkg.NewBadRequestError("daily reservation limit reached")
+		}
+	}
+
+	ownerQuery := `
+	UPDATE reservations
+	SET user_id = $3, updated_at = NOW()
+	WHERE id = $1
+	AND user_id = $2
+	AND date = $4
+	AND guest_id IS NULL
+	AND (status = 'pending' OR status = 'confirmed')
+	AND organisation_id = $5
+	`
+
+	steps := [][]any{
+		{offer.ReservationId, offer.FromUserId, offer.ToUserId, swap.Date.Format("2006-01-02"), tenantId},
+		{offer.CounterReservationId, offer.ToUserId, offer.FromUserId, swap.Date.Format("2006-01-02"), tenantId},
+	}
+
+	for _, args := range steps {
+		result, err := tx.ExecContext(ctx, ownerQuery, args...)
+		if err != nil {
+			return false, pkg.NewInternalServerError("failed to swap reservations", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return false, pkg.NewInternalServerError("failed to swap reservations", err)
+		}
+
+		if rows != 1 {
+			return false, nil
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, pkg.NewInternalServerError("failed to commit reservation swap", err)
+	}
+
+	return true, nil
+}
+
+func (db *ReservationRepositoryDb) FindPendingApprovals(ctx context.Context) ([]domain.Reservation, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+	SELECT * FROM reservations
+	WHERE status = 'pending'
+	AND date >= ` + siteToday + `
+	AND organisation_id = $1
+	ORDER BY date, created_at
+	`
+
+	reservations := []domain.Reservation{}
+
+	if err := db.Conn.SelectContext(ctx, &reservations, query, tenantId); err != nil {
+		return nil, pkg.NewInternalServerError("failed to find pending approvals", err)
+	}
+
+	return reservations, nil
+}
+
+func (db *ReservationRepositoryDb) ReviewReservation(
+	ctx context.Context,
+	id string,
+	status string,
+	reviewerId string,
+) (bool, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	query := `
+	UPDATE reservations
+	SET status = $2, reviewed_by = $3, reviewed_at = NOW(), status_changed_by = $3, updated_at = NOW()
+	WHERE id = $1
+	AND status = 'pending'
+	AND organisation_id = $4
+	`
+
+	result, err := db.Conn.ExecContext(ctx, query, id, status, reviewerId, tenantId)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to review reservation", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to review reservation", err)
+	}
+
+	return rows == 1, nil
+}
+
+func (db *ReservationRepositoryDb) FindReservationsByUser(
+	ctx context.Context,
+	userId string,
+	offset int,
+	limit int,
+) ([]domain.Reservation, int, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+
+	countQuery := `SELECT COUNT(*) FROM reservations WHERE user_id = $1 AND organisation_id = $2`
+	if err := db.Conn.GetContext(ctx, &total, countQuery, userId, tenantId); err != nil {
+		return nil, 0, pkg.NewInternalServerError("failed to count user reservations", err)
+	}
+
+	query := `
+	SELECT * FROM reservations
+	WHERE user_id = $1
+	AND organisation_id = $4
+	ORDER BY date DESC, created_at DESC
+	OFFSET $2 LIMIT $3
+	`
+
+	reservations := []domain.Reservation{}
+
+	if err := db.Conn.SelectContext(ctx, &reservations, query, userId, offset, limit, tenantId); err != nil {
+		return nil, 0, pkg.NewInternalServerError("failed to find user reservations", err)
+	}
+
+	return reservations, total, nil
+}
+
+func (db *ReservationRepositoryDb) DeleteReservationsBefore(ctx context.Context, before time.Time) (int, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+	DELETE FROM swap_offers
+	WHERE reservation_id IN (SELECT id FROM reservations WHERE date < $1 AND organisation_id = $2)
+	OR counter_reservation_id IN (SELECT id FROM reservations WHERE date < $1 AND organisation_id = $2)
+	`, before.Format("2006-01-02"), tenantId)
+	if err != nil {
+		return 0, pkg.NewInternalServerError("failed to delete expired swap offers", err)
+	}
+
+	result, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM reservations WHERE date < $1 AND organisation_id = $2`,
+		before.Format("2006-01-02"),
+		tenantId,
+	)
+	if err != nil {
+		return 0, pkg.NewInternalServerError("failed to delete expired reservations", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, pkg.NewInternalServerError("failed to delete expired reservations", err)
+	}
+
+	// Guests carry no organisation of their own; they belong to their host's.
+	_, err = tx.ExecContext(ctx, `
+	DELETE FROM guests g
+	USING users u
+	WHERE g.host_id = u.id
+	AND u.organisation_id = $2
+	AND g.created_at < $1
+	AND NOT EXISTS (SELECT 1 FROM reservations r WHERE r.guest_id = g.id)
+	`, before, tenantId)
+	if err != nil {
+		return 0, pkg.NewInternalServerError("failed to delete expired guests", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, pkg.NewInternalServerError("failed to commit transaction", err)
+	}
+
+	return int(deleted), nil
+}
+
+func (db *ReservationRepositoryDb) CancelReservation(ctx context.Context, id string, actorId string) (bool, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	query := `
+	UPDATE reservations
+	SET status = 'cancelled', status_changed_by = $3, updated_at = NOW()
+	WHERE id = $1
+	AND organisation_id = $2
+	AND status IN ('pending', 'confirmed')
+	`
+
+	result, err := db.Conn.ExecContext(ctx, query, id, tenantId, actorId)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to cancel reservation", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to cancel reservation", err)
+	}
+
+	return rows == 1, nil
+}
+
+func (db *ReservationRepositoryDb) CheckInReservation(ctx context.Context, id string) (bool, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	query := `
+	UPDATE reservations
+	SET checked_in_at = NOW(), updated_at = NOW()
+	WHERE id = $1
+	AND organisation_id = $2
+	AND status = 'confirmed'
+	AND checked_in_at IS NULL
+	`
+
+	result, err := db.Conn.ExecContext(ctx, query, id, tenantId)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to check in reservation", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to check in reservation", err)
+	}
+
+	return rows == 1, nil
+}
+
+func (db *ReservationRepositoryDb) FindReservationHistory(
+	ctx context.Context,
+	id string,
+) ([]domain.ReservationStatusChange, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+	SELECT id, reservation_id, from_status, to_status, actor_id, changed_at
+	FROM reservation_status_history
+	WHERE reservation_id = $1
+	AND organisation_id = $2
+	ORDER BY changed_at, id
+	`
+
+	history := []domain.ReservationStatusChange{}
+
+	if err := db.Conn.SelectContext(ctx, &history, query, id, tenantId); err != nil {
+		return nil, pkg.NewInternalServerError("failed to find reservation history", err)
+	}
+
+	return history, nil
+}
+
+func (db *ReservationRepositoryDb) MoveReservation(ctx context.Context, move domain.MoveReservation) (bool, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	var lockedId string
+	lockQuery := `SELECT id FROM desks WHERE id = $1 AND organisation_id = $2 FOR UPDATE`
+
+	err = tx.GetContext(ctx, &lockedId, lockQuery, move.DeskId, tenantId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, pkg.NewNotFoundError("desk not found")
+	}
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to lock desk", err)
+	}
+
+	var count int
+	availabilityQuery := `
+	SELECT COUNT(*) FROM reservations
+	WHERE desk_id = $1
+	AND date = $2
+	AND id <> $3
+	AND (status = 'pending' OR status = 'confirmed')
+	`
+
+	err = tx.GetContext(ctx, &count, availabilityQuery, move.DeskId, move.Date.Format("2006-01-02"), move.Id)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to check desk availability", err)
+	}
+
+	if count > 0 {
+		return false, nil
+	}
+
+	reached, err := dailyLimitReached(ctx, tx, tenantId, move.UserId, move.Date, move.DailyLimit, move.Id)
+	if err != nil {
+		return false, err
+	}
+	if reached {
+		return false, pkg.NewBadRequestError("daily reservation limit reached")
+	}
+
+	moveQuery := `
+	UPDATE reservations
+	SET desk_id = $3,
+		date = $4,
+		status = $5,
+		status_changed_by = CASE WHEN status = $5 THEN status_changed_by ELSE $6 END,
+		reviewed_by = CASE WHEN $5 = 'pending' THEN NULL ELSE reviewed_by END,
+		reviewed_at = CASE WHEN $5 = 'pending' THEN NULL ELSE reviewed_at END,
+		updated_at = NOW()
+	WHERE id = $1
+	AND organisation_id = $2
+	AND status = $7
+	AND checked_in_at IS NULL
+	`
+
+	result, err := tx.ExecContext(
+		ctx,
+		moveQuery,
+		move.Id,
+		tenantId,
+		move.DeskId,
+		move.Date.Format("2006-01-02"),
+		move.Status,
+		move.ActorId,
+		move.FromStatus,
+	)
+	if isUniqueViolation(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to move reservation", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to move reservation", err)
+	}
+
+	if rows != 1 {
+		return false, pkg.NewConflictError("reservation was changed by another request", nil)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, pkg.NewInternalServerError("failed to commit reservation move", err)
+	}
+
+	return true, nil
 }
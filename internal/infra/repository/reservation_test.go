@@ -1,7 +1,6 @@
 package infra
 
 import (
-	"context"
 	"database/sql"
 	"fmt"
 	"testing"
@@ -9,8 +8,10 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
 )
 
 func setupReservationRepositoryTestDB(t *testing.T) (*ReservationRepositoryDb, sqlmock.Sqlmock) {
@@ -25,9 +26,15 @@ func setupReservationRepositoryTestDB(t *testing.T) (*ReservationRepositoryDb, s
 	return db, mock
 }
 
+func expectOwnerLock(mock sqlmock.Sqlmock, userId string, tenantId string) {
+	mock.ExpectQuery("SELECT id FROM users WHERE id = \\$1 AND organisation_id = \\$2 FOR UPDATE").
+		WithArgs(userId, tenantId).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userId))
+}
+
 func TestFindReservation(t *testing.T) {
 	db, mock := setupReservationRepositoryTestDB(t)
-	ctx := context.Background()
+	ctx := tenantContext(tenantA)
 	reservation := domain.CreateReservation{
 		DeskId: "123",
 		UserId: "456",
@@ -40,7 +47,7 @@ func TestFindReservation(t *testing.T) {
 
 		mock.ExpectPrepare("SELECT (.+) FROM reservations").
 			ExpectQuery().
-			WithArgs(reservation.DeskId, reservation.Date.Format("2006-01-02")).
+			WithArgs(reservation.DeskId, reservation.Date.Format("2006-01-02"), tenantA).
 			WillReturnRows(rows)
 
 		result, err := db.FindReservation(ctx, reservation)
@@ -58,7 +65,7 @@ func TestFindReservation(t *testing.T) {
 	t.Run("should return nil when reservation not found", func(t *testing.T) {
 		mock.ExpectPrepare("SELECT (.+) FROM reservations").
 			ExpectQuery().
-			WithArgs(reservation.DeskId, reservation.Date.Format("2006-01-02")).
+			WithArgs(reservation.DeskId, reservation.Date.Format("2006-01-02"), tenantA).
 			WillReturnError(sql.ErrNoRows)
 
 		result, err := db.FindReservation(ctx, reservation)
@@ -73,34 +80,484 @@ func TestFindReservation(t *testing.T) {
 
 func TestSaveReservation(t *testing.T) {
 	db, mock := setupReservationRepositoryTestDB(t)
-	ctx := context.Background()
+	ctx := tenantContext(tenantA)
 	reservation := domain.CreateReservation{
 		DeskId: "123",
 		UserId: "456",
 		Date:   time.Now(),
+		Status: "confirmed",
 	}
 
 	t.Run("should save reservation successfully", func(t *testing.T) {
-		mock.ExpectExec("INSERT INTO reservations").
-			WithArgs(reservation.DeskId, reservation.UserId, reservation.Date).
-			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectBegin()
+		expectOwnerLock(mock, reservation.UserId, tenantA)
+		mock.ExpectQuery("INSERT INTO reservations").
+			WithArgs(reservation.DeskId, reservation.UserId, reservation.Date.Format("2006-01-02"), reservation.Status, tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("789"))
+		mock.ExpectCommit()
 
-		err := db.SaveReservation(ctx, reservation)
+		id, err := db.SaveReservation(ctx, reservation)
 		if err != nil {
 			t.Errorf("expected no error, got %v", err)
 		}
+		if id != "789" {
+			t.Errorf("expected reservation id 789, got %s", id)
+		}
 	})
 
 	t.Run("should handle db error", func(t *testing.T) {
-		mock.ExpectExec("INSERT INTO reservations").
-			WithArgs(reservation.DeskId, reservation.UserId, reservation.Date).
+		mock.ExpectBegin()
+		expectOwnerLock(mock, reservation.UserId, tenantA)
+		mock.ExpectQuery("INSERT INTO reservations").
+			WithArgs(reservation.DeskId, reservation.UserId, reservation.Date.Format("2006-01-02"), reservation.Status, tenantA).
 			WillReturnError(fmt.Errorf("db error"))
+		mock.ExpectRollback()
 
-		err := db.SaveReservation(ctx, reservation)
+		_, err := db.SaveReservation(ctx, reservation)
 
 		if err == nil {
 			t.Error("expected error, got nil")
 		}
 	})
+
+	t.Run("should report a concurrent booking of the desk as a conflict", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectOwnerLock(mock, reservation.UserId, tenantA)
+		mock.ExpectQuery("INSERT INTO reservations").
+			WithArgs(reservation.DeskId, reservation.UserId, reservation.Date.Format("2006-01-02"), reservation.Status, tenantA).
+			WillReturnError(&pq.Error{Code: "23505"})
+		mock.ExpectRollback()
+
+		_, err := db.SaveReservation(ctx, reservation)
+
+		if _, ok := err.(*pkg.ConflictError); !ok {
+			t.Errorf("expected conflict error, got %v", err)
+		}
+	})
+
+	t.Run("should check the daily limit under the owner's lock", func(t *testing.T) {
+		limited := reservation
+		limited.DailyLimit = 2
+
+		mock.ExpectBegin()
+		expectOwnerLock(mock, reservation.UserId, tenantA)
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM reservations WHERE user_id = \\$1").
+			WithArgs(reservation.UserId, reservation.Date.Format("2006-01-02"), "", tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectRollback()
+
+		_, err := db.SaveReservation(ctx, limited)
+
+		if err == nil || err.Error() != "daily reservation limit reached" {
+			t.Errorf("expected daily limit error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestCountUserReservationsByDate(t *testing.T) {
+	db, mock := setupReservationRepositoryTestDB(t)
+	ctx := tenantContext(tenantA)
+	date := time.Now()
+
+	t.Run("should count reservations successfully", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT(.+) FROM reservations").
+			WithArgs("456", date.Format("2006-01-02"), tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+		count, err := db.CountUserReservationsByDate(ctx, "456", date)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if count != 2 {
+			t.Errorf("expected count 2, got %d", count)
+		}
+	})
+
+	t.Run("should handle db error", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT(.+) FROM reservations").
+			WithArgs("456", date.Format("2006-01-02"), tenantA).
+			WillReturnError(fmt.Errorf("db error"))
+
+		_, err := db.CountUserReservationsByDate(ctx, "456", date)
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestSaveReservations(t *testing.T) {
+	db, mock := setupReservationRepositoryTestDB(t)
+	ctx := tenantContext(tenantA)
+	firstDay := time.Now()
+	secondDay := firstDay.AddDate(0, 0, 1)
+	reservations := []domain.CreateReservation{
+		{DeskId: "123", UserId: "456", Date: firstDay, Status: "confirmed"},
+		{DeskId: "123", UserId: "456", Date: secondDay, Status: "confirmed"},
+	}
+
+	t.Run("should save all reservations when every day is available", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM desks").
+			WithArgs("123", tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("123"))
+		for _, reservation := range reservations {
+			mock.ExpectQuery("SELECT COUNT(.+) FROM reservations").
+				WithArgs(reservation.DeskId, reservation.Date.Format("2006-01-02")).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		}
+		for _, reservation := range reservations {
+			expectOwnerLock(mock, reservation.UserId, tenantA)
+		}
+		for i, reservation := range reservations {
+			mock.ExpectQuery("INSERT INTO reservations").
+				WithArgs(reservation.DeskId, reservation.UserId, reservation.Date.Format("2006-01-02"), reservation.Status, tenantA).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fmt.Sprintf("reservation-%d", i)))
+		}
+		mock.ExpectCommit()
+
+		ids, conflicts, err := db.SaveReservations(ctx, reservations)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(conflicts) != 0 {
+			t.Errorf("expected no conflicts, got %v", conflicts)
+		}
+		if len(ids) != 2 || ids[0] != "reservation-0" || ids[1] != "reservation-1" {
+			t.Errorf("expected the inserted ids, got %v", ids)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should return conflicting days without saving", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM desks").
+			WithArgs("123", tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("123"))
+		mock.ExpectQuery("SELECT COUNT(.+) FROM reservations").
+			WithArgs("123", firstDay.Format("2006-01-02")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery("SELECT COUNT(.+) FROM reservations").
+			WithArgs("123", secondDay.Format("2006-01-02")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		ids, conflicts, err := db.SaveReservations(ctx, reservations)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(conflicts) != 1 || !conflicts[0].Equal(secondDay) {
+			t.Errorf("expected conflict on %v, got %v", secondDay, conflicts)
+		}
+		if ids != nil {
+			t.Errorf("expected no ids, got %v", ids)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestTransferReservation(t *testing.T) {
+	db, mock := setupReservationRepositoryTestDB(t)
+	ctx := tenantContext(tenantA)
+	transfer := domain.ReservationTransfer{
+		Id:         "789",
+		FromUserId: "456",
+		ToUserId:   "999",
+		Date:       time.Now(),
+	}
+
+	t.Run("should transfer reservation owned by user", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectOwnerLock(mock, transfer.ToUserId, tenantA)
+		mock.ExpectExec("UPDATE reservations").
+			WithArgs("789", "456", "999", transfer.Date.Format("2006-01-02"), tenantA).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		transferred, err := db.TransferReservation(ctx, transfer)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if !transferred {
+			t.Error("expected reservation to be transferred")
+		}
+	})
+
+	t.Run("should not transfer when no row matches", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectOwnerLock(mock, transfer.ToUserId, tenantA)
+		mock.ExpectExec("UPDATE reservations").
+			WithArgs("789", "456", "999", transfer.Date.Format("2006-01-02"), tenantA).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		transferred, err := db.TransferReservation(ctx, transfer)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if transferred {
+			t.Error("expected reservation not to be transferred")
+		}
+	})
+
+	t.Run("should check the colleague's daily limit under their lock", func(t *testing.T) {
+		limited := transfer
+		limited.DailyLimit = 1
+
+		mock.ExpectBegin()
+		expectOwnerLock(mock, transfer.ToUserId, tenantA)
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM reservations WHERE user_id = \\$1").
+			WithArgs(transfer.ToUserId, transfer.Date.Format("2006-01-02"), "", tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		_, err := db.TransferReservation(ctx, limited)
+		if err == nil || err.Error() != "daily reservation limit reached" {
+			t.Errorf("expected daily limit error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
 }
 
+func TestSwapReservationOwners(t *testing.T) {
+	db, mock := setupReservationRepositoryTestDB(t)
+	ctx := tenantContext(tenantA)
+	swap := domain.ReservationSwap{
+		Offer: domain.SwapOffer{
+			Id:                   "offer",
+			ReservationId:        "mine",
+			CounterReservationId: "theirs",
+			FromUserId:           "456",
+			ToUserId:             "999",
+		},
+		Date: time.Now(),
+	}
+	offer := swap.Offer
+	day := swap.Date.Format("2006-01-02")
+
+	t.Run("should swap owners and accept offer", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE swap_offers").
+			WithArgs(offer.Id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectOwnerLock(mock, offer.FromUserId, tenantA)
+		expectOwnerLock(mock, offer.ToUserId, tenantA)
+		mock.ExpectExec("UPDATE reservations").
+			WithArgs(offer.ReservationId, offer.FromUserId, offer.ToUserId, day, tenantA).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE reservations").
+			WithArgs(offer.CounterReservationId, offer.ToUserId, offer.FromUserId, day, tenantA).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		swapped, err := db.SwapReservationOwners(ctx, swap)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if !swapped {
+			t.Error("expected reservations to be swapped")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should rollback when counter reservation changed owner", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE swap_offers").
+			WithArgs(offer.Id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectOwnerLock(mock, offer.FromUserId, tenantA)
+		expectOwnerLock(mock, offer.ToUserId, tenantA)
+		mock.ExpectExec("UPDATE reservations").
+			WithArgs(offer.ReservationId, offer.FromUserId, offer.ToUserId, day, tenantA).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE reservations").
+			WithArgs(offer.CounterReservationId, offer.ToUserId, offer.FromUserId, day, tenantA).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		swapped, err := db.SwapReservationOwners(ctx, swap)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if swapped {
+			t.Error("expected reservations not to be swapped")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should leave out the reservation each owner gives up from the limit", func(t *testing.T) {
+		limited := swap
+		limited.DailyLimit = 1
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE swap_offers").
+			WithArgs(offer.Id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectOwnerLock(mock, offer.FromUserId, tenantA)
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM reservations WHERE user_id = \\$1").
+			WithArgs(offer.FromUserId, day, offer.ReservationId, tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		_, err := db.SwapReservationOwners(ctx, limited)
+		if err == nil || err.Error() != "daily reservation limit reached" {
+			t.Errorf("expected daily limit error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestCancelReservation(t *testing.T) {
+	db, mock := setupReservationRepositoryTestDB(t)
+	ctx := tenantContext(tenantA)
+
+	t.Run("should cancel an active reservation", func(t *testing.T) {
+		mock.ExpectExec("UPDATE reservations SET status = 'cancelled', status_changed_by = \\$3(.+) status IN \\('pending', 'confirmed'\\)").
+			WithArgs("res-1", tenantA, "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		cancelled, err := db.CancelReservation(ctx, "res-1", "user-1")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if !cancelled {
+			t.Error("expected reservation to be cancelled")
+		}
+	})
+
+	t.Run("should report an inactive reservation", func(t *testing.T) {
+		mock.ExpectExec("UPDATE reservations SET status = 'cancelled'").
+			WithArgs("res-1", tenantA, "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		cancelled, err := db.CancelReservation(ctx, "res-1", "user-1")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if cancelled {
+			t.Error("expected reservation not to be cancelled")
+		}
+	})
+}
+
+func TestCheckInReservation(t *testing.T) {
+	db, mock := setupReservationRepositoryTestDB(t)
+
+	mock.ExpectExec("UPDATE reservations SET checked_in_at = NOW(.+) status = 'confirmed' AND checked_in_at IS NULL").
+		WithArgs("res-1", tenantA).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	checkedIn, err := db.CheckInReservation(tenantContext(tenantA), "res-1")
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if !checkedIn {
+		t.Error("expected reservation to be checked in")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestFindReservationHistory(t *testing.T) {
+	db, mock := setupReservationRepositoryTestDB(t)
+	changedAt := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "reservation_id", "from_status", "to_status", "actor_id", "changed_at"}).
+		AddRow("h-1", "res-1", nil, "pending", "user-1", changedAt).
+		AddRow("h-2", "res-1", "pending", "confirmed", "approver-1", changedAt)
+
+	mock.ExpectQuery("SELECT (.+) FROM reservation_status_history WHERE reservation_id = \\$1 AND organisation_id = \\$2").
+		WithArgs("res-1", tenantA).
+		WillReturnRows(rows)
+
+	history, err := db.FindReservationHistory(tenantContext(tenantA), "res-1")
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 status changes, got %d", len(history))
+	}
+	if history[0].FromStatus != nil || *history[1].FromStatus != "pending" {
+		t.Errorf("expected history to start from nothing then pending, got %+v", history)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMoveReservation(t *testing.T) {
+	db, mock := setupReservationRepositoryTestDB(t)
+	ctx := tenantContext(tenantA)
+	move := domain.MoveReservation{
+		Id:         "res-1",
+		DeskId:     "desk-2",
+		Date:       time.Now(),
+		FromStatus: "confirmed",
+		Status:     "confirmed",
+		ActorId:    "user-1",
+		UserId:     "user-1",
+	}
+
+	t.Run("should move reservation in one transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM desks WHERE id = \\$1 AND organisation_id = \\$2 FOR UPDATE").
+			WithArgs(move.DeskId, tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(move.DeskId))
+		mock.ExpectQuery("SELECT COUNT(.+) FROM reservations WHERE desk_id = \\$1 AND date = \\$2 AND id <> \\$3").
+			WithArgs(move.DeskId, move.Date.Format("2006-01-02"), move.Id).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		expectOwnerLock(mock, move.UserId, tenantA)
+		mock.ExpectExec("UPDATE reservations SET desk_id = \\$3").
+			WithArgs(move.Id, tenantA, move.DeskId, move.Date.Format("2006-01-02"), move.Status, move.ActorId, move.FromStatus).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		moved, err := db.MoveReservation(ctx, move)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if !moved {
+			t.Error("expected reservation to be moved")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should keep the original booking when the new slot is taken", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM desks").
+			WithArgs(move.DeskId, tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(move.DeskId))
+		mock.ExpectQuery("SELECT COUNT(.+) FROM reservations").
+			WithArgs(move.DeskId, move.Date.Format("2006-01-02"), move.Id).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		moved, err := db.MoveReservation(ctx, move)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if moved {
+			t.Error("expected reservation not to be moved")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type SiteRepositoryDb struct {
+	Conn *sqlx.DB
+}
+
+func (db *SiteRepositoryDb) FindSiteById(ctx context.Context, id string) (*domain.Site, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var site domain.Site
+	query := `SELECT * FROM sites WHERE id = $1 AND organisation_id = $2 LIMIT 1`
+
+	err = db.Conn.GetContext(ctx, &site, query, id, tenantId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to find site", err)
+	}
+
+	return &site, nil
+}
+
+func (db *SiteRepositoryDb) UpdateSiteTimeZone(ctx context.Context, id string, timeZone string) (bool, error) {
+	tenantId, err := requireTenant(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	query := `UPDATE sites SET time_zone = $2 WHERE id = $1 AND organisation_id = $3`
+
+	result, err := db.Conn.ExecContext(ctx, query, id, timeZone, tenantId)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to update site", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to update site", err)
+	}
+
+	return rows == 1, nil
+}
@@ -0,0 +1,52 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func setupSiteRepositoryTestDB(t *testing.T) (*SiteRepositoryDb, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+	db := &SiteRepositoryDb{Conn: sqlxDB}
+
+	return db, mock
+}
+
+func TestUpdateSiteTimeZone(t *testing.T) {
+	t.Run("should update the request tenant's site", func(t *testing.T) {
+		db, mock := setupSiteRepositoryTestDB(t)
+
+		mock.ExpectExec("UPDATE sites SET time_zone = \\$2 WHERE id = \\$1 AND organisation_id = \\$3").
+			WithArgs("site-1", "Europe/Lisbon", tenantA).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		updated, err := db.UpdateSiteTimeZone(tenantContext(tenantA), "site-1", "Europe/Lisbon")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if !updated {
+			t.Error("expected site to be updated")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should refuse without a tenant", func(t *testing.T) {
+		db, _ := setupSiteRepositoryTestDB(t)
+
+		_, err := db.UpdateSiteTimeZone(context.Background(), "site-1", "Europe/Lisbon")
+		if !errors.Is(err, ErrTenantRequired) {
+			t.Errorf("expected tenant required error, got %v", err)
+		}
+	})
+}
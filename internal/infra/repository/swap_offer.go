@@ -0,0 +1,101 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type SwapOfferRepositoryDb struct {
+	Conn *sqlx.DB
+}
+
+func (db *SwapOfferRepositoryDb) SaveSwapOffer(
+	ctx context.Context,
+	offer domain.CreateSwapOffer,
+) (*domain.SwapOffer, error) {
+	query := `
+	INSERT INTO swap_offers (reservation_id, counter_reservation_id, from_user_id, to_user_id, expires_at)
+	VALUES (:reservation_id, :counter_reservation_id, :from_user_id, :to_user_id, :expires_at)
+	RETURNING *
+	`
+
+	stmt, err := db.Conn.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to prepare statement", err)
+	}
+	defer stmt.Close()
+
+	var result domain.SwapOffer
+
+	if err := stmt.GetContext(ctx, &result, offer); err != nil {
+		return nil, pkg.NewInternalServerError("failed to save swap offer", err)
+	}
+
+	return &result, nil
+}
+
+func (db *SwapOfferRepositoryDb) FindSwapOfferById(
+	ctx context.Context,
+	id string,
+) (*domain.SwapOffer, error) {
+	var offer domain.SwapOffer
+	query := `SELECT * FROM swap_offers WHERE id = $1 LIMIT 1`
+
+	err := db.Conn.GetContext(ctx, &offer, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to find swap offer", err)
+	}
+
+	return &offer, nil
+}
+
+func (db *SwapOfferRepositoryDb) FindPendingSwapOffersByUser(
+	ctx context.Context,
+	userId string,
+) ([]domain.SwapOffer, error) {
+	query := `
+	SELECT * FROM swap_offers
+	WHERE to_user_id = $1
+	AND status = 'pending'
+	AND expires_at > NOW()
+	ORDER BY created_at
+	`
+
+	offers := []domain.SwapOffer{}
+
+	if err := db.Conn.SelectContext(ctx, &offers, query, userId); err != nil {
+		return nil, pkg.NewInternalServerError("failed to find swap offers", err)
+	}
+
+	return offers, nil
+}
+
+func (db *SwapOfferRepositoryDb) UpdateSwapOfferStatus(
+	ctx context.Context,
+	id string,
+	status string,
+) (bool, error) {
+	query := `UPDATE swap_offers SET status = $2, updated_at = NOW() WHERE id = $1 AND status = 'pending'`
+
+	result, err := db.Conn.ExecContext(ctx, query, id, status)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to update swap offer", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to update swap offer", err)
+	}
+
+	return rows == 1, nil
+}
@@ -0,0 +1,57 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func setupSwapOfferRepositoryTestDB(t *testing.T) (*SwapOfferRepositoryDb, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+	db := &SwapOfferRepositoryDb{Conn: sqlxDB}
+
+	return db, mock
+}
+
+func TestUpdateSwapOfferStatus(t *testing.T) {
+	db, mock := setupSwapOfferRepositoryTestDB(t)
+	ctx := context.Background()
+
+	t.Run("should update pending offers", func(t *testing.T) {
+		mock.ExpectExec("UPDATE swap_offers SET status = \\$2, updated_at = NOW\\(\\) WHERE id = \\$1 AND status = 'pending'").
+			WithArgs("offer-1", "declined").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		updated, err := db.UpdateSwapOfferStatus(ctx, "offer-1", "declined")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if !updated {
+			t.Error("expected offer to be updated")
+		}
+	})
+
+	t.Run("should leave offers that are no longer pending", func(t *testing.T) {
+		mock.ExpectExec("UPDATE swap_offers SET status = \\$2").
+			WithArgs("offer-1", "declined").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		updated, err := db.UpdateSwapOfferStatus(ctx, "offer-1", "declined")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if updated {
+			t.Error("expected offer not to be updated")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
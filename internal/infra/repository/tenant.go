@@ -0,0 +1,66 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+var ErrTenantRequired = errors.New("tenant is required")
+
+func requireTenant(ctx context.Context) (string, error) {
+	tenantId, ok := utils.GetContextValue[string](ctx, utils.AuthTenantKey)
+	if !ok || tenantId == "" {
+		return "", pkg.NewInternalServerError("failed to resolve tenant", ErrTenantRequired)
+	}
+
+	return tenantId, nil
+}
+
+// Users are looked up by email or id before a tenant is known (login, token
+// refresh, SCIM), so user queries are only scoped when the request has one.
+func scopeUsers(ctx context.Context, column string, args []any) (string, []any) {
+	tenantId, ok := utils.GetContextValue[string](ctx, utils.AuthTenantKey)
+	if !ok || tenantId == "" {
+		return "", args
+	}
+
+	args = append(args, tenantId)
+	return fmt.Sprintf(" AND %s = $%d", column, len(args)), args
+}
+
+// Sign-ups, OIDC and LDAP logins arrive without a tenant. They join the
+// organisation claiming the email's domain, or the default organisation when
+// none does. Emails stay globally unique because login resolves the tenant
+// from the email, so an address can only ever belong to one organisation.
+func signupTenant(ctx context.Context, conn sqlx.QueryerContext, email string) (string, error) {
+	if tenantId, ok := utils.GetContextValue[string](ctx, utils.AuthTenantKey); ok && tenantId != "" {
+		return tenantId, nil
+	}
+
+	_, emailDomain, found := strings.Cut(email, "@")
+	if !found || emailDomain == "" {
+		return domain.DefaultOrganisationId, nil
+	}
+
+	var tenantId string
+	query := `SELECT id FROM organisations WHERE signup_domain = $1`
+
+	err := sqlx.GetContext(ctx, conn, &tenantId, query, strings.ToLower(emailDomain))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.DefaultOrganisationId, nil
+	}
+	if err != nil {
+		return "", pkg.NewInternalServerError("failed to resolve organisation", err)
+	}
+
+	return tenantId, nil
+}
@@ -0,0 +1,209 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+)
+
+const (
+	tenantA = "aaaaaaaa-0000-0000-0000-000000000001"
+	tenantB = "bbbbbbbb-0000-0000-0000-000000000002"
+)
+
+func tenantContext(tenantId string) context.Context {
+	return utils.SetContextValue(context.Background(), utils.AuthTenantKey, tenantId)
+}
+
+func expectSignupDomain(mock sqlmock.Sqlmock, emailDomain string, tenantId string) {
+	rows := sqlmock.NewRows([]string{"id"})
+	if tenantId != "" {
+		rows.AddRow(tenantId)
+	}
+
+	mock.ExpectQuery("SELECT id FROM organisations WHERE signup_domain = \\$1").
+		WithArgs(emailDomain).
+		WillReturnRows(rows)
+}
+
+func TestTenantIsolation(t *testing.T) {
+	deskOfTenantB := "desk-b"
+
+	t.Run("should not find another tenant's desk", func(t *testing.T) {
+		db, mock := setupDeskRepositoryTestDB(t)
+
+		mock.ExpectQuery("SELECT (.+) FROM desks d (.+) AND d.organisation_id = \\$2").
+			WithArgs(deskOfTenantB, tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		desk, err := db.FindDeskById(tenantContext(tenantA), deskOfTenantB)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if desk != nil {
+			t.Error("expected tenant A not to see tenant B's desk")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should not book another tenant's desk", func(t *testing.T) {
+		db, mock := setupReservationRepositoryTestDB(t)
+		reservation := domain.CreateReservation{
+			DeskId: deskOfTenantB,
+			UserId: "user-a",
+			Date:   time.Now(),
+			Status: "confirmed",
+		}
+
+		mock.ExpectBegin()
+		expectOwnerLock(mock, reservation.UserId, tenantA)
+		mock.ExpectQuery("INSERT INTO reservations (.+) SELECT (.+) FROM desks WHERE id = \\$1 AND organisation_id = \\$5").
+			WithArgs(reservation.DeskId, reservation.UserId, reservation.Date.Format("2006-01-02"), reservation.Status, tenantA).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := db.SaveReservation(tenantContext(tenantA), reservation)
+		if err == nil || err.Error() != "desk not found" {
+			t.Errorf("expected desk not found, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should not book for another tenant's user", func(t *testing.T) {
+		db, mock := setupReservationRepositoryTestDB(t)
+		reservation := domain.CreateReservation{
+			DeskId: "desk-a",
+			UserId: "user-b",
+			Date:   time.Now(),
+			Status: "confirmed",
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM users WHERE id = \\$1 AND organisation_id = \\$2 FOR UPDATE").
+			WithArgs(reservation.UserId, tenantA).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := db.SaveReservation(tenantContext(tenantA), reservation)
+		if err == nil || err.Error() != "user not found" {
+			t.Errorf("expected user not found, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should not lock another tenant's desk for a range booking", func(t *testing.T) {
+		db, mock := setupReservationRepositoryTestDB(t)
+		reservations := []domain.CreateReservation{{DeskId: deskOfTenantB, UserId: "user-a", Date: time.Now()}}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM desks WHERE id = \\$1 AND organisation_id = \\$2 FOR UPDATE").
+			WithArgs(deskOfTenantB, tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		_, _, err := db.SaveReservations(tenantContext(tenantA), reservations)
+		if err == nil || err.Error() != "desk not found" {
+			t.Errorf("expected desk not found, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should only list reservations of the request tenant", func(t *testing.T) {
+		db, mock := setupReservationRepositoryTestDB(t)
+
+		mock.ExpectQuery("SELECT (.+) FROM reservations (.+) AND organisation_id = \\$1").
+			WithArgs(tenantB).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "organisation_id"}).AddRow("r-1", tenantB))
+
+		reservations, err := db.FindPendingApprovals(tenantContext(tenantB))
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(reservations) != 1 || reservations[0].OrganisationId != tenantB {
+			t.Errorf("expected only tenant B reservations, got %+v", reservations)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should scope user lookups to the request tenant", func(t *testing.T) {
+		db, mock := setupUserRepositoryTestDB(t)
+
+		mock.ExpectQuery("SELECT \\* FROM users WHERE id = \\$1 AND organisation_id = \\$2 LIMIT 1").
+			WithArgs("user-b", tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		user, err := db.FindUserById(tenantContext(tenantA), "user-b")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if user != nil {
+			t.Error("expected tenant A not to see tenant B's user")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should only purge the request tenant's reservations and guests", func(t *testing.T) {
+		db, mock := setupReservationRepositoryTestDB(t)
+		before := time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM swap_offers (.+) organisation_id = \\$2").
+			WithArgs("2024-07-08", tenantA).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM reservations WHERE date < \\$1 AND organisation_id = \\$2").
+			WithArgs("2024-07-08", tenantA).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec("DELETE FROM guests g USING users u (.+) u.organisation_id = \\$2").
+			WithArgs(before, tenantA).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		deleted, err := db.DeleteReservationsBefore(tenantContext(tenantA), before)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if deleted != 3 {
+			t.Errorf("expected 3 deleted reservations, got %d", deleted)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should refuse to purge without a tenant", func(t *testing.T) {
+		db, _ := setupReservationRepositoryTestDB(t)
+
+		_, err := db.DeleteReservationsBefore(context.Background(), time.Now())
+		if !errors.Is(err, ErrTenantRequired) {
+			t.Errorf("expected tenant required error, got %v", err)
+		}
+	})
+
+	t.Run("should refuse desk queries without a tenant", func(t *testing.T) {
+		db, _ := setupDeskRepositoryTestDB(t)
+
+		_, err := db.FindDeskById(context.Background(), deskOfTenantB)
+		if !errors.Is(err, ErrTenantRequired) {
+			t.Errorf("expected tenant required error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type TokenRepositoryDb struct {
+	Conn *sqlx.DB
+}
+
+const insertRefreshTokenQuery = `
+	INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at)
+	VALUES ($1, COALESCE(NULLIF($2, '')::uuid, uuid_generate_v4()), $3, $4)
+	`
+
+func (db *TokenRepositoryDb) SaveRefreshToken(
+	ctx context.Context,
+	token domain.CreateRefreshToken,
+) error {
+	_, err := db.Conn.ExecContext(
+		ctx,
+		insertRefreshTokenQuery,
+		token.UserId,
+		token.FamilyId,
+		token.TokenHash,
+		token.ExpiresAt,
+	)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to save refresh token", err)
+	}
+
+	return nil
+}
+
+func (db *TokenRepositoryDb) FindRefreshToken(
+	ctx context.Context,
+	tokenHash string,
+) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	query := `SELECT * FROM refresh_tokens WHERE token_hash = $1 LIMIT 1`
+
+	err := db.Conn.GetContext(ctx, &token, query, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to find refresh token", err)
+	}
+
+	return &token, nil
+}
+
+func (db *TokenRepositoryDb) RotateRefreshToken(
+	ctx context.Context,
+	currentId string,
+	token domain.CreateRefreshToken,
+) (bool, error) {
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	revokeQuery := `
+	UPDATE refresh_tokens
+	SET revoked_at = NOW()
+	WHERE id = $1
+	AND revoked_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, revokeQuery, currentId)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to rotate refresh token", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to rotate refresh token", err)
+	}
+
+	if rows != 1 {
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		insertRefreshTokenQuery,
+		token.UserId,
+		token.FamilyId,
+		token.TokenHash,
+		token.ExpiresAt,
+	)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to save refresh token", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, pkg.NewInternalServerError("failed to commit refresh token rotation", err)
+	}
+
+	return true, nil
+}
+
+func (db *TokenRepositoryDb) RevokeTokenFamily(ctx context.Context, familyId string) error {
+	query := `
+	UPDATE refresh_tokens
+	SET revoked_at = NOW()
+	WHERE family_id = $1
+	AND revoked_at IS NULL
+	`
+
+	if _, err := db.Conn.ExecContext(ctx, query, familyId); err != nil {
+		return pkg.NewInternalServerError("failed to revoke refresh tokens", err)
+	}
+
+	return nil
+}
+
+func (db *TokenRepositoryDb) RevokeAccessToken(
+	ctx context.Context,
+	jti string,
+	expiresAt time.Time,
+) error {
+	query := `
+	INSERT INTO revoked_tokens (jti, expires_at)
+	VALUES ($1, $2)
+	ON CONFLICT (jti) DO NOTHING
+	`
+
+	if _, err := db.Conn.ExecContext(ctx, query, jti, expiresAt); err != nil {
+		return pkg.NewInternalServerError("failed to revoke access token", err)
+	}
+
+	cleanupQuery := `DELETE FROM revoked_tokens WHERE expires_at < NOW()`
+	if _, err := db.Conn.ExecContext(ctx, cleanupQuery); err != nil {
+		pkg.GetLogger().Warn("Could not clean up revoked tokens: %v", err)
+	}
+
+	return nil
+}
+
+func (db *TokenRepositoryDb) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	query := `SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)`
+
+	if err := db.Conn.GetContext(ctx, &revoked, query, jti); err != nil {
+		return false, pkg.NewInternalServerError("failed to check token revocation", err)
+	}
+
+	return revoked, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
@@ -21,9 +23,10 @@ func (db *UserRepositoryDb) FindUserByEmail(
 	email string,
 ) (*domain.User, error) {
 	var user domain.User
-	query := `SELECT * FROM users WHERE email = $1 LIMIT 1`
+	scope, args := scopeUsers(ctx, "organisation_id", []any{email})
+	query := `SELECT * FROM users WHERE email = $1` + scope + ` LIMIT 1`
 
-	err := db.Conn.GetContext(ctx, &user, query, email)
+	err := db.Conn.GetContext(ctx, &user, query, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -34,17 +37,566 @@ func (db *UserRepositoryDb) FindUserByEmail(
 	return &user, nil
 }
 
-func (db *UserRepositoryDb) SaveUser(ctx context.Context, user domain.CreateUser) error {
+func (db *UserRepositoryDb) FindUserById(ctx context.Context, id string) (*domain.User, error) {
+	var user domain.User
+	scope, args := scopeUsers(ctx, "organisation_id", []any{id})
+	query := `SELECT * FROM users WHERE id = $1` + scope + ` LIMIT 1`
+
+	err := db.Conn.GetContext(ctx, &user, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to query user by id", err)
+	}
+
+	return &user, nil
+}
+
+func (db *UserRepositoryDb) SaveUser(ctx context.Context, user domain.CreateUser) (*domain.User, error) {
+	tenantId, err := signupTenant(ctx, db.Conn, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved domain.User
 	query := `
-	INSERT INTO users (name, email, password)
-	VALUES (:name, :email, :password)
+	INSERT INTO users (name, email, password, organisation_id)
+	VALUES ($1, $2, $3, $4)
 	ON CONFLICT (email) DO NOTHING
+	RETURNING *
+	`
+
+	err = db.Conn.GetContext(ctx, &saved, query, user.Name, user.Email, user.Password, tenantId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to save user", err)
+	}
+
+	return &saved, nil
+}
+
+func (db *UserRepositoryDb) UpsertDirectoryUser(
+	ctx context.Context,
+	user domain.DirectoryUser,
+) (*domain.User, error) {
+	tenantId, err := signupTenant(ctx, db.Conn, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved domain.User
+	query := `
+	INSERT INTO users (name, email, password, role, email_verified_at, organisation_id)
+	VALUES ($1, $2, '', $3, NOW(), $4)
+	ON CONFLICT (email) DO UPDATE
+	SET name = EXCLUDED.name,
+		role = EXCLUDED.role,
+		email_verified_at = COALESCE(users.email_verified_at, NOW()),
+		updated_at = NOW()
+	RETURNING *
+	`
+
+	err = db.Conn.GetContext(ctx, &saved, query, user.Name, user.Email, user.Role, tenantId)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to save directory user", err)
+	}
+
+	return &saved, nil
+}
+
+func (db *UserRepositoryDb) FindUsersByRole(ctx context.Context, role string) ([]domain.User, error) {
+	scope, args := scopeUsers(ctx, "organisation_id", []any{role})
+	query := `
+	SELECT * FROM users
+	WHERE role = $1
+	AND deactivated_at IS NULL
+	AND deleted_at IS NULL` + scope + `
+	ORDER BY name`
+
+	users := []domain.User{}
+
+	if err := db.Conn.SelectContext(ctx, &users, query, args...); err != nil {
+		return nil, pkg.NewInternalServerError("failed to query users by role", err)
+	}
+
+	return users, nil
+}
+
+func (db *UserRepositoryDb) ListUsers(
+	ctx context.Context,
+	offset int,
+	limit int,
+) ([]domain.User, int, error) {
+	countScope, countArgs := scopeUsers(ctx, "organisation_id", []any{})
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM users WHERE deleted_at IS NULL` + countScope
+	if err := db.Conn.GetContext(ctx, &total, countQuery, countArgs...); err != nil {
+		return nil, 0, pkg.NewInternalServerError("failed to count users", err)
+	}
+
+	scope, args := scopeUsers(ctx, "organisation_id", []any{offset, limit})
+	query := `SELECT * FROM users WHERE deleted_at IS NULL` + scope + ` ORDER BY created_at, id OFFSET $1 LIMIT $2`
+
+	users := []domain.User{}
+
+	if err := db.Conn.SelectContext(ctx, &users, query, args...); err != nil {
+		return nil, 0, pkg.NewInternalServerError("failed to list users", err)
+	}
+
+	return users, total, nil
+}
+
+func (db *UserRepositoryDb) SaveProvisionedUser(
+	ctx context.Context,
+	user domain.ProvisionUser,
+) (*domain.User, error) {
+	tenantId, err := signupTenant(ctx, db.Conn, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved domain.User
+	query := `
+	INSERT INTO users (name, email, password, external_id, email_verified_at, organisation_id)
+	VALUES ($1, $2, '', $3, NOW(), $4)
+	RETURNING *
 	`
 
-	_, err := db.Conn.NamedExecContext(ctx, query, user)
+	err = db.Conn.GetContext(ctx, &saved, query, user.Name, user.Email, user.ExternalId, tenantId)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to save provisioned user", err)
+	}
+
+	return &saved, nil
+}
+
+func (db *UserRepositoryDb) UpdateProvisionedUser(
+	ctx context.Context,
+	id string,
+	user domain.ProvisionUser,
+) (*domain.User, error) {
+	var saved domain.User
+	scope, args := scopeUsers(ctx, "organisation_id", []any{id, user.Name, user.Email, user.ExternalId})
+	query := `
+	UPDATE users
+	SET name = $2, email = $3, external_id = $4, updated_at = NOW()
+	WHERE id = $1` + scope + `
+	RETURNING *
+	`
+
+	err := db.Conn.GetContext(ctx, &saved, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to update provisioned user", err)
+	}
+
+	return &saved, nil
+}
+
+func (db *UserRepositoryDb) UpdateUserRole(ctx context.Context, id string, role string) (bool, error) {
+	scope, args := scopeUsers(ctx, "organisation_id", []any{id, role})
+	query := `UPDATE users SET role = $2, updated_at = NOW() WHERE id = $1` + scope
+
+	result, err := db.Conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to update user role", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to update user role", err)
+	}
+
+	return rows == 1, nil
+}
+
+func (db *UserRepositoryDb) DeactivateUser(ctx context.Context, id string) (int, error) {
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	if found, err := lockUser(ctx, tx, id); err != nil || !found {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE users
+	SET deactivated_at = NOW(), updated_at = NOW()
+	WHERE id = $1 AND deactivated_at IS NULL
+	`, id)
+	if err != nil {
+		return 0, pkg.NewInternalServerError("failed to deactivate user", err)
+	}
+
+	cancelled, err := releaseUserAccess(ctx, tx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, pkg.NewInternalServerError("failed to commit transaction", err)
+	}
+
+	return cancelled, nil
+}
+
+func (db *UserRepositoryDb) SoftDeleteUser(ctx context.Context, id string) (int, error) {
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	if found, err := lockUser(ctx, tx, id); err != nil || !found {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE users
+	SET deleted_at = NOW(), deactivated_at = COALESCE(deactivated_at, NOW()), updated_at = NOW()
+	WHERE id = $1 AND deleted_at IS NULL
+	`, id)
+	if err != nil {
+		return 0, pkg.NewInternalServerError("failed to delete user", err)
+	}
+
+	cancelled, err := releaseUserAccess(ctx, tx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, pkg.NewInternalServerError("failed to commit transaction", err)
+	}
+
+	return cancelled, nil
+}
+
+func lockUser(ctx context.Context, tx *sqlx.Tx, id string) (bool, error) {
+	scope, args := scopeUsers(ctx, "organisation_id", []any{id})
+
+	var lockedId string
+	err := tx.GetContext(ctx, &lockedId, `SELECT id FROM users WHERE id = $1`+scope+` FOR UPDATE`, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to lock user", err)
+	}
+
+	return true, nil
+}
+
+func releaseUserAccess(ctx context.Context, tx *sqlx.Tx, id string) (int, error) {
+	result, err := tx.ExecContext(ctx, `
+	UPDATE reservations
+	SET status = 'cancelled', status_changed_by = NULL, updated_at = NOW()
+	WHERE user_id = $1
+	AND date >= `+siteToday+`
+	AND status IN ('pending', 'confirmed')
+	`, id)
+	if err != nil {
+		return 0, pkg.NewInternalServerError("failed to cancel user reservations", err)
+	}
+
+	cancelled, err := result.RowsAffected()
+	if err != nil {
+		return 0, pkg.NewInternalServerError("failed to cancel user reservations", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE refresh_tokens
+	SET revoked_at = NOW()
+	WHERE user_id = $1 AND revoked_at IS NULL
+	`, id)
+	if err != nil {
+		return 0, pkg.NewInternalServerError("failed to revoke user tokens", err)
+	}
+
+	return int(cancelled), nil
+}
+
+func (db *UserRepositoryDb) ReactivateUser(ctx context.Context, id string) (bool, error) {
+	scope, args := scopeUsers(ctx, "organisation_id", []any{id})
+	query := `UPDATE users SET deactivated_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL` + scope
+
+	result, err := db.Conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to reactivate user", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to reactivate user", err)
+	}
+
+	return rows == 1, nil
+}
+
+func (db *UserRepositoryDb) UpdateUserPassword(ctx context.Context, id string, password string) error {
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	if found, err := lockUser(ctx, tx, id); err != nil || !found {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE users
+	SET password = $2, email_verified_at = COALESCE(email_verified_at, NOW()), updated_at = NOW()
+	WHERE id = $1
+	`, id, password)
 	if err != nil {
-		return pkg.NewInternalServerError("failed to save user", err)
+		return pkg.NewInternalServerError("failed to update user password", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE refresh_tokens
+	SET revoked_at = NOW()
+	WHERE user_id = $1 AND revoked_at IS NULL
+	`, id)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to revoke user tokens", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return pkg.NewInternalServerError("failed to commit transaction", err)
 	}
 
 	return nil
 }
+
+func (db *UserRepositoryDb) MarkEmailVerified(ctx context.Context, id string) error {
+	scope, args := scopeUsers(ctx, "organisation_id", []any{id})
+	query := `
+	UPDATE users
+	SET email_verified_at = COALESCE(email_verified_at, NOW()), updated_at = NOW()
+	WHERE id = $1` + scope
+
+	if _, err := db.Conn.ExecContext(ctx, query, args...); err != nil {
+		return pkg.NewInternalServerError("failed to mark email as verified", err)
+	}
+
+	return nil
+}
+
+func (db *UserRepositoryDb) UpdateUserProfile(
+	ctx context.Context,
+	id string,
+	profile domain.UserProfile,
+) (*domain.User, error) {
+	var user domain.User
+	scope, args := scopeUsers(ctx, "organisation_id", []any{
+		id,
+		profile.Name,
+		profile.PreferredSiteId,
+		profile.DefaultDeskId,
+		profile.TimeZone,
+		profile.NotificationPreferences,
+	})
+	query := `
+	UPDATE users
+	SET name = $2,
+		preferred_site_id = $3,
+		default_desk_id = $4,
+		time_zone = $5,
+		notification_preferences = $6,
+		updated_at = NOW()
+	WHERE id = $1` + scope + `
+	RETURNING *
+	`
+
+	err := db.Conn.GetContext(ctx, &user, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to update user profile", err)
+	}
+
+	return &user, nil
+}
+
+func (db *UserRepositoryDb) SearchUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error) {
+	conditions, args := userFilterConditions(filter)
+	scope, args := scopeUsers(ctx, "organisation_id", args)
+	where := "WHERE " + strings.Join(conditions, " AND ") + scope
+
+	var total int
+	if err := db.Conn.GetContext(ctx, &total, "SELECT COUNT(*) FROM users "+where, args...); err != nil {
+		return nil, 0, pkg.NewInternalServerError("failed to count users", err)
+	}
+
+	args = append(args, filter.Offset, filter.Limit)
+	query := fmt.Sprintf(
+		"SELECT * FROM users %s ORDER BY name, id OFFSET $%d LIMIT $%d",
+		where,
+		len(args)-1,
+		len(args),
+	)
+
+	users := []domain.User{}
+
+	if err := db.Conn.SelectContext(ctx, &users, query, args...); err != nil {
+		return nil, 0, pkg.NewInternalServerError("failed to search users", err)
+	}
+
+	return users, total, nil
+}
+
+func userFilterConditions(filter domain.UserFilter) ([]string, []any) {
+	conditions := []string{}
+	args := []any{}
+
+	if filter.Query != "" {
+		args = append(args, "%"+escapeLike(filter.Query)+"%")
+		conditions = append(conditions, fmt.Sprintf("(name ILIKE $%d OR email ILIKE $%d)", len(args), len(args)))
+	}
+
+	if filter.Team != "" {
+		args = append(args, filter.Team)
+		conditions = append(conditions, fmt.Sprintf("team = $%d", len(args)))
+	}
+
+	if filter.Role != "" {
+		args = append(args, filter.Role)
+		conditions = append(conditions, fmt.Sprintf("role = $%d", len(args)))
+	}
+
+	switch filter.Status {
+	case domain.UserStatusDeleted:
+		conditions = append(conditions, "deleted_at IS NOT NULL")
+	case domain.UserStatusDeactivated:
+		conditions = append(conditions, "deleted_at IS NULL", "deactivated_at IS NOT NULL")
+	case domain.UserStatusUnverified:
+		conditions = append(conditions, "deleted_at IS NULL", "email_verified_at IS NULL")
+	case domain.UserStatusActive:
+		conditions = append(conditions, "deleted_at IS NULL", "deactivated_at IS NULL")
+	default:
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	return conditions, args
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func (db *UserRepositoryDb) UpdateUserTeam(ctx context.Context, id string, team *string) (bool, error) {
+	scope, args := scopeUsers(ctx, "organisation_id", []any{id, team})
+	query := `UPDATE users SET team = $2, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL` + scope
+
+	result, err := db.Conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to update user team", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to update user team", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (db *UserRepositoryDb) AnonymizeUser(
+	ctx context.Context,
+	id string,
+	pseudonym domain.UserPseudonym,
+) (bool, error) {
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	var email string
+	scope, args := scopeUsers(ctx, "organisation_id", []any{id})
+	query := `SELECT email FROM users WHERE id = $1 AND anonymized_at IS NULL` + scope + ` FOR UPDATE`
+	err = tx.GetContext(ctx, &email, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to find user", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE users
+	SET name = $2,
+		email = $3,
+		password = '',
+		external_id = NULL,
+		team = NULL,
+		preferred_site_id = NULL,
+		default_desk_id = NULL,
+		time_zone = 'UTC',
+		deactivated_at = COALESCE(deactivated_at, NOW()),
+		deleted_at = COALESCE(deleted_at, NOW()),
+		anonymized_at = NOW(),
+		updated_at = NOW()
+	WHERE id = $1
+	`, id, pseudonym.Name, pseudonym.Email)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to anonymize user", err)
+	}
+
+	if _, err := releaseUserAccess(ctx, tx, id); err != nil {
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE guests
+	SET name = $2,
+		email = 'erased-guest-' || id || '@' || $3
+	WHERE host_id = $1
+	`, id, pseudonym.GuestName, pseudonym.GuestEmailDomain)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to anonymize guests", err)
+	}
+
+	for _, table := range []string{"user_identities", "user_tokens", "mfa_recovery_codes", "user_mfa", "api_keys"} {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE user_id = $1", id); err != nil {
+			return false, pkg.NewInternalServerError("failed to delete user data from "+table, err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM login_attempts WHERE LOWER(email) = LOWER($1)`, email)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to delete login attempts", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('app.audit_redaction', 'on', true)`); err != nil {
+		return false, pkg.NewInternalServerError("failed to anonymize audit logs", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE audit_logs
+	SET ip_address = NULL,
+		details = details - 'email',
+		before = CASE WHEN target_type = 'user' AND target_id = $1::text THEN NULL ELSE before END,
+		after = CASE WHEN target_type = 'user' AND target_id = $1::text THEN NULL ELSE after END
+	WHERE actor_id = $1 OR (target_type = 'user' AND target_id = $1::text)
+	`, id)
+	if err != nil {
+		return false, pkg.NewInternalServerError("failed to anonymize audit logs", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, pkg.NewInternalServerError("failed to commit transaction", err)
+	}
+
+	return true, nil
+}
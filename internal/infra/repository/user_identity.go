@@ -0,0 +1,109 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type UserIdentityRepositoryDb struct {
+	Conn *sqlx.DB
+}
+
+const insertUserIdentityQuery = `
+	INSERT INTO user_identities (user_id, provider, subject)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (provider, subject) DO NOTHING
+	`
+
+func (db *UserIdentityRepositoryDb) FindUserByIdentity(
+	ctx context.Context,
+	provider string,
+	subject string,
+) (*domain.User, error) {
+	var user domain.User
+	query := `
+	SELECT u.* FROM users u
+	JOIN user_identities i ON i.user_id = u.id
+	WHERE i.provider = $1 AND i.subject = $2
+	LIMIT 1
+	`
+
+	err := db.Conn.GetContext(ctx, &user, query, provider, subject)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to query user by identity", err)
+	}
+
+	return &user, nil
+}
+
+func (db *UserIdentityRepositoryDb) LinkIdentity(
+	ctx context.Context,
+	identity domain.CreateUserIdentity,
+) error {
+	_, err := db.Conn.ExecContext(
+		ctx,
+		insertUserIdentityQuery,
+		identity.UserId,
+		identity.Provider,
+		identity.Subject,
+	)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to link user identity", err)
+	}
+
+	return nil
+}
+
+func (db *UserIdentityRepositoryDb) SaveUserWithIdentity(
+	ctx context.Context,
+	user domain.CreateUser,
+	identity domain.CreateUserIdentity,
+) (*domain.User, error) {
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	tenantId, err := signupTenant(ctx, tx, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved domain.User
+	query := `
+	INSERT INTO users (name, email, password, email_verified_at, organisation_id)
+	VALUES ($1, $2, $3, NOW(), $4)
+	RETURNING *
+	`
+
+	if err := tx.GetContext(ctx, &saved, query, user.Name, user.Email, user.Password, tenantId); err != nil {
+		return nil, pkg.NewInternalServerError("failed to save user", err)
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		insertUserIdentityQuery,
+		saved.Id,
+		identity.Provider,
+		identity.Subject,
+	); err != nil {
+		return nil, pkg.NewInternalServerError("failed to link user identity", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, pkg.NewInternalServerError("failed to commit transaction", err)
+	}
+
+	return &saved, nil
+}
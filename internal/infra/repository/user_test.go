@@ -74,26 +74,383 @@ func TestSaveUser(t *testing.T) {
 	}
 
 	t.Run("should save user successfully", func(t *testing.T) {
-		mock.ExpectExec("INSERT INTO users").
-			WithArgs(user.Name, user.Email, user.Password).
-			WillReturnResult(sqlmock.NewResult(1, 1))
+		rows := sqlmock.NewRows([]string{"id", "name", "email"}).
+			AddRow("123", user.Name, user.Email)
 
-		err := db.SaveUser(ctx, user)
+		expectSignupDomain(mock, "example.com", "")
+		mock.ExpectQuery("INSERT INTO users").
+			WithArgs(user.Name, user.Email, user.Password, domain.DefaultOrganisationId).
+			WillReturnRows(rows)
+
+		saved, err := db.SaveUser(ctx, user)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if saved == nil || saved.Id != "123" {
+			t.Errorf("expected saved user 123, got %+v", saved)
+		}
+	})
+
+	t.Run("should join the organisation claiming the email domain", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "email"}).
+			AddRow("123", user.Name, user.Email)
+
+		expectSignupDomain(mock, "example.com", tenantA)
+		mock.ExpectQuery("INSERT INTO users").
+			WithArgs(user.Name, user.Email, user.Password, tenantA).
+			WillReturnRows(rows)
+
+		saved, err := db.SaveUser(ctx, user)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if saved == nil || saved.Id != "123" {
+			t.Errorf("expected saved user 123, got %+v", saved)
+		}
+	})
+
+	t.Run("should not look up the domain when the request has a tenant", func(t *testing.T) {
+		mock.ExpectQuery("INSERT INTO users").
+			WithArgs(user.Name, user.Email, user.Password, tenantB).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("123"))
+
+		if _, err := db.SaveUser(tenantContext(tenantB), user); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should return nil when the email is taken", func(t *testing.T) {
+		expectSignupDomain(mock, "example.com", "")
+		mock.ExpectQuery("INSERT INTO users").
+			WithArgs(user.Name, user.Email, user.Password, domain.DefaultOrganisationId).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		saved, err := db.SaveUser(ctx, user)
 		if err != nil {
 			t.Errorf("expected no error, got %v", err)
 		}
+		if saved != nil {
+			t.Error("expected user to be nil")
+		}
 	})
 
 	t.Run("should handle db error", func(t *testing.T) {
-		mock.ExpectExec("INSERT INTO users").
-			WithArgs(user.Name, user.Email, user.Password).
+		expectSignupDomain(mock, "example.com", "")
+		mock.ExpectQuery("INSERT INTO users").
+			WithArgs(user.Name, user.Email, user.Password, domain.DefaultOrganisationId).
 			WillReturnError(fmt.Errorf("db error"))
 
-		err := db.SaveUser(ctx, user)
+		_, err := db.SaveUser(ctx, user)
+
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestUpsertDirectoryUser(t *testing.T) {
+	db, mock := setupUserRepositoryTestDB(t)
+	ctx := context.Background()
+	user := domain.DirectoryUser{
+		Name:  "Test User",
+		Email: "test@example.com",
+		Role:  domain.RoleApprover,
+	}
+
+	t.Run("should upsert directory user", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "role"}).
+			AddRow("123", user.Name, user.Email, "", user.Role)
+
+		expectSignupDomain(mock, "example.com", "")
+		mock.ExpectQuery("INSERT INTO users (.+) ON CONFLICT \\(email\\) DO UPDATE").
+			WithArgs(user.Name, user.Email, user.Role, domain.DefaultOrganisationId).
+			WillReturnRows(rows)
+
+		saved, err := db.UpsertDirectoryUser(ctx, user)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if saved.Role != domain.RoleApprover {
+			t.Errorf("expected role %s, got %s", domain.RoleApprover, saved.Role)
+		}
+	})
+
+	t.Run("should handle db error", func(t *testing.T) {
+		expectSignupDomain(mock, "example.com", "")
+		mock.ExpectQuery("INSERT INTO users").
+			WithArgs(user.Name, user.Email, user.Role, domain.DefaultOrganisationId).
+			WillReturnError(fmt.Errorf("db error"))
+
+		_, err := db.UpsertDirectoryUser(ctx, user)
+
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestFindUsersByRole(t *testing.T) {
+	db, mock := setupUserRepositoryTestDB(t)
+
+	t.Run("should skip deactivated and deleted users", func(t *testing.T) {
+		mock.ExpectQuery("WHERE role = \\$1\\s+AND deactivated_at IS NULL\\s+AND deleted_at IS NULL AND organisation_id = \\$2").
+			WithArgs(domain.RoleApprover, tenantA).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "role"}).AddRow("1", domain.RoleApprover))
+
+		users, err := db.FindUsersByRole(tenantContext(tenantA), domain.RoleApprover)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(users) != 1 {
+			t.Errorf("expected 1 user, got %d", len(users))
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestDeactivateUser(t *testing.T) {
+	db, mock := setupUserRepositoryTestDB(t)
+	ctx := context.Background()
+	userId := "123"
+
+	t.Run("should deactivate user, cancel reservations and revoke tokens", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM users").
+			WithArgs(userId).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userId))
+		mock.ExpectExec("UPDATE users").
+			WithArgs(userId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE reservations").
+			WithArgs(userId).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("UPDATE refresh_tokens").
+			WithArgs(userId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		cancelled, err := db.DeactivateUser(ctx, userId)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if cancelled != 2 {
+			t.Errorf("expected 2 cancelled reservations, got %d", cancelled)
+		}
+	})
+
+	t.Run("should rollback on error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM users").
+			WithArgs(userId).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userId))
+		mock.ExpectExec("UPDATE users").
+			WithArgs(userId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE reservations").
+			WithArgs(userId).
+			WillReturnError(fmt.Errorf("db error"))
+		mock.ExpectRollback()
 
+		_, err := db.DeactivateUser(ctx, userId)
 		if err == nil {
 			t.Error("expected error, got nil")
 		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestUpdateUserProfile(t *testing.T) {
+	db, mock := setupUserRepositoryTestDB(t)
+	ctx := context.Background()
+	siteId := "site-1"
+	profile := domain.UserProfile{
+		Name:                    "Jane Doe",
+		PreferredSiteId:         &siteId,
+		TimeZone:                "Europe/Lisbon",
+		NotificationPreferences: domain.NotificationPreferences{Reminders: true},
+	}
+
+	t.Run("should update profile and scan preferences", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "time_zone", "notification_preferences"}).
+			AddRow("123", "Jane Doe", "Europe/Lisbon", []byte(`{"reservation_updates":false,"approval_requests":false,"reminders":true}`))
+
+		mock.ExpectQuery("UPDATE users (.+) RETURNING").
+			WithArgs("123", profile.Name, profile.PreferredSiteId, profile.DefaultDeskId, profile.TimeZone, sqlmock.AnyArg()).
+			WillReturnRows(rows)
+
+		user, err := db.UpdateUserProfile(ctx, "123", profile)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if user == nil || !user.NotificationPreferences.Reminders || user.TimeZone != "Europe/Lisbon" {
+			t.Errorf("expected updated profile, got %+v", user)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
 	})
 }
 
+func TestSearchUsers(t *testing.T) {
+	db, mock := setupUserRepositoryTestDB(t)
+	ctx := context.Background()
+
+	t.Run("should filter by query, team and status and exclude deleted users", func(t *testing.T) {
+		filter := domain.UserFilter{Query: "jane_", Team: "design", Status: domain.UserStatusActive, Offset: 25, Limit: 25}
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users WHERE \(name ILIKE \$1 OR email ILIKE \$1\) AND team = \$2 AND deleted_at IS NULL AND deactivated_at IS NULL`).
+			WithArgs(`%jane\_%`, "design").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(26))
+		mock.ExpectQuery(`SELECT \* FROM users WHERE (.+) ORDER BY name, id OFFSET \$3 LIMIT \$4`).
+			WithArgs(`%jane\_%`, "design", 25, 25).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("123", "Jane Doe"))
+
+		users, total, err := db.SearchUsers(ctx, filter)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if total != 26 || len(users) != 1 {
+			t.Errorf("expected 1 user of 26, got %d of %d", len(users), total)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should only return deleted users when asked", func(t *testing.T) {
+		filter := domain.UserFilter{Status: domain.UserStatusDeleted, Limit: 25}
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users WHERE deleted_at IS NOT NULL`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(`SELECT \* FROM users WHERE deleted_at IS NOT NULL ORDER BY name, id OFFSET \$1 LIMIT \$2`).
+			WithArgs(0, 25).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		users, _, err := db.SearchUsers(ctx, filter)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(users) != 0 {
+			t.Errorf("expected no users, got %d", len(users))
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestSoftDeleteUser(t *testing.T) {
+	db, mock := setupUserRepositoryTestDB(t)
+	ctx := context.Background()
+	userId := "123"
+
+	t.Run("should mark user deleted, cancel reservations and revoke tokens", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM users").
+			WithArgs(userId).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userId))
+		mock.ExpectExec("UPDATE users SET deleted_at = NOW()").
+			WithArgs(userId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE reservations").
+			WithArgs(userId).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec("UPDATE refresh_tokens").
+			WithArgs(userId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		cancelled, err := db.SoftDeleteUser(ctx, userId)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if cancelled != 3 {
+			t.Errorf("expected 3 cancelled reservations, got %d", cancelled)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestAnonymizeUser(t *testing.T) {
+	db, mock := setupUserRepositoryTestDB(t)
+	ctx := context.Background()
+	userId := "123"
+	pseudonym := domain.UserPseudonym{
+		Name:             "Erased user",
+		Email:            "erased-123@erased.invalid",
+		GuestName:        "Erased guest",
+		GuestEmailDomain: "erased.invalid",
+	}
+
+	t.Run("should pseudonymise user and remove personal data", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT email FROM users").
+			WithArgs(userId).
+			WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow("Jane@Example.com"))
+		mock.ExpectExec("UPDATE users").
+			WithArgs(userId, pseudonym.Name, pseudonym.Email).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE reservations").
+			WithArgs(userId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE refresh_tokens").
+			WithArgs(userId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE guests").
+			WithArgs(userId, pseudonym.GuestName, pseudonym.GuestEmailDomain).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		for _, table := range []string{"user_identities", "user_tokens", "mfa_recovery_codes", "user_mfa", "api_keys"} {
+			mock.ExpectExec("DELETE FROM " + table).
+				WithArgs(userId).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		mock.ExpectExec("DELETE FROM login_attempts").
+			WithArgs("Jane@Example.com").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec("SELECT set_config\\('app.audit_redaction'").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE audit_logs").
+			WithArgs(userId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		erased, err := db.AnonymizeUser(ctx, userId, pseudonym)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if !erased {
+			t.Error("expected user to be erased")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("should skip users already erased", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT email FROM users").
+			WithArgs(userId).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		erased, err := db.AnonymizeUser(ctx, userId, pseudonym)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if erased {
+			t.Error("expected user not to be erased again")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
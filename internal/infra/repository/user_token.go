@@ -0,0 +1,100 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type UserTokenRepositoryDb struct {
+	Conn *sqlx.DB
+}
+
+func (db *UserTokenRepositoryDb) SaveUserToken(ctx context.Context, token domain.CreateUserToken) error {
+	tx, err := db.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE user_tokens
+	SET used_at = NOW()
+	WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL
+	`, token.UserId, token.Purpose)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to invalidate previous tokens", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO user_tokens (user_id, purpose, token_hash, expires_at)
+	VALUES ($1, $2, $3, $4)
+	`, token.UserId, token.Purpose, token.TokenHash, token.ExpiresAt)
+	if err != nil {
+		return pkg.NewInternalServerError("failed to save user token", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return pkg.NewInternalServerError("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+func (db *UserTokenRepositoryDb) FindUserToken(
+	ctx context.Context,
+	purpose string,
+	tokenHash string,
+) (*domain.UserToken, error) {
+	var token domain.UserToken
+	query := `
+	SELECT * FROM user_tokens
+	WHERE token_hash = $1
+	AND purpose = $2
+	AND used_at IS NULL
+	AND expires_at > NOW()
+	`
+
+	err := db.Conn.GetContext(ctx, &token, query, tokenHash, purpose)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to find user token", err)
+	}
+
+	return &token, nil
+}
+
+func (db *UserTokenRepositoryDb) ConsumeUserToken(
+	ctx context.Context,
+	purpose string,
+	tokenHash string,
+) (*domain.UserToken, error) {
+	var token domain.UserToken
+	query := `
+	UPDATE user_tokens
+	SET used_at = NOW()
+	WHERE token_hash = $1
+	AND purpose = $2
+	AND used_at IS NULL
+	AND expires_at > NOW()
+	RETURNING *
+	`
+
+	err := db.Conn.GetContext(ctx, &token, query, tokenHash, purpose)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, pkg.NewInternalServerError("failed to consume user token", err)
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,90 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+func setupUserTokenRepositoryTestDB(t *testing.T) (*UserTokenRepositoryDb, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+	db := &UserTokenRepositoryDb{Conn: sqlxDB}
+
+	return db, mock
+}
+
+func TestSaveUserToken(t *testing.T) {
+	db, mock := setupUserTokenRepositoryTestDB(t)
+	ctx := context.Background()
+	token := domain.CreateUserToken{
+		UserId:    "123",
+		Purpose:   domain.UserTokenPurposePasswordReset,
+		TokenHash: "hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	t.Run("should invalidate previous tokens and save new one", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE user_tokens").
+			WithArgs(token.UserId, token.Purpose).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO user_tokens").
+			WithArgs(token.UserId, token.Purpose, token.TokenHash, token.ExpiresAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := db.SaveUserToken(ctx, token); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestConsumeUserToken(t *testing.T) {
+	db, mock := setupUserTokenRepositoryTestDB(t)
+	ctx := context.Background()
+
+	t.Run("should consume valid token", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "user_id", "purpose", "token_hash"}).
+			AddRow("1", "123", domain.UserTokenPurposePasswordReset, "hash")
+
+		mock.ExpectQuery("UPDATE user_tokens (.+) RETURNING").
+			WithArgs("hash", domain.UserTokenPurposePasswordReset).
+			WillReturnRows(rows)
+
+		token, err := db.ConsumeUserToken(ctx, domain.UserTokenPurposePasswordReset, "hash")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if token == nil || token.UserId != "123" {
+			t.Errorf("expected token for user 123, got %+v", token)
+		}
+	})
+
+	t.Run("should return nil for used or expired token", func(t *testing.T) {
+		mock.ExpectQuery("UPDATE user_tokens").
+			WithArgs("hash", domain.UserTokenPurposePasswordReset).
+			WillReturnError(sql.ErrNoRows)
+
+		token, err := db.ConsumeUserToken(ctx, domain.UserTokenPurposePasswordReset, "hash")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if token != nil {
+			t.Error("expected token to be nil")
+		}
+	})
+}
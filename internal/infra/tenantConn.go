@@ -0,0 +1,232 @@
+package infra
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/tufee/desk-reservation-go/internal/utils"
+)
+
+const setTenantQuery = "SELECT set_config('app.tenant_id', $1, false), set_config('app.bypass_rls', $2, false)"
+
+// tenantConnector keeps the app.tenant_id and app.bypass_rls session variables
+// used by the row level security policies in step with each query's context.
+// Only contexts marked with utils.SystemScopeKey may bypass the policies.
+type tenantConnector struct {
+	driver.Connector
+}
+
+func (c tenantConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	execer, ok := conn.(driver.ExecerContext)
+	if !ok {
+		conn.Close()
+		return nil, fmt.Errorf("driver connection does not support ExecContext")
+	}
+
+	return &tenantConn{Conn: conn, execer: execer}, nil
+}
+
+type tenantConn struct {
+	driver.Conn
+	execer   driver.ExecerContext
+	tenantId string
+	bypass   bool
+	synced   bool
+}
+
+func (c *tenantConn) syncTenant(ctx context.Context) error {
+	tenantId, _ := utils.GetContextValue[string](ctx, utils.AuthTenantKey)
+	bypass, _ := utils.GetContextValue[bool](ctx, utils.SystemScopeKey)
+	if c.synced && c.tenantId == tenantId && c.bypass == bypass {
+		return nil
+	}
+
+	bypassSetting := "off"
+	if bypass {
+		bypassSetting = "on"
+	}
+
+	c.synced = false
+	args := []driver.NamedValue{{Ordinal: 1, Value: tenantId}, {Ordinal: 2, Value: bypassSetting}}
+	if _, err := c.execer.ExecContext(ctx, setTenantQuery, args); err != nil {
+		return fmt.Errorf("failed to set tenant: %w", err)
+	}
+
+	c.tenantId, c.bypass, c.synced = tenantId, bypass, true
+	return nil
+}
+
+func (c *tenantConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.syncTenant(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.execer.ExecContext(ctx, query, args)
+}
+
+func (c *tenantConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if err := c.syncTenant(ctx); err != nil {
+		return nil, err
+	}
+
+	return queryer.QueryContext(ctx, query, args)
+}
+
+func (c *tenantConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := c.syncTenant(ctx); err != nil {
+		return nil, err
+	}
+
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenantStmt{Stmt: stmt, conn: c}, nil
+}
+
+func (c *tenantConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	var (
+		tx  driver.Tx
+		err error
+	)
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		tx, err = beginner.BeginTx(ctx, opts)
+	} else {
+		tx, err = c.Conn.Begin()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenantTx{Tx: tx, conn: c}, nil
+}
+
+func (c *tenantConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+
+	return driver.ErrSkip
+}
+
+func (c *tenantConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+
+	return nil
+}
+
+func (c *tenantConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+
+	return nil
+}
+
+func (c *tenantConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+
+	return true
+}
+
+// A rolled back transaction also rolls back any set_config made inside it.
+type tenantTx struct {
+	driver.Tx
+	conn *tenantConn
+}
+
+func (tx *tenantTx) Commit() error {
+	err := tx.Tx.Commit()
+	if err != nil {
+		tx.conn.synced = false
+	}
+
+	return err
+}
+
+func (tx *tenantTx) Rollback() error {
+	tx.conn.synced = false
+	return tx.Tx.Rollback()
+}
+
+// A prepared statement can outlive the request that prepared it, so the
+// tenant is synced again on every execution.
+type tenantStmt struct {
+	driver.Stmt
+	conn *tenantConn
+}
+
+func (s *tenantStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if err := s.conn.syncTenant(ctx); err != nil {
+		return nil, err
+	}
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		return execer.ExecContext(ctx, args)
+	}
+
+	values, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Stmt.Exec(values)
+}
+
+func (s *tenantStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if err := s.conn.syncTenant(ctx); err != nil {
+		return nil, err
+	}
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		return queryer.QueryContext(ctx, args)
+	}
+
+	values, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Stmt.Query(values)
+}
+
+func (s *tenantStmt) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+
+	return s.conn.CheckNamedValue(value)
+}
+
+func namedValuesToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, fmt.Errorf("driver does not support named parameters")
+		}
+		values[i] = arg.Value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,141 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/tufee/desk-reservation-go/internal/utils"
+)
+
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+func setupTenantConnTestDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.NewWithDSN(t.Name())
+	if err != nil {
+		t.Fatalf("Failed to create mock: %v", err)
+	}
+
+	db := sql.OpenDB(tenantConnector{dsnConnector{dsn: t.Name(), driver: mockDB.Driver()}})
+	db.SetMaxOpenConns(1)
+
+	return db, mock
+}
+
+func TestTenantConnSetsTenant(t *testing.T) {
+	db, mock := setupTenantConnTestDB(t)
+	tenantA := utils.SetContextValue(context.Background(), utils.AuthTenantKey, "tenant-a")
+	tenantB := utils.SetContextValue(context.Background(), utils.AuthTenantKey, "tenant-b")
+
+	mock.ExpectExec("SELECT set_config").WithArgs("tenant-a", "off").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM reservations").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM reservations").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("SELECT set_config").WithArgs("tenant-b", "off").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM reservations").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("SELECT set_config").WithArgs("", "off").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM reservations").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	for _, ctx := range []context.Context{tenantA, tenantA, tenantB, context.Background()} {
+		if _, err := db.ExecContext(ctx, "DELETE FROM reservations"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTenantConnResetsAfterRollback(t *testing.T) {
+	db, mock := setupTenantConnTestDB(t)
+	ctx := utils.SetContextValue(context.Background(), utils.AuthTenantKey, "tenant-a")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT set_config").WithArgs("tenant-a", "off").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM reservations").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+	mock.ExpectExec("SELECT set_config").WithArgs("tenant-a", "off").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM reservations").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM reservations"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := db.ExecContext(ctx, "DELETE FROM reservations"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTenantConnBypassesOnlyForSystemScope(t *testing.T) {
+	db, mock := setupTenantConnTestDB(t)
+	system := utils.SetContextValue(context.Background(), utils.SystemScopeKey, true)
+
+	mock.ExpectExec("SELECT set_config").WithArgs("", "on").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("SELECT id FROM users").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("SELECT set_config").WithArgs("", "off").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("SELECT id FROM users").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	for _, ctx := range []context.Context{system, context.Background()} {
+		if _, err := db.ExecContext(ctx, "SELECT id FROM users"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTenantConnSyncsPreparedStatements(t *testing.T) {
+	db, mock := setupTenantConnTestDB(t)
+	tenantA := utils.SetContextValue(context.Background(), utils.AuthTenantKey, "tenant-a")
+	tenantB := utils.SetContextValue(context.Background(), utils.AuthTenantKey, "tenant-b")
+
+	mock.ExpectExec("SELECT set_config").WithArgs("tenant-a", "off").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare("DELETE FROM reservations").
+		ExpectExec().
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("SELECT set_config").WithArgs("tenant-b", "off").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM reservations").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	stmt, err := db.PrepareContext(tenantA, "DELETE FROM reservations")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(tenantA); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := stmt.ExecContext(tenantB); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
@@ -1,23 +1,122 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
+	"slices"
+	"strings"
 
+	"github.com/tufee/desk-reservation-go/internal/infra"
+	repo "github.com/tufee/desk-reservation-go/internal/infra/repository"
+	"github.com/tufee/desk-reservation-go/internal/service"
 	"github.com/tufee/desk-reservation-go/internal/utils"
 	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
 )
 
-func AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
+const apiKeyScheme = "ApiKey "
+
+// AuthMiddleware accepts bearer JWTs on every route. API keys are only
+// accepted when the route lists a scope granted to the key.
+func AuthMiddleware(next http.HandlerFunc, scopes ...string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		if rawKey, ok := strings.CutPrefix(r.Header.Get("Authorization"), apiKeyScheme); ok {
+			authenticateAPIKey(w, r, next, strings.TrimSpace(rawKey), scopes)
+			return
+		}
+
 		token := pkg.ExtractToken(w, r)
 		if token == nil {
 			return
 		}
 
 		ctx := r.Context()
+
+		if token.ID == "" || token.TenantId == "" {
+			http.Error(w, "Invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		revoked, err := isTokenRevoked(ctx, token.ID)
+		if err != nil {
+			http.Error(w, "Could not verify token", http.StatusInternalServerError)
+			return
+		}
+
+		if revoked {
+			http.Error(w, "Token has been revoked", http.StatusUnauthorized)
+			return
+		}
+
 		ctx = utils.SetContextValue(ctx, utils.AuthUserKey, token.UserId)
 		ctx = utils.SetContextValue(ctx, utils.AuthEmailKey, token.Email)
+		ctx = utils.SetContextValue(ctx, utils.AuthRoleKey, token.Role)
+		ctx = utils.SetContextValue(ctx, utils.AuthTokenIdKey, token.ID)
+		ctx = utils.SetContextValue(ctx, utils.AuthTokenExpiryKey, token.ExpiresAt.Time)
+		ctx = utils.SetContextValue(ctx, utils.AuthTenantKey, token.TenantId)
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	}
 }
+
+func authenticateAPIKey(
+	w http.ResponseWriter,
+	r *http.Request,
+	next http.HandlerFunc,
+	rawKey string,
+	scopes []string,
+) {
+	ctx := r.Context()
+
+	db, err := infra.InitializeDB()
+	if err != nil {
+		http.Error(w, "Could not verify api key", http.StatusInternalServerError)
+		return
+	}
+
+	apiKeyService := service.APIKeyService{
+		APIKeyRepository: &repo.APIKeyRepositoryDb{Conn: db.Conn},
+		UserRepository:   &repo.UserRepositoryDb{Conn: db.Conn},
+	}
+
+	// The key's owner, and so its tenant, is only known after the lookup.
+	lookupCtx := utils.SetContextValue(ctx, utils.SystemScopeKey, true)
+
+	key, user, err := apiKeyService.AuthenticateAPIKeyService(lookupCtx, rawKey)
+	if err != nil {
+		pkg.HandleHTTPError(w, err)
+		return
+	}
+
+	if !hasAnyScope(key.Scopes, scopes) {
+		http.Error(w, "API key does not grant access to this endpoint", http.StatusForbidden)
+		return
+	}
+
+	ctx = utils.SetContextValue(ctx, utils.AuthUserKey, user.Id)
+	ctx = utils.SetContextValue(ctx, utils.AuthEmailKey, user.Email)
+	ctx = utils.SetContextValue(ctx, utils.AuthRoleKey, user.Role)
+	ctx = utils.SetContextValue(ctx, utils.AuthAPIKeyIdKey, key.Id)
+	ctx = utils.SetContextValue(ctx, utils.AuthTenantKey, user.OrganisationId)
+
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+func hasAnyScope(granted []string, required []string) bool {
+	for _, scope := range required {
+		if slices.Contains(granted, scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	db, err := infra.InitializeDB()
+	if err != nil {
+		return false, err
+	}
+
+	tokenRepository := &repo.TokenRepositoryDb{Conn: db.Conn}
+	return tokenRepository.IsAccessTokenRevoked(ctx, jti)
+}
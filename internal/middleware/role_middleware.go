@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/tufee/desk-reservation-go/internal/utils"
+)
+
+func RequireRole(next http.HandlerFunc, roles ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		role, ok := utils.GetContextValue[string](r.Context(), utils.AuthRoleKey)
+		if !ok || !slices.Contains(roles, role) {
+			http.Error(w, "Insufficient permissions", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+)
+
+func ScimAuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		expected := os.Getenv("SCIM_TOKEN")
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		if expected == "" || !ok || subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "Invalid SCIM token", http.StatusUnauthorized)
+			return
+		}
+
+		tenantId := os.Getenv("SCIM_ORGANISATION_ID")
+		if tenantId == "" {
+			tenantId = domain.DefaultOrganisationId
+		}
+
+		ctx := utils.SetContextValue(r.Context(), utils.AuthTenantKey, tenantId)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+}
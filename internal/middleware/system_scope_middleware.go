@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/tufee/desk-reservation-go/internal/utils"
+)
+
+// SystemScopeMiddleware marks routes that have to find a user before their
+// organisation is known (sign-up, login, password and email flows). Their
+// queries bypass the tenant row level security policies.
+func SystemScopeMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := utils.SetContextValue(r.Context(), utils.SystemScopeKey, true)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+}
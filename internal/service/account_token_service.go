@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const (
+	passwordResetTokenTTL     = time.Hour
+	emailVerificationTokenTTL = 48 * time.Hour
+)
+
+type AccountTokenService struct {
+	UserRepository      domain.UserRepositoryInterface
+	UserTokenRepository domain.UserTokenRepositoryInterface
+	MailSender          domain.MailSenderInterface
+	BaseURL             string
+	PasswordPolicy      domain.PasswordPolicy
+	BreachedPasswords   domain.BreachedPasswordCheckerInterface
+}
+
+func (repo *AccountTokenService) RequestPasswordResetService(
+	ctx context.Context,
+	data domain.RequestPasswordReset,
+) error {
+	log := pkg.GetLogger()
+
+	user, err := repo.UserRepository.FindUserByEmail(ctx, data.Email)
+	if err != nil {
+		log.Error("Error to find user by email: %v", err)
+		return err
+	}
+
+	if user == nil || user.DeactivatedAt != nil {
+		log.Info("Password reset requested for unknown or inactive email: %s", data.Email)
+		return nil
+	}
+
+	token, err := issueUserToken(ctx, repo.UserTokenRepository, user.Id, domain.UserTokenPurposePasswordReset, passwordResetTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Use the link below to choose a new password. It expires in %s.\n\n%s",
+		passwordResetTokenTTL,
+		accountLink(repo.BaseURL, "/reset-password", token),
+	)
+
+	if err := repo.MailSender.SendMail(ctx, user.Email, "Reset your password", body); err != nil {
+		log.Error("Error sending password reset mail: %v", err)
+		return pkg.NewInternalServerError("failed to send password reset mail", err)
+	}
+
+	log.Info("Password reset token issued for user: %s", user.Id)
+	return nil
+}
+
+func (repo *AccountTokenService) ResetPasswordService(ctx context.Context, data domain.ResetPassword) error {
+	log := pkg.GetLogger()
+
+	pending, err := repo.UserTokenRepository.FindUserToken(
+		ctx,
+		domain.UserTokenPurposePasswordReset,
+		pkg.HashToken(data.Token),
+	)
+	if err != nil {
+		log.Error("Error finding password reset token: %v", err)
+		return err
+	}
+
+	if pending == nil {
+		return pkg.NewBadRequestError("invalid or expired token")
+	}
+
+	user, err := repo.UserRepository.FindUserById(ctx, pending.UserId)
+	if err != nil {
+		log.Error("Error to find user by id: %v", err)
+		return err
+	}
+
+	if user == nil {
+		return pkg.NewBadRequestError("invalid or expired token")
+	}
+
+	err = checkPasswordPolicy(ctx, repo.PasswordPolicy, repo.BreachedPasswords, data.Password, user.Email, user.Name)
+	if err != nil {
+		log.Info("Password rejected by policy for user: %s", user.Id)
+		return err
+	}
+
+	token, err := consumeUserToken(ctx, repo.UserTokenRepository, domain.UserTokenPurposePasswordReset, data.Token)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := pkg.HashPassword(data.Password)
+	if err != nil {
+		return pkg.NewInternalServerError("error processing user data", err)
+	}
+
+	if err := repo.UserRepository.UpdateUserPassword(ctx, token.UserId, hashedPassword); err != nil {
+		log.Error("Error updating user password: %v", err)
+		return err
+	}
+
+	log.Info("Password reset for user: %s", token.UserId)
+	return nil
+}
+
+func (repo *AccountTokenService) SendEmailVerificationService(
+	ctx context.Context,
+	data domain.RequestEmailVerification,
+) error {
+	log := pkg.GetLogger()
+
+	user, err := repo.UserRepository.FindUserByEmail(ctx, data.Email)
+	if err != nil {
+		log.Error("Error to find user by email: %v", err)
+		return err
+	}
+
+	if user == nil || user.EmailVerifiedAt != nil {
+		log.Info("Email verification not needed for: %s", data.Email)
+		return nil
+	}
+
+	token, err := issueUserToken(ctx, repo.UserTokenRepository, user.Id, domain.UserTokenPurposeEmailVerification, emailVerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Confirm your email address to start booking desks. The link expires in %s.\n\n%s",
+		emailVerificationTokenTTL,
+		accountLink(repo.BaseURL, "/verify-email", token),
+	)
+
+	if err := repo.MailSender.SendMail(ctx, user.Email, "Verify your email address", body); err != nil {
+		log.Error("Error sending verification mail: %v", err)
+		return pkg.NewInternalServerError("failed to send verification mail", err)
+	}
+
+	log.Info("Email verification token issued for user: %s", user.Id)
+	return nil
+}
+
+func (repo *AccountTokenService) VerifyEmailService(ctx context.Context, data domain.VerifyEmail) error {
+	log := pkg.GetLogger()
+
+	token, err := consumeUserToken(ctx, repo.UserTokenRepository, domain.UserTokenPurposeEmailVerification, data.Token)
+	if err != nil {
+		return err
+	}
+
+	if err := repo.UserRepository.MarkEmailVerified(ctx, token.UserId); err != nil {
+		log.Error("Error marking email as verified: %v", err)
+		return err
+	}
+
+	log.Info("Email verified for user: %s", token.UserId)
+	return nil
+}
+
+func accountLink(baseURL string, path string, token string) string {
+	return strings.TrimRight(baseURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+func issueUserToken(
+	ctx context.Context,
+	tokens domain.UserTokenRepositoryInterface,
+	userId string,
+	purpose string,
+	ttl time.Duration,
+) (string, error) {
+	log := pkg.GetLogger()
+
+	token, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		log.Error("Error generating %s token: %v", purpose, err)
+		return "", pkg.NewInternalServerError("failed to generate token", err)
+	}
+
+	err = tokens.SaveUserToken(ctx, domain.CreateUserToken{
+		UserId:    userId,
+		Purpose:   purpose,
+		TokenHash: pkg.HashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		log.Error("Error saving %s token: %v", purpose, err)
+		return "", err
+	}
+
+	return token, nil
+}
+
+func consumeUserToken(
+	ctx context.Context,
+	tokens domain.UserTokenRepositoryInterface,
+	purpose string,
+	token string,
+) (*domain.UserToken, error) {
+	log := pkg.GetLogger()
+
+	consumed, err := tokens.ConsumeUserToken(ctx, purpose, pkg.HashToken(token))
+	if err != nil {
+		log.Error("Error consuming %s token: %v", purpose, err)
+		return nil, err
+	}
+
+	if consumed == nil {
+		return nil, pkg.NewBadRequestError("invalid or expired token")
+	}
+
+	return consumed, nil
+}
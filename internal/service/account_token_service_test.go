@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type userTokenRepo struct {
+	SaveUserTokenFunc    func(ctx context.Context, token domain.CreateUserToken) error
+	FindUserTokenFunc    func(ctx context.Context, purpose string, tokenHash string) (*domain.UserToken, error)
+	ConsumeUserTokenFunc func(ctx context.Context, purpose string, tokenHash string) (*domain.UserToken, error)
+}
+
+func (r *userTokenRepo) SaveUserToken(ctx context.Context, token domain.CreateUserToken) error {
+	return r.SaveUserTokenFunc(ctx, token)
+}
+
+func (r *userTokenRepo) FindUserToken(
+	ctx context.Context,
+	purpose string,
+	tokenHash string,
+) (*domain.UserToken, error) {
+	return r.FindUserTokenFunc(ctx, purpose, tokenHash)
+}
+
+func (r *userTokenRepo) ConsumeUserToken(
+	ctx context.Context,
+	purpose string,
+	tokenHash string,
+) (*domain.UserToken, error) {
+	return r.ConsumeUserTokenFunc(ctx, purpose, tokenHash)
+}
+
+type mailSenderMock struct {
+	sent []string
+}
+
+func (m *mailSenderMock) SendMail(ctx context.Context, to string, subject string, body string) error {
+	m.sent = append(m.sent, body)
+	return nil
+}
+
+func TestRequestPasswordResetService(t *testing.T) {
+	data := domain.RequestPasswordReset{Email: "test@example.com"}
+
+	t.Run("should store hashed token and mail the link", func(t *testing.T) {
+		var saved domain.CreateUserToken
+
+		users := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return &domain.User{Id: "user-1", Email: email}, nil
+			},
+		}
+		tokens := &userTokenRepo{
+			SaveUserTokenFunc: func(ctx context.Context, token domain.CreateUserToken) error {
+				saved = token
+				return nil
+			},
+		}
+		mailer := &mailSenderMock{}
+
+		ctx := context.Background()
+		accountService := AccountTokenService{
+			UserRepository:      users,
+			UserTokenRepository: tokens,
+			MailSender:          mailer,
+			BaseURL:             "https://desks.example.com/",
+		}
+		err := accountService.RequestPasswordResetService(ctx, data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, domain.UserTokenPurposePasswordReset, saved.Purpose, "should use reset purpose")
+		assert.WithinDuration(t, time.Now().Add(passwordResetTokenTTL), saved.ExpiresAt, time.Minute)
+		assert.Len(t, mailer.sent, 1, "should send one mail")
+
+		link := mailer.sent[0][strings.Index(mailer.sent[0], "https://"):]
+		token := strings.TrimPrefix(link, "https://desks.example.com/reset-password?token=")
+		assert.Equal(t, pkg.HashToken(token), saved.TokenHash, "should store only the token hash")
+	})
+
+	t.Run("should not reveal unknown emails", func(t *testing.T) {
+		users := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return nil, nil
+			},
+		}
+		mailer := &mailSenderMock{}
+
+		ctx := context.Background()
+		accountService := AccountTokenService{UserRepository: users, MailSender: mailer}
+		err := accountService.RequestPasswordResetService(ctx, data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Empty(t, mailer.sent, "should not send mail")
+	})
+}
+
+func TestResetPasswordService(t *testing.T) {
+	data := domain.ResetPassword{Token: "token", Password: "new-password", PasswordConfirmation: "new-password"}
+
+	t.Run("should update password with valid token", func(t *testing.T) {
+		var updatedId, updatedHash string
+
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id, Name: "Jane Doe", Email: "jane@example.com"}, nil
+			},
+			updateUserPasswordFunc: func(ctx context.Context, id string, password string) error {
+				updatedId, updatedHash = id, password
+				return nil
+			},
+		}
+		tokens := &userTokenRepo{
+			FindUserTokenFunc: func(ctx context.Context, purpose string, tokenHash string) (*domain.UserToken, error) {
+				return &domain.UserToken{UserId: "user-1"}, nil
+			},
+			ConsumeUserTokenFunc: func(ctx context.Context, purpose string, tokenHash string) (*domain.UserToken, error) {
+				assert.Equal(t, domain.UserTokenPurposePasswordReset, purpose)
+				assert.Equal(t, pkg.HashToken("token"), tokenHash)
+				return &domain.UserToken{UserId: "user-1"}, nil
+			},
+		}
+
+		ctx := context.Background()
+		accountService := AccountTokenService{UserRepository: users, UserTokenRepository: tokens}
+		err := accountService.ResetPasswordService(ctx, data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "user-1", updatedId, "should update the token owner")
+		assert.True(t, pkg.CheckPasswordHash("new-password", updatedHash), "should store the hashed password")
+	})
+
+	t.Run("should reject used or expired token", func(t *testing.T) {
+		tokens := &userTokenRepo{
+			FindUserTokenFunc: func(ctx context.Context, purpose string, tokenHash string) (*domain.UserToken, error) {
+				return nil, nil
+			},
+		}
+
+		ctx := context.Background()
+		accountService := AccountTokenService{UserTokenRepository: tokens}
+		err := accountService.ResetPasswordService(ctx, data)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "invalid or expired token", err.Error(), "should return correct message")
+	})
+
+	t.Run("should keep token when password violates policy", func(t *testing.T) {
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id, Name: "Jane Doe", Email: "jane@example.com"}, nil
+			},
+		}
+		tokens := &userTokenRepo{
+			FindUserTokenFunc: func(ctx context.Context, purpose string, tokenHash string) (*domain.UserToken, error) {
+				return &domain.UserToken{UserId: "user-1"}, nil
+			},
+		}
+		weak := domain.ResetPassword{Token: "token", Password: "jane-2025", PasswordConfirmation: "jane-2025"}
+
+		ctx := context.Background()
+		accountService := AccountTokenService{
+			UserRepository:      users,
+			UserTokenRepository: tokens,
+			PasswordPolicy:      domain.PasswordPolicy{MinLength: 12, DisallowPersonalInfo: true},
+		}
+		err := accountService.ResetPasswordService(ctx, weak)
+
+		assert.IsType(t, &pkg.ValidationError{}, err, "should be validation error")
+		assert.Len(t, err.(*pkg.ValidationError).Errors, 2, "should report every violation")
+	})
+}
+
+func TestSendEmailVerificationService(t *testing.T) {
+	t.Run("should skip already verified users", func(t *testing.T) {
+		verifiedAt := time.Now()
+		users := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return &domain.User{Id: "user-1", Email: email, EmailVerifiedAt: &verifiedAt}, nil
+			},
+		}
+		mailer := &mailSenderMock{}
+
+		ctx := context.Background()
+		accountService := AccountTokenService{UserRepository: users, MailSender: mailer}
+		err := accountService.SendEmailVerificationService(ctx, domain.RequestEmailVerification{Email: "a@b.com"})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Empty(t, mailer.sent, "should not send mail")
+	})
+
+	t.Run("should send verification link to unverified users", func(t *testing.T) {
+		var saved domain.CreateUserToken
+
+		users := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return &domain.User{Id: "user-1", Email: email}, nil
+			},
+		}
+		tokens := &userTokenRepo{
+			SaveUserTokenFunc: func(ctx context.Context, token domain.CreateUserToken) error {
+				saved = token
+				return nil
+			},
+		}
+		mailer := &mailSenderMock{}
+
+		ctx := context.Background()
+		accountService := AccountTokenService{
+			UserRepository:      users,
+			UserTokenRepository: tokens,
+			MailSender:          mailer,
+			BaseURL:             "https://desks.example.com",
+		}
+		err := accountService.SendEmailVerificationService(ctx, domain.RequestEmailVerification{Email: "a@b.com"})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, domain.UserTokenPurposeEmailVerification, saved.Purpose, "should use verification purpose")
+		assert.Contains(t, mailer.sent[0], "https://desks.example.com/verify-email?token=", "should mail the link")
+	})
+}
+
+func TestVerifyEmailService(t *testing.T) {
+	t.Run("should mark email as verified", func(t *testing.T) {
+		var verifiedId string
+
+		users := &userRepo{
+			markEmailVerifiedFunc: func(ctx context.Context, id string) error {
+				verifiedId = id
+				return nil
+			},
+		}
+		tokens := &userTokenRepo{
+			ConsumeUserTokenFunc: func(ctx context.Context, purpose string, tokenHash string) (*domain.UserToken, error) {
+				assert.Equal(t, domain.UserTokenPurposeEmailVerification, purpose)
+				return &domain.UserToken{UserId: "user-1"}, nil
+			},
+		}
+
+		ctx := context.Background()
+		accountService := AccountTokenService{UserRepository: users, UserTokenRepository: tokens}
+		err := accountService.VerifyEmailService(ctx, domain.VerifyEmail{Token: "token"})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "user-1", verifiedId, "should verify the token owner")
+	})
+}
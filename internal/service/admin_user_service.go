@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type AdminUserService struct {
+	UserRepository        domain.UserRepositoryInterface
+	ReservationRepository domain.ReservationRepositoryInterface
+	AuditLogRepository    domain.AuditLogRepositoryInterface
+}
+
+func (repo *AdminUserService) ListUsersService(
+	ctx context.Context,
+	query domain.AdminUserQuery,
+) (*domain.Page[domain.User], error) {
+	log := pkg.GetLogger()
+
+	if query.Status != "" && !slices.Contains(domain.UserStatuses, query.Status) {
+		return nil, pkg.NewBadRequestError("invalid status filter")
+	}
+
+	if query.Role != "" && !slices.Contains(domain.Roles, query.Role) {
+		return nil, pkg.NewBadRequestError("invalid role filter")
+	}
+
+	filter := domain.UserFilter{
+		Query:  strings.TrimSpace(query.Query),
+		Team:   strings.TrimSpace(query.Team),
+		Role:   query.Role,
+		Status: query.Status,
+		Offset: (query.Page - 1) * query.PageSize,
+		Limit:  query.PageSize,
+	}
+
+	users, total, err := repo.UserRepository.SearchUsers(ctx, filter)
+	if err != nil {
+		log.Error("Error searching users: %v", err)
+		return nil, err
+	}
+
+	return &domain.Page[domain.User]{
+		Items:    users,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
+}
+
+func (repo *AdminUserService) GetUserService(ctx context.Context, id string) (*domain.User, error) {
+	return findAdminUser(ctx, repo, id)
+}
+
+func (repo *AdminUserService) UpdateUserService(
+	ctx context.Context,
+	actorId string,
+	id string,
+	data domain.UpdateAdminUser,
+) (*domain.User, error) {
+	log := pkg.GetLogger()
+
+	user, err := findAdminUser(ctx, repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DeletedAt != nil {
+		return nil, pkg.NewConflictError("user has been deleted", nil)
+	}
+
+	roleChanged := data.Role != nil && *data.Role != user.Role
+	if roleChanged {
+		if actorId == id {
+			return nil, pkg.NewBadRequestError("admins cannot change their own role")
+		}
+
+		if _, err := repo.UserRepository.UpdateUserRole(ctx, id, *data.Role); err != nil {
+			log.Error("Error updating user role: %v", err)
+			return nil, err
+		}
+		log.Info("Role of user %s changed to %s by %s", id, *data.Role, actorId)
+	}
+
+	if data.Team != nil {
+		var team *string
+		if trimmed := strings.TrimSpace(*data.Team); trimmed != "" {
+			team = &trimmed
+		}
+
+		if _, err := repo.UserRepository.UpdateUserTeam(ctx, id, team); err != nil {
+			log.Error("Error updating user team: %v", err)
+			return nil, err
+		}
+	}
+
+	updated, err := findAdminUser(ctx, repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if roleChanged {
+		recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+			ActorId:    &actorId,
+			Action:     domain.AuditActionUserRoleChanged,
+			TargetType: domain.AuditTargetUser,
+			TargetId:   id,
+			Before:     map[string]any{"role": user.Role},
+			After:      map[string]any{"role": updated.Role},
+			Details:    map[string]any{"source": "admin"},
+		})
+	}
+
+	if data.Team != nil {
+		recordUserAudit(ctx, repo, actorId, domain.AuditActionUserUpdated, user, updated, nil)
+	}
+
+	return updated, nil
+}
+
+func (repo *AdminUserService) DeactivateUserService(ctx context.Context, actorId string, id string) (int, error) {
+	log := pkg.GetLogger()
+
+	if actorId == id {
+		return 0, pkg.NewBadRequestError("admins cannot deactivate their own account")
+	}
+
+	user, err := findAdminUser(ctx, repo, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if user.DeletedAt != nil {
+		return 0, pkg.NewConflictError("user has been deleted", nil)
+	}
+
+	if user.DeactivatedAt != nil {
+		return 0, nil
+	}
+
+	cancelled, err := repo.UserRepository.DeactivateUser(ctx, id)
+	if err != nil {
+		log.Error("Error deactivating user: %v", err)
+		return 0, err
+	}
+
+	now := time.Now()
+	after := *user
+	after.DeactivatedAt = &now
+	recordUserAudit(ctx, repo, actorId, domain.AuditActionUserDeactivated, user, &after, map[string]any{
+		"cancelled_reservations": cancelled,
+	})
+
+	log.Info("User %s deactivated by %s, cancelled %d reservations", id, actorId, cancelled)
+	return cancelled, nil
+}
+
+func (repo *AdminUserService) ReactivateUserService(ctx context.Context, actorId string, id string) error {
+	log := pkg.GetLogger()
+
+	user, err := findAdminUser(ctx, repo, id)
+	if err != nil {
+		return err
+	}
+
+	if user.DeletedAt != nil {
+		return pkg.NewConflictError("deleted users cannot be reactivated", nil)
+	}
+
+	reactivated, err := repo.UserRepository.ReactivateUser(ctx, id)
+	if err != nil {
+		log.Error("Error reactivating user: %v", err)
+		return err
+	}
+
+	if reactivated {
+		after := *user
+		after.DeactivatedAt = nil
+		recordUserAudit(ctx, repo, actorId, domain.AuditActionUserReactivated, user, &after, nil)
+	}
+
+	log.Info("User %s reactivated by %s", id, actorId)
+	return nil
+}
+
+func (repo *AdminUserService) DeleteUserService(ctx context.Context, actorId string, id string) (int, error) {
+	log := pkg.GetLogger()
+
+	if actorId == id {
+		return 0, pkg.NewBadRequestError("admins cannot delete their own account")
+	}
+
+	user, err := findAdminUser(ctx, repo, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if user.DeletedAt != nil {
+		return 0, nil
+	}
+
+	cancelled, err := repo.UserRepository.SoftDeleteUser(ctx, id)
+	if err != nil {
+		log.Error("Error deleting user: %v", err)
+		return 0, err
+	}
+
+	now := time.Now()
+	after := *user
+	after.DeletedAt = &now
+	recordUserAudit(ctx, repo, actorId, domain.AuditActionUserDeleted, user, &after, map[string]any{
+		"cancelled_reservations": cancelled,
+	})
+
+	log.Info("User %s deleted by %s, cancelled %d reservations", id, actorId, cancelled)
+	return cancelled, nil
+}
+
+func (repo *AdminUserService) ListUserReservationsService(
+	ctx context.Context,
+	id string,
+	page int,
+	pageSize int,
+) (*domain.Page[domain.Reservation], error) {
+	log := pkg.GetLogger()
+
+	if _, err := findAdminUser(ctx, repo, id); err != nil {
+		return nil, err
+	}
+
+	reservations, total, err := repo.ReservationRepository.FindReservationsByUser(
+		ctx,
+		id,
+		(page-1)*pageSize,
+		pageSize,
+	)
+	if err != nil {
+		log.Error("Error finding user reservations: %v", err)
+		return nil, err
+	}
+
+	return &domain.Page[domain.Reservation]{
+		Items:    reservations,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+func findAdminUser(ctx context.Context, repo *AdminUserService, id string) (*domain.User, error) {
+	log := pkg.GetLogger()
+
+	user, err := repo.UserRepository.FindUserById(ctx, id)
+	if err != nil {
+		log.Error("Error finding user: %v", err)
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, pkg.NewNotFoundError("user not found")
+	}
+
+	return user, nil
+}
+
+func recordUserAudit(
+	ctx context.Context,
+	repo *AdminUserService,
+	actorId string,
+	action string,
+	before *domain.User,
+	after *domain.User,
+	details map[string]any,
+) {
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:    &actorId,
+		Action:     action,
+		TargetType: domain.AuditTargetUser,
+		TargetId:   before.Id,
+		Before:     before,
+		After:      after,
+		Details:    details,
+	})
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+func TestListUsersService(t *testing.T) {
+	t.Run("should translate page into offset and limit", func(t *testing.T) {
+		var filter domain.UserFilter
+
+		mock := &userRepo{
+			searchUsersFunc: func(ctx context.Context, f domain.UserFilter) ([]domain.User, int, error) {
+				filter = f
+				return []domain.User{{Id: "1"}}, 51, nil
+			},
+		}
+
+		ctx := context.Background()
+		adminUserService := AdminUserService{UserRepository: mock}
+		page, err := adminUserService.ListUsersService(ctx, domain.AdminUserQuery{
+			Query:    " jane ",
+			Status:   domain.UserStatusDeactivated,
+			Page:     3,
+			PageSize: 25,
+		})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, 50, filter.Offset, "should skip previous pages")
+		assert.Equal(t, 25, filter.Limit, "should limit to page size")
+		assert.Equal(t, "jane", filter.Query, "should trim query")
+		assert.Equal(t, 51, page.Total, "should return total")
+	})
+
+	t.Run("should reject unknown status", func(t *testing.T) {
+		ctx := context.Background()
+		adminUserService := AdminUserService{UserRepository: &userRepo{}}
+		_, err := adminUserService.ListUsersService(ctx, domain.AdminUserQuery{Status: "banned", Page: 1, PageSize: 25})
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "invalid status filter", err.Error(), "should return correct message")
+	})
+}
+
+func TestUpdateAdminUserService(t *testing.T) {
+	adminId := "5f0b5c1e-7a8e-4d43-8f0d-2b3f41a9d7c2"
+	role := domain.RoleUser
+
+	t.Run("should not let admins demote themselves", func(t *testing.T) {
+		mock := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id, Role: domain.RoleAdmin}, nil
+			},
+		}
+
+		ctx := context.Background()
+		adminUserService := AdminUserService{UserRepository: mock}
+		_, err := adminUserService.UpdateUserService(ctx, adminId, adminId, domain.UpdateAdminUser{Role: &role})
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "admins cannot change their own role", err.Error(), "should return correct message")
+	})
+
+	t.Run("should clear team when empty", func(t *testing.T) {
+		team := "design"
+		empty := " "
+
+		mock := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id, Role: domain.RoleUser, Team: &team}, nil
+			},
+			updateUserTeamFunc: func(ctx context.Context, id string, t *string) (bool, error) {
+				team = ""
+				if t != nil {
+					team = *t
+				}
+				return true, nil
+			},
+		}
+
+		ctx := context.Background()
+		adminUserService := AdminUserService{UserRepository: mock}
+		_, err := adminUserService.UpdateUserService(ctx, adminId, "user-1", domain.UpdateAdminUser{Team: &empty})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "", team, "should clear team")
+	})
+
+	t.Run("should audit role changes", func(t *testing.T) {
+		var entries []domain.CreateAuditLog
+		current := domain.RoleUser
+		admin := domain.RoleAdmin
+
+		mock := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id, Role: current}, nil
+			},
+			updateUserRoleFunc: func(ctx context.Context, id string, role string) (bool, error) {
+				current = role
+				return true, nil
+			},
+		}
+		audit := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				entries = append(entries, entry)
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		adminUserService := AdminUserService{UserRepository: mock, AuditLogRepository: audit}
+		_, err := adminUserService.UpdateUserService(ctx, adminId, "user-1", domain.UpdateAdminUser{Role: &admin})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Len(t, entries, 1, "should record a single entry")
+		assert.Equal(t, domain.AuditActionUserRoleChanged, entries[0].Action, "should record a role change")
+		assert.Equal(t, "user-1", entries[0].TargetId, "should target the user")
+		assert.Equal(t, map[string]any{"role": domain.RoleUser}, entries[0].Before, "should record the previous role")
+		assert.Equal(t, map[string]any{"role": domain.RoleAdmin}, entries[0].After, "should record the new role")
+	})
+}
+
+func TestDeleteUserService(t *testing.T) {
+	adminId := "5f0b5c1e-7a8e-4d43-8f0d-2b3f41a9d7c2"
+
+	t.Run("should soft delete user", func(t *testing.T) {
+		mock := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id}, nil
+			},
+			softDeleteUserFunc: func(ctx context.Context, id string) (int, error) {
+				return 2, nil
+			},
+		}
+
+		ctx := context.Background()
+		adminUserService := AdminUserService{UserRepository: mock}
+		cancelled, err := adminUserService.DeleteUserService(ctx, adminId, "user-1")
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, 2, cancelled, "should report cancelled reservations")
+	})
+
+	t.Run("should not let admins delete themselves", func(t *testing.T) {
+		ctx := context.Background()
+		adminUserService := AdminUserService{UserRepository: &userRepo{}}
+		_, err := adminUserService.DeleteUserService(ctx, adminId, adminId)
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "admins cannot delete their own account", err.Error(), "should return correct message")
+	})
+}
+
+func TestReactivateUserService(t *testing.T) {
+	t.Run("should not reactivate deleted users", func(t *testing.T) {
+		deletedAt := time.Now()
+
+		mock := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id, DeactivatedAt: &deletedAt, DeletedAt: &deletedAt}, nil
+			},
+		}
+
+		ctx := context.Background()
+		adminUserService := AdminUserService{UserRepository: mock}
+		err := adminUserService.ReactivateUserService(ctx, "admin-1", "user-1")
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "deleted users cannot be reactivated", err.Error(), "should return correct message")
+	})
+}
+
+func TestListUserReservationsService(t *testing.T) {
+	t.Run("should list reservations of deleted users", func(t *testing.T) {
+		deletedAt := time.Now()
+
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id, DeletedAt: &deletedAt}, nil
+			},
+		}
+		reservations := &reservationRepo{
+			FindReservationsByUserFunc: func(
+				ctx context.Context,
+				userId string,
+				offset int,
+				limit int,
+			) ([]domain.Reservation, int, error) {
+				return []domain.Reservation{{Id: "r-1", UserId: userId}}, 1, nil
+			},
+		}
+
+		ctx := context.Background()
+		adminUserService := AdminUserService{UserRepository: users, ReservationRepository: reservations}
+		page, err := adminUserService.ListUserReservationsService(ctx, "user-1", 1, 25)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "user-1", page.Items[0].UserId, "should keep referencing the user")
+	})
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const (
+	apiKeyPrefix        = "dsk_"
+	apiKeyDisplayLength = 12
+)
+
+type APIKeyService struct {
+	APIKeyRepository domain.APIKeyRepositoryInterface
+	UserRepository   domain.UserRepositoryInterface
+}
+
+func (repo *APIKeyService) CreateAPIKeyService(
+	ctx context.Context,
+	userId string,
+	data domain.CreateAPIKeyRequest,
+) (*domain.CreatedAPIKey, error) {
+	log := pkg.GetLogger()
+
+	if data.ExpiresAt != nil && !data.ExpiresAt.After(time.Now()) {
+		return nil, pkg.NewBadRequestError("expiry must be in the future")
+	}
+
+	token, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		log.Error("Error generating api key: %v", err)
+		return nil, pkg.NewInternalServerError("failed to generate api key", err)
+	}
+
+	rawKey := apiKeyPrefix + token
+	slices.Sort(data.Scopes)
+
+	saved, err := repo.APIKeyRepository.SaveAPIKey(ctx, domain.CreateAPIKey{
+		UserId:    userId,
+		Name:      data.Name,
+		KeyPrefix: rawKey[:apiKeyDisplayLength],
+		KeyHash:   pkg.HashToken(rawKey),
+		Scopes:    slices.Compact(data.Scopes),
+		ExpiresAt: data.ExpiresAt,
+	})
+	if err != nil {
+		log.Error("Error saving api key: %v", err)
+		return nil, err
+	}
+
+	log.Info("API key %s created for user: %s", saved.Id, userId)
+	return &domain.CreatedAPIKey{APIKey: *saved, Key: rawKey}, nil
+}
+
+func (repo *APIKeyService) ListAPIKeysService(ctx context.Context, userId string) ([]domain.APIKey, error) {
+	log := pkg.GetLogger()
+
+	keys, err := repo.APIKeyRepository.ListAPIKeys(ctx, userId)
+	if err != nil {
+		log.Error("Error listing api keys: %v", err)
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (repo *APIKeyService) RevokeAPIKeyService(ctx context.Context, userId string, id string) error {
+	log := pkg.GetLogger()
+
+	revoked, err := repo.APIKeyRepository.RevokeAPIKey(ctx, userId, id)
+	if err != nil {
+		log.Error("Error revoking api key: %v", err)
+		return err
+	}
+
+	if !revoked {
+		return pkg.NewNotFoundError("api key not found")
+	}
+
+	log.Info("API key %s revoked by user: %s", id, userId)
+	return nil
+}
+
+func (repo *APIKeyService) AuthenticateAPIKeyService(
+	ctx context.Context,
+	rawKey string,
+) (*domain.APIKey, *domain.User, error) {
+	log := pkg.GetLogger()
+
+	key, err := repo.APIKeyRepository.FindAPIKeyByHash(ctx, pkg.HashToken(rawKey))
+	if err != nil {
+		log.Error("Error finding api key: %v", err)
+		return nil, nil, err
+	}
+
+	if key == nil || key.RevokedAt != nil {
+		return nil, nil, pkg.NewUnauthorizedError("invalid api key")
+	}
+
+	if key.ExpiresAt != nil && time.Now().After(*key.ExpiresAt) {
+		return nil, nil, pkg.NewUnauthorizedError("api key has expired")
+	}
+
+	user, err := repo.UserRepository.FindUserById(ctx, key.UserId)
+	if err != nil {
+		log.Error("Error to find user by id: %v", err)
+		return nil, nil, err
+	}
+
+	if user == nil {
+		return nil, nil, pkg.NewUnauthorizedError("invalid api key")
+	}
+
+	if err := checkUserActive(user); err != nil {
+		return nil, nil, err
+	}
+
+	if err := repo.APIKeyRepository.TouchAPIKey(ctx, key.Id); err != nil {
+		log.Error("Error updating api key usage: %v", err)
+		return nil, nil, err
+	}
+
+	return key, user, nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type apiKeyRepo struct {
+	SaveAPIKeyFunc       func(ctx context.Context, key domain.CreateAPIKey) (*domain.APIKey, error)
+	ListAPIKeysFunc      func(ctx context.Context, userId string) ([]domain.APIKey, error)
+	FindAPIKeyByHashFunc func(ctx context.Context, keyHash string) (*domain.APIKey, error)
+	TouchAPIKeyFunc      func(ctx context.Context, id string) error
+	RevokeAPIKeyFunc     func(ctx context.Context, userId string, id string) (bool, error)
+}
+
+func (r *apiKeyRepo) SaveAPIKey(ctx context.Context, key domain.CreateAPIKey) (*domain.APIKey, error) {
+	return r.SaveAPIKeyFunc(ctx, key)
+}
+
+func (r *apiKeyRepo) ListAPIKeys(ctx context.Context, userId string) ([]domain.APIKey, error) {
+	return r.ListAPIKeysFunc(ctx, userId)
+}
+
+func (r *apiKeyRepo) FindAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	return r.FindAPIKeyByHashFunc(ctx, keyHash)
+}
+
+func (r *apiKeyRepo) TouchAPIKey(ctx context.Context, id string) error {
+	return r.TouchAPIKeyFunc(ctx, id)
+}
+
+func (r *apiKeyRepo) RevokeAPIKey(ctx context.Context, userId string, id string) (bool, error) {
+	return r.RevokeAPIKeyFunc(ctx, userId, id)
+}
+
+func TestCreateAPIKeyService(t *testing.T) {
+	t.Run("should store only the key hash", func(t *testing.T) {
+		var saved domain.CreateAPIKey
+
+		keys := &apiKeyRepo{
+			SaveAPIKeyFunc: func(ctx context.Context, key domain.CreateAPIKey) (*domain.APIKey, error) {
+				saved = key
+				return &domain.APIKey{Id: "key-1", UserId: key.UserId, Name: key.Name, Scopes: key.Scopes}, nil
+			},
+		}
+		data := domain.CreateAPIKeyRequest{
+			Name:   "chat bot",
+			Scopes: []string{domain.ScopeWriteReservations, domain.ScopeReadReservations, domain.ScopeReadReservations},
+		}
+
+		ctx := context.Background()
+		apiKeyService := APIKeyService{APIKeyRepository: keys}
+		created, err := apiKeyService.CreateAPIKeyService(ctx, "user-1", data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.True(t, strings.HasPrefix(created.Key, "dsk_"), "should return the raw key once")
+		assert.Equal(t, pkg.HashToken(created.Key), saved.KeyHash, "should store the key hash")
+		assert.Equal(t, created.Key[:12], saved.KeyPrefix, "should store a display prefix")
+		assert.Equal(t, []string{domain.ScopeReadReservations, domain.ScopeWriteReservations}, saved.Scopes)
+	})
+
+	t.Run("should reject expiry in the past", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+
+		ctx := context.Background()
+		apiKeyService := APIKeyService{}
+		_, err := apiKeyService.CreateAPIKeyService(ctx, "user-1", domain.CreateAPIKeyRequest{
+			Name:      "old",
+			Scopes:    []string{domain.ScopeReadReservations},
+			ExpiresAt: &expiresAt,
+		})
+
+		assert.IsType(t, &pkg.BadRequestError{}, err, "should be bad request")
+	})
+}
+
+func TestAuthenticateAPIKeyService(t *testing.T) {
+	users := &userRepo{
+		findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+			return &domain.User{Id: id, Email: "test@test.com", Role: domain.RoleUser}, nil
+		},
+	}
+
+	t.Run("should authenticate and track usage", func(t *testing.T) {
+		var touched string
+
+		keys := &apiKeyRepo{
+			FindAPIKeyByHashFunc: func(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+				assert.Equal(t, pkg.HashToken("dsk_secret"), keyHash)
+				return &domain.APIKey{Id: "key-1", UserId: "user-1"}, nil
+			},
+			TouchAPIKeyFunc: func(ctx context.Context, id string) error {
+				touched = id
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		apiKeyService := APIKeyService{APIKeyRepository: keys, UserRepository: users}
+		key, user, err := apiKeyService.AuthenticateAPIKeyService(ctx, "dsk_secret")
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "key-1", key.Id)
+		assert.Equal(t, "user-1", user.Id)
+		assert.Equal(t, "key-1", touched, "should record last use")
+	})
+
+	t.Run("should reject expired key", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Minute)
+		keys := &apiKeyRepo{
+			FindAPIKeyByHashFunc: func(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+				return &domain.APIKey{Id: "key-1", UserId: "user-1", ExpiresAt: &expiresAt}, nil
+			},
+		}
+
+		ctx := context.Background()
+		apiKeyService := APIKeyService{APIKeyRepository: keys, UserRepository: users}
+		_, _, err := apiKeyService.AuthenticateAPIKeyService(ctx, "dsk_secret")
+
+		assert.IsType(t, &pkg.UnauthorizedError{}, err, "should be unauthorized")
+	})
+
+	t.Run("should reject revoked key", func(t *testing.T) {
+		revokedAt := time.Now()
+		keys := &apiKeyRepo{
+			FindAPIKeyByHashFunc: func(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+				return &domain.APIKey{Id: "key-1", UserId: "user-1", RevokedAt: &revokedAt}, nil
+			},
+		}
+
+		ctx := context.Background()
+		apiKeyService := APIKeyService{APIKeyRepository: keys, UserRepository: users}
+		_, _, err := apiKeyService.AuthenticateAPIKeyService(ctx, "dsk_secret")
+
+		assert.Equal(t, "invalid api key", err.Error(), "should return correct message")
+	})
+}
+
+func TestRevokeAPIKeyService(t *testing.T) {
+	t.Run("should return not found for another user's key", func(t *testing.T) {
+		keys := &apiKeyRepo{
+			RevokeAPIKeyFunc: func(ctx context.Context, userId string, id string) (bool, error) {
+				return false, nil
+			},
+		}
+
+		ctx := context.Background()
+		apiKeyService := APIKeyService{APIKeyRepository: keys}
+		err := apiKeyService.RevokeAPIKeyService(ctx, "user-2", "key-1")
+
+		assert.IsType(t, &pkg.NotFoundError{}, err, "should be not found")
+	})
+}
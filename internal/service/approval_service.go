@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func (repo *ReservationService) ListPendingApprovalsService(
+	ctx context.Context,
+) ([]domain.Reservation, error) {
+	log := pkg.GetLogger()
+
+	reservations, err := repo.ReservationRepository.FindPendingApprovals(ctx)
+	if err != nil {
+		log.Error("Error to find pending approvals: %v", err)
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+func (repo *ReservationService) ReviewReservationService(
+	ctx context.Context,
+	reviewerId string,
+	reservationId string,
+	approve bool,
+) error {
+	log := pkg.GetLogger()
+
+	status := domain.ReservationStatusRejected
+	if approve {
+		status = domain.ReservationStatusConfirmed
+	}
+
+	log.Info("Processing review of reservation: %s as %s", reservationId, status)
+
+	reservation, err := findReservation(ctx, repo, reservationId)
+	if err != nil {
+		return err
+	}
+
+	if reservation.UserId == reviewerId {
+		return pkg.NewForbiddenError("reviewers cannot review their own reservations")
+	}
+
+	if err := checkReservationTransition(reservation.Status, status); err != nil {
+		return err
+	}
+
+	reviewed, err := repo.ReservationRepository.ReviewReservation(ctx, reservationId, status, reviewerId)
+	if err != nil {
+		log.Error("Error to review reservation: %v", err)
+		return err
+	}
+
+	if !reviewed {
+		return pkg.NewBadRequestError("reservation is not pending approval")
+	}
+
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:    &reviewerId,
+		Action:     domain.AuditActionReservationReviewed,
+		TargetType: domain.AuditTargetReservation,
+		TargetId:   reservationId,
+		Before:     map[string]any{"status": domain.ReservationStatusPending},
+		After:      map[string]any{"status": status, "reviewed_by": reviewerId},
+	})
+
+	log.Info("reservation %s successfully", status)
+	return nil
+}
+
+func findReservableDesk(
+	ctx context.Context,
+	repo *ReservationService,
+	deskId string,
+) (*domain.Desk, error) {
+	log := pkg.GetLogger()
+
+	desk, err := repo.DeskRepository.FindDeskById(ctx, deskId)
+	if err != nil {
+		log.Error("Error to find desk: %v", err)
+		return nil, err
+	}
+
+	if desk == nil {
+		return nil, pkg.NewNotFoundError("desk not found")
+	}
+
+	return desk, nil
+}
+
+func resolveReservationStatus(desk *domain.Desk) string {
+	if desk.RequiresApproval {
+		return domain.ReservationStatusPending
+	}
+
+	return domain.ReservationStatusConfirmed
+}
+
+func notifyApprovers(ctx context.Context, repo *ReservationService, deskId string, date time.Time) {
+	log := pkg.GetLogger()
+
+	if repo.Notifier == nil || repo.UserRepository == nil {
+		return
+	}
+
+	approvers, err := repo.UserRepository.FindUsersByRole(ctx, domain.RoleApprover)
+	if err != nil {
+		log.Warn("Could not load approvers: %v", err)
+		return
+	}
+
+	recipients := []string{}
+	for _, approver := range approvers {
+		if !approver.NotificationPreferences.ApprovalRequests {
+			continue
+		}
+		recipients = append(recipients, approver.Email)
+	}
+
+	if len(recipients) == 0 {
+		log.Warn("No approvers to notify for desk: %s", deskId)
+		return
+	}
+
+	body := fmt.Sprintf(
+		"A reservation for desk %s on %s is waiting for approval.",
+		deskId,
+		date.Format("2006-01-02"),
+	)
+
+	if err := repo.Notifier.Notify(ctx, recipients, "Reservation pending approval", body); err != nil {
+		log.Warn("Could not notify approvers: %v", err)
+	}
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type notifierMock struct {
+	recipients []string
+	subject    string
+}
+
+func (n *notifierMock) Notify(
+	ctx context.Context,
+	recipients []string,
+	subject string,
+	body string,
+) error {
+	n.recipients = recipients
+	n.subject = subject
+	return nil
+}
+
+func TestCreateReservationRequiringApproval(t *testing.T) {
+	parsedTime, _ := time.Parse(time.RFC3339, "2025-06-05T00:54:07Z")
+
+	data := domain.CreateReservation{
+		DeskId: "48b8c429-be55-470f-a245-651fc3c75a6b",
+		UserId: "1a162e27-45ff-4632-817a-a79e88c8f878",
+		Date:   parsedTime,
+	}
+
+	t.Run("should keep reservation pending and notify approvers", func(t *testing.T) {
+		var savedStatus string
+
+		mock := &reservationRepo{
+			FindReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (*domain.Reservation, error) {
+				return nil, nil
+			},
+			SaveReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (string, error) {
+				savedStatus = reservation.Status
+				return "reservation-id", nil
+			},
+		}
+		desks := &deskRepo{
+			FindDeskByIdFunc: func(ctx context.Context, id string) (*domain.Desk, error) {
+				return &domain.Desk{Id: id, RequiresApproval: true}, nil
+			},
+		}
+		users := &userRepo{
+			findUsersByRoleFunc: func(ctx context.Context, role string) ([]domain.User, error) {
+				return []domain.User{
+					{
+						Email:                   "approver@example.com",
+						Role:                    role,
+						NotificationPreferences: domain.NotificationPreferences{ApprovalRequests: true},
+					},
+					{Email: "opted-out@example.com", Role: role},
+				}, nil
+			},
+		}
+		notifier := &notifierMock{}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        desks,
+			UserRepository:        users,
+			Notifier:              notifier,
+		}
+		err := reservation.CreateReservationService(ctx, data.UserId, data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, domain.ReservationStatusPending, savedStatus, "should save as pending")
+		assert.Equal(t, []string{"approver@example.com"}, notifier.recipients, "should skip approvers who opted out")
+	})
+
+	t.Run("should confirm reservation for regular desks", func(t *testing.T) {
+		var savedStatus string
+		notifier := &notifierMock{}
+
+		mock := &reservationRepo{
+			FindReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (*domain.Reservation, error) {
+				return nil, nil
+			},
+			SaveReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (string, error) {
+				savedStatus = reservation.Status
+				return "reservation-id", nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			Notifier:              notifier,
+		}
+		err := reservation.CreateReservationService(ctx, data.UserId, data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, domain.ReservationStatusConfirmed, savedStatus, "should save as confirmed")
+		assert.Empty(t, notifier.recipients, "should not notify approvers")
+	})
+
+	t.Run("should return desk not found", func(t *testing.T) {
+		mock := &reservationRepo{
+			FindReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (*domain.Reservation, error) {
+				return nil, nil
+			},
+		}
+		desks := &deskRepo{
+			FindDeskByIdFunc: func(ctx context.Context, id string) (*domain.Desk, error) {
+				return nil, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{ReservationRepository: mock, DeskRepository: desks}
+		err := reservation.CreateReservationService(ctx, data.UserId, data)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "desk not found", err.Error(), "should return correct message")
+	})
+}
+
+func pendingReservationRepo() *reservationRepo {
+	return reservationByIdRepo(domain.Reservation{
+		Id:     "res-1",
+		UserId: "1a162e27-45ff-4632-817a-a79e88c8f878",
+		Status: domain.ReservationStatusPending,
+	})
+}
+
+func TestReviewReservationService(t *testing.T) {
+	reviewerId := "5f0b5c1e-7a8e-4d43-8f0d-2b3f41a9d7c2"
+
+	t.Run("should confirm approved reservation", func(t *testing.T) {
+		var reviewedStatus string
+
+		mock := pendingReservationRepo()
+		mock.ReviewReservationFunc = func(
+			ctx context.Context,
+			id string,
+			status string,
+			reviewer string,
+		) (bool, error) {
+			reviewedStatus = status
+			return true, nil
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{ReservationRepository: mock}
+		err := reservation.ReviewReservationService(ctx, reviewerId, "res-1", true)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, domain.ReservationStatusConfirmed, reviewedStatus, "should confirm reservation")
+	})
+
+	t.Run("should reject reservation", func(t *testing.T) {
+		var reviewedStatus string
+
+		mock := pendingReservationRepo()
+		mock.ReviewReservationFunc = func(
+			ctx context.Context,
+			id string,
+			status string,
+			reviewer string,
+		) (bool, error) {
+			reviewedStatus = status
+			return true, nil
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{ReservationRepository: mock}
+		err := reservation.ReviewReservationService(ctx, reviewerId, "res-1", false)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, domain.ReservationStatusRejected, reviewedStatus, "should reject reservation")
+	})
+
+	t.Run("should fail when reservation is not pending", func(t *testing.T) {
+		mock := pendingReservationRepo()
+		mock.ReviewReservationFunc = func(
+			ctx context.Context,
+			id string,
+			status string,
+			reviewer string,
+		) (bool, error) {
+			return false, nil
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{ReservationRepository: mock}
+		err := reservation.ReviewReservationService(ctx, reviewerId, "res-1", true)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "reservation is not pending approval", err.Error(), "should return correct message")
+	})
+	t.Run("should not let reviewers review their own reservation", func(t *testing.T) {
+		mock := pendingReservationRepo()
+
+		ctx := context.Background()
+		reservation := ReservationService{ReservationRepository: mock}
+		err := reservation.ReviewReservationService(ctx, "1a162e27-45ff-4632-817a-a79e88c8f878", "res-1", true)
+
+		assert.Error(t, err, "should return error")
+		assert.IsType(t, &pkg.ForbiddenError{}, err, "should be forbidden")
+		assert.Equal(t, "reviewers cannot review their own reservations", err.Error(), "should return correct message")
+	})
+
+	t.Run("should not review a confirmed reservation", func(t *testing.T) {
+		mock := reservationByIdRepo(domain.Reservation{Id: "res-1", Status: domain.ReservationStatusConfirmed})
+
+		ctx := context.Background()
+		reservation := ReservationService{ReservationRepository: mock}
+		err := reservation.ReviewReservationService(ctx, reviewerId, "res-1", false)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(
+			t,
+			"reservation cannot move from confirmed to rejected",
+			err.Error(),
+			"should reject illegal transition",
+		)
+	})
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type AuditService struct {
+	AuditLogRepository domain.AuditLogRepositoryInterface
+}
+
+func (repo *AuditService) ListAuditLogsService(
+	ctx context.Context,
+	query domain.AuditLogQuery,
+) (*domain.Page[domain.AuditLog], error) {
+	log := pkg.GetLogger()
+
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return nil, pkg.NewBadRequestError("from must be before to")
+	}
+
+	filter := domain.AuditLogFilter{
+		ActorId:    query.ActorId,
+		Action:     query.Action,
+		TargetType: query.TargetType,
+		TargetId:   query.TargetId,
+		RequestId:  query.RequestId,
+		From:       query.From,
+		To:         query.To,
+		Offset:     (query.Page - 1) * query.PageSize,
+		Limit:      query.PageSize,
+	}
+
+	logs, total, err := repo.AuditLogRepository.FindAuditLogs(ctx, filter)
+	if err != nil {
+		log.Error("Error finding audit logs: %v", err)
+		return nil, err
+	}
+
+	return &domain.Page[domain.AuditLog]{
+		Items:    logs,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
+}
+
+// recordAudit never fails the action being audited; a lost entry is logged.
+func recordAudit(ctx context.Context, auditLogs domain.AuditLogRepositoryInterface, entry domain.CreateAuditLog) {
+	log := pkg.GetLogger()
+
+	if auditLogs == nil {
+		return
+	}
+
+	if err := auditLogs.SaveAuditLog(ctx, entry); err != nil {
+		log.Error("Error saving %s audit log: %v", entry.Action, err)
+	}
+}
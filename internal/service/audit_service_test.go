@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+func TestListAuditLogsService(t *testing.T) {
+	t.Run("should translate page into offset and limit", func(t *testing.T) {
+		var filter domain.AuditLogFilter
+
+		mock := &auditLogRepo{
+			FindAuditLogsFunc: func(ctx context.Context, f domain.AuditLogFilter) ([]domain.AuditLog, int, error) {
+				filter = f
+				return []domain.AuditLog{{Id: "1"}}, 26, nil
+			},
+		}
+
+		ctx := context.Background()
+		auditService := AuditService{AuditLogRepository: mock}
+		page, err := auditService.ListAuditLogsService(ctx, domain.AuditLogQuery{
+			Action:   domain.AuditActionReservationCancelled,
+			Page:     2,
+			PageSize: 25,
+		})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, 25, filter.Offset, "should skip previous pages")
+		assert.Equal(t, 25, filter.Limit, "should limit to page size")
+		assert.Equal(t, domain.AuditActionReservationCancelled, filter.Action, "should filter by action")
+		assert.Equal(t, 26, page.Total, "should return total")
+	})
+
+	t.Run("should reject inverted time range", func(t *testing.T) {
+		from := time.Now()
+		to := from.Add(-time.Hour)
+
+		ctx := context.Background()
+		auditService := AuditService{AuditLogRepository: &auditLogRepo{}}
+		_, err := auditService.ListAuditLogsService(ctx, domain.AuditLogQuery{From: &from, To: &to, Page: 1, PageSize: 25})
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "from must be before to", err.Error(), "should return correct message")
+	})
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type DeskService struct {
+	DeskRepository     domain.DeskRepositoryInterface
+	AuditLogRepository domain.AuditLogRepositoryInterface
+}
+
+func (repo *DeskService) UpdateDeskApprovalService(
+	ctx context.Context,
+	actorId string,
+	deskId string,
+	requiresApproval bool,
+) error {
+	log := pkg.GetLogger()
+
+	log.Info("Processing approval flag update for desk: %s", deskId)
+
+	previous, err := repo.DeskRepository.UpdateDeskApproval(ctx, deskId, requiresApproval)
+	if err != nil {
+		log.Error("Error to update desk: %v", err)
+		return err
+	}
+
+	if previous == nil {
+		return pkg.NewNotFoundError("desk not found")
+	}
+
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:    &actorId,
+		Action:     domain.AuditActionDeskUpdated,
+		TargetType: domain.AuditTargetDesk,
+		TargetId:   deskId,
+		Before:     map[string]any{"requires_approval": *previous},
+		After:      map[string]any{"requires_approval": requiresApproval},
+	})
+
+	log.Info("desk updated successfully")
+	return nil
+}
+
+func (repo *DeskService) UpdateZoneApprovalService(
+	ctx context.Context,
+	actorId string,
+	zoneId string,
+	requiresApproval bool,
+) error {
+	log := pkg.GetLogger()
+
+	log.Info("Processing approval flag update for zone: %s", zoneId)
+
+	previous, err := repo.DeskRepository.UpdateZoneApproval(ctx, zoneId, requiresApproval)
+	if err != nil {
+		log.Error("Error to update zone: %v", err)
+		return err
+	}
+
+	if previous == nil {
+		return pkg.NewNotFoundError("zone not found")
+	}
+
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:    &actorId,
+		Action:     domain.AuditActionZoneUpdated,
+		TargetType: domain.AuditTargetZone,
+		TargetId:   zoneId,
+		Before:     map[string]any{"requires_approval": *previous},
+		After:      map[string]any{"requires_approval": requiresApproval},
+	})
+
+	log.Info("zone updated successfully")
+	return nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+func TestUpdateDeskApprovalService(t *testing.T) {
+	t.Run("should audit the previous and new flag", func(t *testing.T) {
+		var audited domain.CreateAuditLog
+		previous := false
+
+		desks := &deskRepo{
+			UpdateDeskApprovalFunc: func(ctx context.Context, id string, requiresApproval bool) (*bool, error) {
+				return &previous, nil
+			},
+		}
+		audit := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = entry
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		deskService := DeskService{DeskRepository: desks, AuditLogRepository: audit}
+		err := deskService.UpdateDeskApprovalService(ctx, "admin-1", "desk-1", true)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, false, audited.Before.(map[string]any)["requires_approval"], "should record the previous flag")
+		assert.Equal(t, true, audited.After.(map[string]any)["requires_approval"], "should record the new flag")
+	})
+}
+
+func TestUpdateZoneApprovalService(t *testing.T) {
+	t.Run("should update and audit the zone", func(t *testing.T) {
+		var audited domain.CreateAuditLog
+		previous := true
+
+		desks := &deskRepo{
+			UpdateZoneApprovalFunc: func(ctx context.Context, id string, requiresApproval bool) (*bool, error) {
+				return &previous, nil
+			},
+		}
+		audit := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = entry
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		deskService := DeskService{DeskRepository: desks, AuditLogRepository: audit}
+		err := deskService.UpdateZoneApprovalService(ctx, "admin-1", "zone-1", false)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, domain.AuditActionZoneUpdated, audited.Action, "should audit the update")
+		assert.Equal(t, "zone-1", audited.TargetId, "should target the zone")
+		assert.Equal(t, true, audited.Before.(map[string]any)["requires_approval"], "should record the previous flag")
+	})
+
+	t.Run("should not update another tenant's zone", func(t *testing.T) {
+		desks := &deskRepo{
+			UpdateZoneApprovalFunc: func(ctx context.Context, id string, requiresApproval bool) (*bool, error) {
+				return nil, nil
+			},
+		}
+
+		ctx := context.Background()
+		deskService := DeskService{DeskRepository: desks}
+		err := deskService.UpdateZoneApprovalService(ctx, "admin-1", "zone-1", true)
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "zone not found", err.Error(), "should return correct message")
+	})
+}
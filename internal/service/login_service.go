@@ -2,13 +2,25 @@ package service
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/tufee/desk-reservation-go/internal/domain"
 	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
 )
 
+// Compared against when the email is unknown so that response timing does not
+// reveal which accounts exist.
+const timingEqualizerHash = "$2a$10$Zysv9VyFBooYNcfReCR3renNztkxCE/juy6R/3tsOPlvXLIAJo4Be"
+
 type LoginService struct {
-	UserRepository domain.UserRepositoryInterface
+	UserRepository         domain.UserRepositoryInterface
+	TokenRepository        domain.TokenRepositoryInterface
+	Directory              domain.DirectoryAuthenticatorInterface
+	MFARepository          domain.MFARepositoryInterface
+	LoginAttemptRepository domain.LoginAttemptRepositoryInterface
+	AuditLogRepository     domain.AuditLogRepositoryInterface
+	Throttle               domain.LoginThrottlePolicy
 }
 
 func (repo *LoginService) LoginService(
@@ -19,39 +31,310 @@ func (repo *LoginService) LoginService(
 
 	log.Info("Processing login for: %s", credentials.Email)
 
-	user, err := GetUserByEmail(ctx, repo, credentials.Email)
+	if err := checkLoginThrottle(ctx, repo, credentials); err != nil {
+		return nil, err
+	}
+
+	user, err := repo.UserRepository.FindUserByEmail(ctx, credentials.Email)
 	if err != nil {
 		log.Error("error to find user by email: %v", err)
 		return nil, err
 	}
 
-	isPasswordMatch := pkg.CheckPasswordHash(credentials.Password, user.Password)
+	passwordHash := timingEqualizerHash
+	if user != nil {
+		passwordHash = user.Password
+	}
+
+	if !pkg.CheckPasswordHash(credentials.Password, passwordHash) || user == nil {
+		directoryUser, err := authenticateWithDirectory(ctx, repo, credentials)
+		if err != nil {
+			return nil, err
+		}
+
+		if directoryUser == nil {
+			log.Info("Invalid credentials for: %s", credentials.Email)
+			recordLoginAudit(ctx, repo, domain.AuditActionLoginFailed, credentials, user)
+			if err := recordLoginAttempt(ctx, repo, credentials, user, false); err != nil {
+				return nil, err
+			}
+			return nil, pkg.NewBadRequestError("invalid credentials")
+		}
+
+		user = directoryUser
+	}
+
+	if err := checkUserActive(user); err != nil {
+		log.Info("Login attempt for deactivated user: %s", credentials.Email)
+		return nil, err
+	}
 
-	if !isPasswordMatch {
-		log.Info("Invalid password for user: %s", credentials.Email)
-		return nil, pkg.NewBadRequestError("invalid password")
+	if err := checkEmailVerified(user); err != nil {
+		log.Info("Login attempt for unverified user: %s", credentials.Email)
+		return nil, err
 	}
 
-	token, err := pkg.GenerateJWT(user.Id, user.Email)
-	if err != nil {
-		log.Error("Error generating JWT token: %v", err)
-		return nil, pkg.NewInternalServerError("failed to generate JWT token", err)
+	recordLoginAudit(ctx, repo, domain.AuditActionLogin, credentials, user)
+
+	challenge, err := mfaChallenge(ctx, repo, user)
+	if err != nil || challenge != nil {
+		return challenge, err
+	}
+
+	// With a second factor owed the attempt only succeeds once the code is
+	// verified, so a known password cannot clear earlier code failures.
+	if err := recordLoginAttempt(ctx, repo, credentials, user, true); err != nil {
+		return nil, err
 	}
-	return &domain.LoginResponse{Token: token}, nil
+
+	return issueLoginTokens(ctx, repo.TokenRepository, user)
 }
 
-func GetUserByEmail(ctx context.Context, repo *LoginService, email string) (*domain.User, error) {
+func mfaChallenge(ctx context.Context, repo *LoginService, user *domain.User) (*domain.LoginResponse, error) {
 	log := pkg.GetLogger()
 
-	user, err := repo.UserRepository.FindUserByEmail(ctx, email)
+	if repo.MFARepository == nil {
+		return nil, nil
+	}
+
+	mfa, err := repo.MFARepository.FindUserMFA(ctx, user.Id)
 	if err != nil {
+		log.Error("Error to find user mfa: %v", err)
 		return nil, err
 	}
 
-	if user == nil {
-		log.Info("User not found with email: %s", email)
-		return nil, pkg.NewBadRequestError("user not found")
+	if mfa == nil || mfa.EnabledAt == nil {
+		return nil, nil
+	}
+
+	token, err := pkg.GenerateMFAChallenge(user.Id)
+	if err != nil {
+		log.Error("Error generating mfa challenge: %v", err)
+		return nil, pkg.NewInternalServerError("failed to generate mfa challenge", err)
+	}
+
+	log.Info("MFA challenge issued for user: %s", user.Id)
+	return &domain.LoginResponse{
+		MfaRequired: true,
+		MfaToken:    token,
+		ExpiresIn:   int(pkg.MFAChallengeTTL.Seconds()),
+	}, nil
+}
+
+func checkLoginThrottle(ctx context.Context, repo *LoginService, credentials domain.Credentials) error {
+	log := pkg.GetLogger()
+
+	if repo.LoginAttemptRepository == nil {
+		return nil
+	}
+
+	now := time.Now()
+	since := now.Add(-repo.Throttle.Window)
+
+	accountFailures, err := repo.LoginAttemptRepository.CountAccountFailures(ctx, throttleKey(credentials.Email), since)
+	if err != nil {
+		log.Error("Error counting account login failures: %v", err)
+		return err
+	}
+
+	ipFailures, err := repo.LoginAttemptRepository.CountIPFailures(ctx, credentials.IpAddress, since)
+	if err != nil {
+		log.Error("Error counting ip login failures: %v", err)
+		return err
+	}
+
+	retryAfter := max(
+		lockoutRemaining(repo.Throttle, accountFailures, repo.Throttle.MaxAccountFailures, now),
+		lockoutRemaining(repo.Throttle, ipFailures, repo.Throttle.MaxIPFailures, now),
+	)
+
+	if retryAfter > 0 {
+		log.Warn("Login throttled for %s from %s for %s", credentials.Email, credentials.IpAddress, retryAfter)
+		return pkg.NewTooManyRequestsError("too many failed login attempts, try again later", retryAfter)
+	}
+
+	return nil
+}
+
+func recordLoginAttempt(
+	ctx context.Context,
+	repo *LoginService,
+	credentials domain.Credentials,
+	user *domain.User,
+	succeeded bool,
+) error {
+	log := pkg.GetLogger()
+
+	if repo.LoginAttemptRepository == nil {
+		return nil
+	}
+
+	now := time.Now()
+	err := repo.LoginAttemptRepository.SaveLoginAttempt(ctx, domain.LoginAttempt{
+		Email:       throttleKey(credentials.Email),
+		IpAddress:   credentials.IpAddress,
+		Succeeded:   succeeded,
+		AttemptedAt: now,
+	})
+	if err != nil {
+		log.Error("Error saving login attempt: %v", err)
+		return err
+	}
+
+	if succeeded {
+		return nil
+	}
+
+	since := now.Add(-repo.Throttle.Window)
+
+	accountFailures, err := repo.LoginAttemptRepository.CountAccountFailures(ctx, throttleKey(credentials.Email), since)
+	if err != nil {
+		log.Error("Error counting account login failures: %v", err)
+		return err
+	}
+
+	if lockout := lockoutRemaining(repo.Throttle, accountFailures, repo.Throttle.MaxAccountFailures, now); lockout > 0 {
+		entry := domain.CreateAuditLog{
+			Action:     domain.AuditActionLoginLockout,
+			TargetType: domain.AuditTargetUser,
+			IpAddress:  credentials.IpAddress,
+			Details: map[string]any{
+				"scope":    "account",
+				"email":    credentials.Email,
+				"failures": accountFailures.Count,
+				"lockout":  lockout.String(),
+			},
+		}
+		if user != nil {
+			entry.TargetId = user.Id
+		}
+		recordLockout(ctx, repo, entry)
+	}
+
+	ipFailures, err := repo.LoginAttemptRepository.CountIPFailures(ctx, credentials.IpAddress, since)
+	if err != nil {
+		log.Error("Error counting ip login failures: %v", err)
+		return err
+	}
+
+	if lockout := lockoutRemaining(repo.Throttle, ipFailures, repo.Throttle.MaxIPFailures, now); lockout > 0 {
+		recordLockout(ctx, repo, domain.CreateAuditLog{
+			Action:     domain.AuditActionLoginLockout,
+			TargetType: domain.AuditTargetIPAddress,
+			TargetId:   credentials.IpAddress,
+			IpAddress:  credentials.IpAddress,
+			Details: map[string]any{
+				"scope":    "ip_address",
+				"failures": ipFailures.Count,
+				"lockout":  lockout.String(),
+			},
+		})
+	}
+
+	return nil
+}
+
+func recordLockout(ctx context.Context, repo *LoginService, entry domain.CreateAuditLog) {
+	log := pkg.GetLogger()
+
+	log.Warn("Login lockout (%s) for %s %s", entry.Details["scope"], entry.TargetType, entry.TargetId)
+
+	recordAudit(ctx, repo.AuditLogRepository, entry)
+}
+
+func recordLoginAudit(
+	ctx context.Context,
+	repo *LoginService,
+	action string,
+	credentials domain.Credentials,
+	user *domain.User,
+) {
+	entry := domain.CreateAuditLog{
+		Action:     action,
+		TargetType: domain.AuditTargetUser,
+		IpAddress:  credentials.IpAddress,
+		Details:    map[string]any{"email": credentials.Email},
+	}
+
+	if user != nil {
+		entry.TargetId = user.Id
+		entry.OrganisationId = user.OrganisationId
+	}
+
+	// A failed attempt is not attributed to the account it targeted.
+	if user != nil && action == domain.AuditActionLogin {
+		entry.ActorId = &user.Id
+	}
+
+	recordAudit(ctx, repo.AuditLogRepository, entry)
+}
+
+func lockoutRemaining(
+	policy domain.LoginThrottlePolicy,
+	failures *domain.LoginFailures,
+	limit int,
+	now time.Time,
+) time.Duration {
+	if limit <= 0 || failures == nil || failures.LastFailedAt == nil || failures.Count < limit {
+		return 0
+	}
+
+	lockout := policy.BaseLockout
+	for excess := failures.Count - limit; excess > 0 && lockout < policy.MaxLockout; excess-- {
+		lockout *= 2
+	}
+	lockout = min(lockout, policy.MaxLockout)
+
+	return max(failures.LastFailedAt.Add(lockout).Sub(now), 0)
+}
+
+func throttleKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func checkUserActive(user *domain.User) error {
+	if user.DeactivatedAt != nil || user.DeletedAt != nil {
+		return pkg.NewForbiddenError("user account is deactivated")
+	}
+
+	return nil
+}
+
+func checkEmailVerified(user *domain.User) error {
+	if user.EmailVerifiedAt == nil {
+		return pkg.NewForbiddenError("email address has not been verified")
+	}
+
+	return nil
+}
+
+func authenticateWithDirectory(
+	ctx context.Context,
+	repo *LoginService,
+	credentials domain.Credentials,
+) (*domain.User, error) {
+	log := pkg.GetLogger()
+
+	if repo.Directory == nil {
+		return nil, nil
+	}
+
+	directoryUser, err := repo.Directory.Authenticate(ctx, credentials.Email, credentials.Password)
+	if err != nil {
+		log.Error("Error authenticating against directory: %v", err)
+		return nil, pkg.NewInternalServerError("failed to authenticate against directory", err)
+	}
+
+	if directoryUser == nil {
+		return nil, nil
+	}
+
+	user, err := repo.UserRepository.UpsertDirectoryUser(ctx, *directoryUser)
+	if err != nil {
+		log.Error("Error saving directory user: %v", err)
+		return nil, err
 	}
 
+	log.Info("User %s authenticated against directory with role %s", user.Id, user.Role)
 	return user, nil
 }
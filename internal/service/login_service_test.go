@@ -2,20 +2,25 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
 )
 
 func TestLoginService(t *testing.T) {
 	t.Run("should login successfully", func(t *testing.T) {
+		verifiedAt := time.Now()
 		mock := &userRepo{
 			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
 				return &domain.User{
-					Email:    "test@test.com",
-					Password: "$2a$10$gG9c609HyTVVIX09MLuTAOpiXpLxJhYRFLS8lUsgxcivhHVu2Uk5.",
+					Email:           "test@test.com",
+					Password:        "$2a$10$gG9c609HyTVVIX09MLuTAOpiXpLxJhYRFLS8lUsgxcivhHVu2Uk5.",
+					EmailVerifiedAt: &verifiedAt,
 				}, nil
 			},
 		}
@@ -24,12 +29,19 @@ func TestLoginService(t *testing.T) {
 			Password: "senha",
 		}
 
+		tokens := &tokenRepo{
+			SaveRefreshTokenFunc: func(ctx context.Context, token domain.CreateRefreshToken) error {
+				return nil
+			},
+		}
+
 		context := context.Background()
 
-		userService := LoginService{UserRepository: mock}
+		userService := LoginService{UserRepository: mock, TokenRepository: tokens}
 		token, _ := userService.LoginService(context, credentials)
 
 		assert.IsType(t, "", token.Token, "should be a string")
+		assert.NotEmpty(t, token.RefreshToken, "should issue a refresh token")
 	})
 
 	t.Run("should return error to find user by email", func(t *testing.T) {
@@ -48,7 +60,7 @@ func TestLoginService(t *testing.T) {
 		userService := LoginService{UserRepository: mock}
 		_, err := userService.LoginService(context, credentials)
 
-		assert.Equal(t, "user not found", err.Error(), "should return user not found")
+		assert.Equal(t, "invalid credentials", err.Error(), "should not reveal unknown email")
 	})
 
 	t.Run("should return error to invalid password", func(t *testing.T) {
@@ -70,6 +82,363 @@ func TestLoginService(t *testing.T) {
 		userService := LoginService{UserRepository: mock}
 		_, err := userService.LoginService(context, credentials)
 
-		assert.Equal(t, "invalid password", err.Error(), "should return invalid password")
+		assert.Equal(t, "invalid credentials", err.Error(), "should return invalid credentials")
+	})
+
+	t.Run("should login with directory and provision user", func(t *testing.T) {
+		var upserted domain.DirectoryUser
+
+		mock := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return nil, nil
+			},
+			upsertDirectoryUserFunc: func(ctx context.Context, user domain.DirectoryUser) (*domain.User, error) {
+				upserted = user
+				verifiedAt := time.Now()
+				return &domain.User{Id: "user-1", Email: user.Email, Role: user.Role, EmailVerifiedAt: &verifiedAt}, nil
+			},
+		}
+		directory := &directoryMock{
+			AuthenticateFunc: func(ctx context.Context, email, password string) (*domain.DirectoryUser, error) {
+				return &domain.DirectoryUser{Email: email, Name: "Test", Role: domain.RoleApprover}, nil
+			},
+		}
+		tokens := &tokenRepo{
+			SaveRefreshTokenFunc: func(ctx context.Context, token domain.CreateRefreshToken) error {
+				return nil
+			},
+		}
+		credentials := domain.Credentials{
+			Email:    "test@test.com",
+			Password: "senha",
+		}
+
+		ctx := context.Background()
+
+		userService := LoginService{UserRepository: mock, TokenRepository: tokens, Directory: directory}
+		token, err := userService.LoginService(ctx, credentials)
+
+		assert.NoError(t, err, "should not return error")
+		assert.NotEmpty(t, token.Token, "should issue an access token")
+		assert.Equal(t, domain.RoleApprover, upserted.Role, "should sync the directory role")
+	})
+
+	t.Run("should return invalid credentials when directory rejects credentials", func(t *testing.T) {
+		mock := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return &domain.User{
+					Email:    "test@test.com",
+					Password: "senha",
+				}, nil
+			},
+		}
+		directory := &directoryMock{
+			AuthenticateFunc: func(ctx context.Context, email, password string) (*domain.DirectoryUser, error) {
+				return nil, nil
+			},
+		}
+		credentials := domain.Credentials{
+			Email:    "test@test.com",
+			Password: "senha",
+		}
+
+		ctx := context.Background()
+
+		userService := LoginService{UserRepository: mock, Directory: directory}
+		_, err := userService.LoginService(ctx, credentials)
+
+		assert.Equal(t, "invalid credentials", err.Error(), "should return invalid credentials")
+	})
+
+	t.Run("should return error when directory is unavailable", func(t *testing.T) {
+		mock := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return nil, nil
+			},
+		}
+		directory := &directoryMock{
+			AuthenticateFunc: func(ctx context.Context, email, password string) (*domain.DirectoryUser, error) {
+				return nil, errors.New("connection refused")
+			},
+		}
+		credentials := domain.Credentials{
+			Email:    "test@test.com",
+			Password: "senha",
+		}
+
+		ctx := context.Background()
+
+		userService := LoginService{UserRepository: mock, Directory: directory}
+		_, err := userService.LoginService(ctx, credentials)
+
+		assert.IsType(t, &pkg.InternalServerError{}, err, "should be internal server error")
+	})
+
+	t.Run("should reject deactivated user", func(t *testing.T) {
+		deactivatedAt := time.Now()
+		mock := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return &domain.User{
+					Email:         "test@test.com",
+					Password:      "$2a$10$gG9c609HyTVVIX09MLuTAOpiXpLxJhYRFLS8lUsgxcivhHVu2Uk5.",
+					DeactivatedAt: &deactivatedAt,
+				}, nil
+			},
+		}
+		credentials := domain.Credentials{
+			Email:    "test@test.com",
+			Password: "senha",
+		}
+
+		ctx := context.Background()
+
+		userService := LoginService{UserRepository: mock}
+		_, err := userService.LoginService(ctx, credentials)
+
+		assert.IsType(t, &pkg.ForbiddenError{}, err, "should be forbidden")
 	})
+
+	t.Run("should reject unverified email", func(t *testing.T) {
+		mock := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return &domain.User{
+					Email:    "test@test.com",
+					Password: "$2a$10$gG9c609HyTVVIX09MLuTAOpiXpLxJhYRFLS8lUsgxcivhHVu2Uk5.",
+				}, nil
+			},
+		}
+		credentials := domain.Credentials{
+			Email:    "test@test.com",
+			Password: "senha",
+		}
+
+		ctx := context.Background()
+
+		userService := LoginService{UserRepository: mock}
+		_, err := userService.LoginService(ctx, credentials)
+
+		assert.IsType(t, &pkg.ForbiddenError{}, err, "should be forbidden")
+		assert.Equal(t, "email address has not been verified", err.Error(), "should return correct message")
+	})
+
+	t.Run("should return mfa challenge when two-factor is enabled", func(t *testing.T) {
+		verifiedAt := time.Now()
+		mock := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return &domain.User{
+					Id:              "user-1",
+					Email:           "test@test.com",
+					Password:        "$2a$10$gG9c609HyTVVIX09MLuTAOpiXpLxJhYRFLS8lUsgxcivhHVu2Uk5.",
+					EmailVerifiedAt: &verifiedAt,
+				}, nil
+			},
+		}
+		mfa := &mfaRepo{
+			FindUserMFAFunc: func(ctx context.Context, userId string) (*domain.UserMFA, error) {
+				return &domain.UserMFA{UserId: userId, EnabledAt: &verifiedAt}, nil
+			},
+		}
+		credentials := domain.Credentials{
+			Email:    "test@test.com",
+			Password: "senha",
+		}
+
+		ctx := context.Background()
+
+		recorded := false
+		attempts := &loginAttemptRepo{
+			SaveLoginAttemptFunc: func(ctx context.Context, attempt domain.LoginAttempt) error {
+				recorded = true
+				return nil
+			},
+			CountAccountFailuresFunc: func(ctx context.Context, email string, since time.Time) (*domain.LoginFailures, error) {
+				return &domain.LoginFailures{}, nil
+			},
+			CountIPFailuresFunc: func(ctx context.Context, ipAddress string, since time.Time) (*domain.LoginFailures, error) {
+				return &domain.LoginFailures{}, nil
+			},
+		}
+
+		userService := LoginService{UserRepository: mock, MFARepository: mfa, LoginAttemptRepository: attempts}
+		response, err := userService.LoginService(ctx, credentials)
+
+		assert.NoError(t, err, "should not return error")
+		assert.False(t, recorded, "should not clear failures before the code is verified")
+		assert.True(t, response.MfaRequired, "should require mfa")
+		assert.Empty(t, response.Token, "should not issue an access token")
+		assert.Empty(t, response.RefreshToken, "should not issue a refresh token")
+
+		_, err = pkg.ValidateToken(response.MfaToken)
+		assert.Error(t, err, "challenge should not be usable as access token")
+	})
+}
+
+func TestLoginThrottle(t *testing.T) {
+	policy := domain.LoginThrottlePolicy{
+		MaxAccountFailures: 5,
+		MaxIPFailures:      50,
+		Window:             time.Hour,
+		BaseLockout:        30 * time.Second,
+		MaxLockout:         time.Hour,
+	}
+	credentials := domain.Credentials{
+		Email:     "Test@Test.com",
+		Password:  "wrong",
+		IpAddress: "10.0.0.5",
+	}
+
+	t.Run("should reject locked account without checking password", func(t *testing.T) {
+		lastFailedAt := time.Now().Add(-10 * time.Second)
+		attempts := &loginAttemptRepo{
+			CountAccountFailuresFunc: func(ctx context.Context, email string, since time.Time) (*domain.LoginFailures, error) {
+				assert.Equal(t, "test@test.com", email, "should normalise the email")
+				return &domain.LoginFailures{Count: 5, LastFailedAt: &lastFailedAt}, nil
+			},
+			CountIPFailuresFunc: func(ctx context.Context, ipAddress string, since time.Time) (*domain.LoginFailures, error) {
+				return &domain.LoginFailures{}, nil
+			},
+		}
+
+		ctx := context.Background()
+		userService := LoginService{UserRepository: &userRepo{}, LoginAttemptRepository: attempts, Throttle: policy}
+		_, err := userService.LoginService(ctx, credentials)
+
+		var throttled *pkg.TooManyRequestsError
+		assert.ErrorAs(t, err, &throttled, "should be too many requests")
+		assert.InDelta(t, 20*time.Second, throttled.RetryAfter, float64(time.Second), "should report remaining lockout")
+	})
+
+	t.Run("should record failure and audit lockout", func(t *testing.T) {
+		var saved []domain.LoginAttempt
+		var audited []domain.CreateAuditLog
+
+		attempts := &loginAttemptRepo{
+			SaveLoginAttemptFunc: func(ctx context.Context, attempt domain.LoginAttempt) error {
+				saved = append(saved, attempt)
+				return nil
+			},
+			CountAccountFailuresFunc: func(ctx context.Context, email string, since time.Time) (*domain.LoginFailures, error) {
+				if len(saved) == 0 {
+					return &domain.LoginFailures{Count: 4}, nil
+				}
+				lastFailedAt := saved[len(saved)-1].AttemptedAt
+				return &domain.LoginFailures{Count: 5, LastFailedAt: &lastFailedAt}, nil
+			},
+			CountIPFailuresFunc: func(ctx context.Context, ipAddress string, since time.Time) (*domain.LoginFailures, error) {
+				return &domain.LoginFailures{Count: 5}, nil
+			},
+		}
+		audit := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = append(audited, entry)
+				return nil
+			},
+		}
+		users := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return &domain.User{Id: "user-1", Email: email, Password: "$2a$10$gG9c609HyTVVIX09MLuTAOpiXpLxJhYRFLS8lUsgxcivhHVu2Uk5."}, nil
+			},
+		}
+
+		ctx := context.Background()
+		userService := LoginService{
+			UserRepository:         users,
+			LoginAttemptRepository: attempts,
+			AuditLogRepository:     audit,
+			Throttle:               policy,
+		}
+		_, err := userService.LoginService(ctx, credentials)
+
+		assert.Equal(t, "invalid credentials", err.Error(), "should return invalid credentials")
+		assert.Len(t, saved, 1, "should record the failed attempt")
+		assert.False(t, saved[0].Succeeded, "should record a failure")
+		assert.Equal(t, "10.0.0.5", saved[0].IpAddress, "should record the client ip")
+		assert.Len(t, audited, 2, "should audit the failed login and the account lockout")
+		assert.Equal(t, domain.AuditActionLoginFailed, audited[0].Action)
+		assert.Nil(t, audited[0].ActorId, "should not attribute a failed login to the user")
+		assert.Equal(t, domain.AuditActionLoginLockout, audited[1].Action)
+		assert.Equal(t, "user-1", audited[1].TargetId, "should target the locked user")
+	})
+}
+
+func TestLockoutRemaining(t *testing.T) {
+	policy := domain.LoginThrottlePolicy{BaseLockout: 30 * time.Second, MaxLockout: 10 * time.Minute}
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		failures int
+		expected time.Duration
+	}{
+		{name: "below limit", failures: 4, expected: 0},
+		{name: "at limit", failures: 5, expected: 30 * time.Second},
+		{name: "doubles per failure", failures: 7, expected: 2 * time.Minute},
+		{name: "capped", failures: 100, expected: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures := &domain.LoginFailures{Count: tt.failures, LastFailedAt: &now}
+			assert.Equal(t, tt.expected, lockoutRemaining(policy, failures, 5, now))
+		})
+	}
+}
+
+type loginAttemptRepo struct {
+	SaveLoginAttemptFunc     func(ctx context.Context, attempt domain.LoginAttempt) error
+	CountAccountFailuresFunc func(ctx context.Context, email string, since time.Time) (*domain.LoginFailures, error)
+	CountIPFailuresFunc      func(ctx context.Context, ipAddress string, since time.Time) (*domain.LoginFailures, error)
+}
+
+func (r *loginAttemptRepo) SaveLoginAttempt(ctx context.Context, attempt domain.LoginAttempt) error {
+	return r.SaveLoginAttemptFunc(ctx, attempt)
+}
+
+func (r *loginAttemptRepo) CountAccountFailures(
+	ctx context.Context,
+	email string,
+	since time.Time,
+) (*domain.LoginFailures, error) {
+	return r.CountAccountFailuresFunc(ctx, email, since)
+}
+
+func (r *loginAttemptRepo) CountIPFailures(
+	ctx context.Context,
+	ipAddress string,
+	since time.Time,
+) (*domain.LoginFailures, error) {
+	return r.CountIPFailuresFunc(ctx, ipAddress, since)
+}
+
+type auditLogRepo struct {
+	SaveAuditLogFunc        func(ctx context.Context, entry domain.CreateAuditLog) error
+	FindAuditLogsByUserFunc func(ctx context.Context, userId string) ([]domain.AuditLog, error)
+	FindAuditLogsFunc       func(ctx context.Context, filter domain.AuditLogFilter) ([]domain.AuditLog, int, error)
+}
+
+func (r *auditLogRepo) SaveAuditLog(ctx context.Context, entry domain.CreateAuditLog) error {
+	return r.SaveAuditLogFunc(ctx, entry)
+}
+
+func (r *auditLogRepo) FindAuditLogsByUser(ctx context.Context, userId string) ([]domain.AuditLog, error) {
+	return r.FindAuditLogsByUserFunc(ctx, userId)
+}
+
+func (r *auditLogRepo) FindAuditLogs(
+	ctx context.Context,
+	filter domain.AuditLogFilter,
+) ([]domain.AuditLog, int, error) {
+	return r.FindAuditLogsFunc(ctx, filter)
+}
+
+type directoryMock struct {
+	AuthenticateFunc func(ctx context.Context, email, password string) (*domain.DirectoryUser, error)
+}
+
+func (d *directoryMock) Authenticate(
+	ctx context.Context,
+	email string,
+	password string,
+) (*domain.DirectoryUser, error) {
+	return d.AuthenticateFunc(ctx, email, password)
 }
@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"math"
+	mathrand "math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const lotteryFairnessWindowDays = 56
+
+type LotteryService struct {
+	LotteryRepository  domain.LotteryRepositoryInterface
+	SiteRepository     domain.SiteRepositoryInterface
+	DeskRepository     domain.DeskRepositoryInterface
+	AuditLogRepository domain.AuditLogRepositoryInterface
+	Policy             domain.ReservationPolicy
+}
+
+func (repo *LotteryService) RequestLotteryService(
+	ctx context.Context,
+	userId string,
+	siteId string,
+	entry domain.LotteryEntry,
+) error {
+	log := pkg.GetLogger()
+
+	site, err := getLotterySite(ctx, repo, siteId)
+	if err != nil {
+		return err
+	}
+
+	date := domain.LocalDate(entry.Date, site.Location())
+
+	log.Info("Processing lottery request for site: %s on %s", siteId, date.Format("2006-01-02"))
+
+	if !time.Now().Before(lotteryCutoff(site, date)) {
+		return pkg.NewBadRequestError("lottery for this date is closed")
+	}
+
+	saved, err := repo.LotteryRepository.SaveLotteryRequest(ctx, domain.CreateLotteryRequest{
+		SiteId: site.Id,
+		UserId: userId,
+		Date:   date,
+	})
+	if err != nil {
+		log.Error("Error saving lottery request to database: %v", err)
+		return err
+	}
+
+	if !saved {
+		return pkg.NewBadRequestError("lottery request already registered")
+	}
+
+	log.Info("lottery request registered successfully")
+	return nil
+}
+
+func (repo *LotteryService) DrawLotteryService(
+	ctx context.Context,
+	adminId string,
+	siteId string,
+	date time.Time,
+) (*domain.CreateLotteryDraw, error) {
+	log := pkg.GetLogger()
+
+	site, err := getLotterySite(ctx, repo, siteId)
+	if err != nil {
+		return nil, err
+	}
+
+	date = lotteryDate(site, date)
+
+	log.Info("Processing lottery draw for site: %s on %s", siteId, date.Format("2006-01-02"))
+
+	if time.Now().Before(lotteryCutoff(site, date)) {
+		return nil, pkg.NewBadRequestError("lottery for this date is still open")
+	}
+
+	existing, err := repo.LotteryRepository.FindLotteryDraw(ctx, site.Id, date)
+	if err != nil {
+		log.Error("Error to find lottery draw: %v", err)
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, pkg.NewBadRequestError("lottery already drawn for this date")
+	}
+
+	requests, err := repo.LotteryRepository.FindLotteryRequests(ctx, site.Id, date)
+	if err != nil {
+		log.Error("Error to find lottery requests: %v", err)
+		return nil, err
+	}
+
+	desks, err := repo.DeskRepository.FindAvailableDesksBySite(ctx, site.Id, date)
+	if err != nil {
+		log.Error("Error to find available desks: %v", err)
+		return nil, err
+	}
+
+	wins, err := repo.LotteryRepository.CountRecentWins(
+		ctx,
+		site.Id,
+		date.AddDate(0, 0, -lotteryFairnessWindowDays),
+	)
+	if err != nil {
+		log.Error("Error to count lottery wins: %v", err)
+		return nil, err
+	}
+
+	seed, err := newLotterySeed()
+	if err != nil {
+		return nil, pkg.NewInternalServerError("failed to generate lottery seed", err)
+	}
+
+	draw := domain.CreateLotteryDraw{
+		SiteId:     site.Id,
+		Date:       date,
+		Seed:       seed,
+		DrawnBy:    adminId,
+		DailyLimit: repo.Policy.MaxReservationsPerDay,
+		Outcomes:   DrawLottery(seed, requests, wins, desks),
+	}
+
+	if err := repo.LotteryRepository.SaveLotteryDraw(ctx, draw); err != nil {
+		log.Error("Error saving lottery draw to database: %v", err)
+		return nil, err
+	}
+
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:    &adminId,
+		Action:     domain.AuditActionLotteryDrawn,
+		TargetType: domain.AuditTargetSite,
+		TargetId:   site.Id,
+		After: map[string]any{
+			"date":     date.Format("2006-01-02"),
+			"seed":     seed,
+			"requests": len(requests),
+			"desks":    len(desks),
+		},
+	})
+
+	log.Info("lottery drawn with seed %d for %d requests and %d desks", seed, len(requests), len(desks))
+	return &draw, nil
+}
+
+func (repo *LotteryService) GetLotteryDrawService(
+	ctx context.Context,
+	siteId string,
+	date time.Time,
+) (*domain.LotteryDraw, error) {
+	log := pkg.GetLogger()
+
+	site, err := getLotterySite(ctx, repo, siteId)
+	if err != nil {
+		return nil, err
+	}
+
+	draw, err := repo.LotteryRepository.FindLotteryDraw(ctx, site.Id, lotteryDate(site, date))
+	if err != nil {
+		log.Error("Error to find lottery draw: %v", err)
+		return nil, err
+	}
+
+	if draw == nil {
+		return nil, pkg.NewNotFoundError("lottery draw not found")
+	}
+
+	return draw, nil
+}
+
+// DrawLottery is deterministic for a given seed and input, so a published
+// draw can be replayed from its seed and the weights stored in its results.
+func DrawLottery(
+	seed int64,
+	requests []domain.LotteryRequest,
+	wins map[string]int,
+	desks []domain.Desk,
+) []domain.LotteryOutcome {
+	ordered := make([]domain.LotteryRequest, len(requests))
+	copy(ordered, requests)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].UserId < ordered[j].UserId
+	})
+
+	rng := mathrand.New(mathrand.NewPCG(uint64(seed), uint64(seed)))
+
+	type candidate struct {
+		request domain.LotteryRequest
+		weight  float64
+		key     float64
+	}
+
+	candidates := make([]candidate, 0, len(ordered))
+	for _, request := range ordered {
+		weight := 1 / float64(1+wins[request.UserId])
+		u := rng.Float64()
+		for u == 0 {
+			u = rng.Float64()
+		}
+
+		candidates = append(candidates, candidate{
+			request: request,
+			weight:  weight,
+			key:     math.Log(u) / weight,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].key > candidates[j].key
+	})
+
+	outcomes := make([]domain.LotteryOutcome, 0, len(candidates))
+	for i, c := range candidates {
+		outcome := domain.LotteryOutcome{
+			RequestId: c.request.Id,
+			UserId:    c.request.UserId,
+			Weight:    c.weight,
+		}
+
+		if i < len(desks) {
+			deskId := desks[i].Id
+			outcome.Status = domain.LotteryStatusWon
+			outcome.DeskId = &deskId
+		} else {
+			position := i - len(desks) + 1
+			outcome.Status = domain.LotteryStatusWaitlisted
+			outcome.WaitlistPosition = &position
+		}
+
+		outcomes = append(outcomes, outcome)
+	}
+
+	return outcomes
+}
+
+func checkLotteryAllocation(
+	ctx context.Context,
+	repo *ReservationService,
+	desk *domain.Desk,
+	date time.Time,
+) error {
+	log := pkg.GetLogger()
+
+	if !desk.LotteryEnabled || desk.SiteId == nil {
+		return nil
+	}
+
+	draw, err := repo.LotteryRepository.FindLotteryDraw(ctx, *desk.SiteId, date)
+	if err != nil {
+		log.Error("Error to find lottery draw: %v", err)
+		return err
+	}
+
+	if draw == nil {
+		return pkg.NewBadRequestError("desk is allocated by lottery for this date")
+	}
+
+	return nil
+}
+
+// promoteLotteryWaitlist gives a cancelled lottery desk to the next eligible
+// waitlisted colleague. Cancellations of ordinary bookings are left alone.
+func promoteLotteryWaitlist(
+	ctx context.Context,
+	repo *ReservationService,
+	actorId string,
+	desk *domain.Desk,
+	reservation *domain.Reservation,
+) {
+	log := pkg.GetLogger()
+
+	if !desk.LotteryEnabled || desk.SiteId == nil {
+		return
+	}
+
+	promoted, err := repo.LotteryRepository.PromoteLotteryWaitlist(ctx, domain.LotteryPromotion{
+		SiteId:     *desk.SiteId,
+		DeskId:     desk.Id,
+		FromUserId: reservation.UserId,
+		Date:       reservation.Date,
+		DailyLimit: repo.Policy.MaxReservationsPerDay,
+		ActorId:    actorId,
+	})
+	if err != nil {
+		log.Error("Error promoting lottery waitlist: %v", err)
+		return
+	}
+
+	if promoted == nil {
+		return
+	}
+
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:    &actorId,
+		Action:     domain.AuditActionReservationCreated,
+		TargetType: domain.AuditTargetReservation,
+		TargetId:   promoted.Id,
+		After:      reservationSnapshot(*promoted),
+	})
+
+	log.Info("Desk %s passed to waitlisted user %s", desk.Id, promoted.UserId)
+}
+
+func getLotterySite(ctx context.Context, repo *LotteryService, siteId string) (*domain.Site, error) {
+	log := pkg.GetLogger()
+
+	site, err := repo.SiteRepository.FindSiteById(ctx, siteId)
+	if err != nil {
+		log.Error("Error to find site: %v", err)
+		return nil, err
+	}
+
+	if site == nil {
+		return nil, pkg.NewNotFoundError("site not found")
+	}
+
+	if !site.LotteryEnabled {
+		return nil, pkg.NewBadRequestError("site does not allocate desks by lottery")
+	}
+
+	return site, nil
+}
+
+func lotteryCutoff(site *domain.Site, date time.Time) time.Time {
+	return domain.StartOfLocalDay(date, site.Location()).Add(-time.Duration(site.LotteryCutoffHours) * time.Hour)
+}
+
+// lotteryDate pins a calendar day to the site's zone before normalising it, so
+// a day parsed at UTC midnight is not shifted back for sites west of UTC.
+func lotteryDate(site *domain.Site, date time.Time) time.Time {
+	loc := site.Location()
+	return domain.LocalDate(domain.StartOfLocalDay(date, loc), loc)
+}
+
+func newLotterySeed() (int64, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return 0, err
+	}
+
+	return int64(binary.BigEndian.Uint64(buf[:]) >> 1), nil
+}
@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+type lotteryRepo struct {
+	SaveLotteryRequestFunc     func(ctx context.Context, request domain.CreateLotteryRequest) (bool, error)
+	FindLotteryRequestsFunc    func(ctx context.Context, siteId string, date time.Time) ([]domain.LotteryRequest, error)
+	CountRecentWinsFunc        func(ctx context.Context, siteId string, since time.Time) (map[string]int, error)
+	FindLotteryDrawFunc        func(ctx context.Context, siteId string, date time.Time) (*domain.LotteryDraw, error)
+	SaveLotteryDrawFunc        func(ctx context.Context, draw domain.CreateLotteryDraw) error
+	PromoteLotteryWaitlistFunc func(ctx context.Context, promotion domain.LotteryPromotion) (*domain.Reservation, error)
+}
+
+func (r *lotteryRepo) PromoteLotteryWaitlist(
+	ctx context.Context,
+	promotion domain.LotteryPromotion,
+) (*domain.Reservation, error) {
+	return r.PromoteLotteryWaitlistFunc(ctx, promotion)
+}
+
+func (r *lotteryRepo) SaveLotteryRequest(
+	ctx context.Context,
+	request domain.CreateLotteryRequest,
+) (bool, error) {
+	return r.SaveLotteryRequestFunc(ctx, request)
+}
+
+func (r *lotteryRepo) FindLotteryRequests(
+	ctx context.Context,
+	siteId string,
+	date time.Time,
+) ([]domain.LotteryRequest, error) {
+	return r.FindLotteryRequestsFunc(ctx, siteId, date)
+}
+
+func (r *lotteryRepo) CountRecentWins(
+	ctx context.Context,
+	siteId string,
+	since time.Time,
+) (map[string]int, error) {
+	return r.CountRecentWinsFunc(ctx, siteId, since)
+}
+
+func (r *lotteryRepo) FindLotteryDraw(
+	ctx context.Context,
+	siteId string,
+	date time.Time,
+) (*domain.LotteryDraw, error) {
+	return r.FindLotteryDrawFunc(ctx, siteId, date)
+}
+
+func (r *lotteryRepo) SaveLotteryDraw(ctx context.Context, draw domain.CreateLotteryDraw) error {
+	return r.SaveLotteryDrawFunc(ctx, draw)
+}
+
+type siteRepo struct {
+	FindSiteByIdFunc       func(ctx context.Context, id string) (*domain.Site, error)
+	UpdateSiteTimeZoneFunc func(ctx context.Context, id string, timeZone string) (bool, error)
+}
+
+func (r *siteRepo) FindSiteById(ctx context.Context, id string) (*domain.Site, error) {
+	return r.FindSiteByIdFunc(ctx, id)
+}
+
+func (r *siteRepo) UpdateSiteTimeZone(ctx context.Context, id string, timeZone string) (bool, error) {
+	return r.UpdateSiteTimeZoneFunc(ctx, id, timeZone)
+}
+
+func lotterySite(cutoffHours int) *siteRepo {
+	return &siteRepo{
+		FindSiteByIdFunc: func(ctx context.Context, id string) (*domain.Site, error) {
+			return &domain.Site{Id: id, LotteryEnabled: true, LotteryCutoffHours: cutoffHours}, nil
+		},
+	}
+}
+
+func lotteryRequests(userIds ...string) []domain.LotteryRequest {
+	requests := []domain.LotteryRequest{}
+	for _, userId := range userIds {
+		requests = append(requests, domain.LotteryRequest{Id: "req-" + userId, UserId: userId})
+	}
+	return requests
+}
+
+func TestDrawLottery(t *testing.T) {
+	requests := lotteryRequests("a", "b", "c", "d")
+	desks := []domain.Desk{{Id: "desk-1"}, {Id: "desk-2"}}
+
+	t.Run("should be reproducible from the seed", func(t *testing.T) {
+		first := DrawLottery(42, requests, map[string]int{}, desks)
+		reversed := []domain.LotteryRequest{requests[3], requests[2], requests[1], requests[0]}
+		second := DrawLottery(42, reversed, map[string]int{}, desks)
+
+		assert.Equal(t, first, second, "should produce the same results regardless of input order")
+	})
+
+	t.Run("should assign desks to winners and waitlist the rest", func(t *testing.T) {
+		outcomes := DrawLottery(7, requests, map[string]int{}, desks)
+
+		assert.Len(t, outcomes, 4, "should return one outcome per request")
+		assert.Equal(t, domain.LotteryStatusWon, outcomes[0].Status, "first should win")
+		assert.Equal(t, "desk-1", *outcomes[0].DeskId, "first should get first desk")
+		assert.Equal(t, domain.LotteryStatusWon, outcomes[1].Status, "second should win")
+		assert.Equal(t, domain.LotteryStatusWaitlisted, outcomes[2].Status, "third should be waitlisted")
+		assert.Equal(t, 1, *outcomes[2].WaitlistPosition, "third should be first in waitlist")
+		assert.Equal(t, 2, *outcomes[3].WaitlistPosition, "fourth should be second in waitlist")
+	})
+
+	t.Run("should favour users with fewer past wins", func(t *testing.T) {
+		wins := map[string]int{"a": 10, "b": 10, "c": 10}
+		dWins := 0
+
+		for seed := int64(0); seed < 200; seed++ {
+			outcomes := DrawLottery(seed, requests, wins, desks[:1])
+			if outcomes[0].UserId == "d" {
+				dWins++
+			}
+		}
+
+		assert.Greater(t, dWins, 150, "user without past wins should win most draws")
+	})
+}
+
+func TestRequestLotteryService(t *testing.T) {
+	userId := "1a162e27-45ff-4632-817a-a79e88c8f878"
+
+	t.Run("should register request before the cutoff", func(t *testing.T) {
+		var saved domain.CreateLotteryRequest
+
+		lottery := &lotteryRepo{
+			SaveLotteryRequestFunc: func(
+				ctx context.Context,
+				request domain.CreateLotteryRequest,
+			) (bool, error) {
+				saved = request
+				return true, nil
+			},
+		}
+
+		ctx := context.Background()
+		lotteryService := LotteryService{LotteryRepository: lottery, SiteRepository: lotterySite(18)}
+		entry := domain.LotteryEntry{Date: time.Now().AddDate(0, 0, 7)}
+		err := lotteryService.RequestLotteryService(ctx, userId, "site-1", entry)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, userId, saved.UserId, "should save request for the user")
+	})
+
+	t.Run("should reject request after the cutoff", func(t *testing.T) {
+		ctx := context.Background()
+		lotteryService := LotteryService{LotteryRepository: &lotteryRepo{}, SiteRepository: lotterySite(18)}
+		entry := domain.LotteryEntry{Date: time.Now()}
+		err := lotteryService.RequestLotteryService(ctx, userId, "site-1", entry)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "lottery for this date is closed", err.Error(), "should return correct message")
+	})
+
+	t.Run("should reject duplicated requests", func(t *testing.T) {
+		lottery := &lotteryRepo{
+			SaveLotteryRequestFunc: func(
+				ctx context.Context,
+				request domain.CreateLotteryRequest,
+			) (bool, error) {
+				return false, nil
+			},
+		}
+
+		ctx := context.Background()
+		lotteryService := LotteryService{LotteryRepository: lottery, SiteRepository: lotterySite(18)}
+		entry := domain.LotteryEntry{Date: time.Now().AddDate(0, 0, 7)}
+		err := lotteryService.RequestLotteryService(ctx, userId, "site-1", entry)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "lottery request already registered", err.Error(), "should return correct message")
+	})
+}
+
+func TestDrawLotteryService(t *testing.T) {
+	adminId := "5f0b5c1e-7a8e-4d43-8f0d-2b3f41a9d7c2"
+
+	t.Run("should draw and save results with seed", func(t *testing.T) {
+		var saved domain.CreateLotteryDraw
+
+		lottery := &lotteryRepo{
+			FindLotteryDrawFunc: func(
+				ctx context.Context,
+				siteId string,
+				date time.Time,
+			) (*domain.LotteryDraw, error) {
+				return nil, nil
+			},
+			FindLotteryRequestsFunc: func(
+				ctx context.Context,
+				siteId string,
+				date time.Time,
+			) ([]domain.LotteryRequest, error) {
+				return lotteryRequests("a", "b", "c"), nil
+			},
+			CountRecentWinsFunc: func(
+				ctx context.Context,
+				siteId string,
+				since time.Time,
+			) (map[string]int, error) {
+				return map[string]int{"a": 1}, nil
+			},
+			SaveLotteryDrawFunc: func(ctx context.Context, draw domain.CreateLotteryDraw) error {
+				saved = draw
+				return nil
+			},
+		}
+		desks := &deskRepo{
+			FindAvailableDesksBySiteFunc: func(
+				ctx context.Context,
+				siteId string,
+				date time.Time,
+			) ([]domain.Desk, error) {
+				return []domain.Desk{{Id: "desk-1"}}, nil
+			},
+		}
+
+		var audited domain.CreateAuditLog
+		audit := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = entry
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		lotteryService := LotteryService{
+			LotteryRepository:  lottery,
+			SiteRepository:     lotterySite(0),
+			DeskRepository:     desks,
+			AuditLogRepository: audit,
+			Policy:             domain.ReservationPolicy{MaxReservationsPerDay: 1},
+		}
+		draw, err := lotteryService.DrawLotteryService(ctx, adminId, "site-1", time.Now())
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, adminId, saved.DrawnBy, "should record who ran the draw")
+		assert.Equal(t, 1, saved.DailyLimit, "should enforce the daily limit on winners")
+		assert.Equal(t, domain.AuditActionLotteryDrawn, audited.Action, "should audit the draw")
+		assert.Equal(t, draw.Seed, audited.After.(map[string]any)["seed"], "should record the seed")
+		assert.Len(t, saved.Outcomes, 3, "should save an outcome per request")
+		assert.Equal(
+			t,
+			DrawLottery(draw.Seed, lotteryRequests("a", "b", "c"), map[string]int{"a": 1}, []domain.Desk{{Id: "desk-1"}}),
+			saved.Outcomes,
+			"results should be reproducible from the stored seed",
+		)
+	})
+
+	t.Run("should not draw before the cutoff", func(t *testing.T) {
+		ctx := context.Background()
+		lotteryService := LotteryService{LotteryRepository: &lotteryRepo{}, SiteRepository: lotterySite(18)}
+		_, err := lotteryService.DrawLotteryService(ctx, adminId, "site-1", time.Now().AddDate(0, 0, 7))
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "lottery for this date is still open", err.Error(), "should return correct message")
+	})
+}
+
+func TestCreateReservationOnLotterySite(t *testing.T) {
+	siteId := "site-1"
+	parsedTime, _ := time.Parse(time.RFC3339, "2025-06-05T00:54:07Z")
+
+	data := domain.CreateReservation{
+		DeskId: "48b8c429-be55-470f-a245-651fc3c75a6b",
+		UserId: "1a162e27-45ff-4632-817a-a79e88c8f878",
+		Date:   parsedTime,
+	}
+
+	t.Run("should reject first-come-first-served booking before the draw", func(t *testing.T) {
+		mock := &reservationRepo{
+			FindReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (*domain.Reservation, error) {
+				return nil, nil
+			},
+		}
+		desks := &deskRepo{
+			FindDeskByIdFunc: func(ctx context.Context, id string) (*domain.Desk, error) {
+				return &domain.Desk{Id: id, SiteId: &siteId, LotteryEnabled: true}, nil
+			},
+		}
+		lottery := &lotteryRepo{
+			FindLotteryDrawFunc: func(
+				ctx context.Context,
+				siteId string,
+				date time.Time,
+			) (*domain.LotteryDraw, error) {
+				return nil, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        desks,
+			LotteryRepository:     lottery,
+		}
+		err := reservation.CreateReservationService(ctx, data.UserId, data)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(
+			t,
+			"desk is allocated by lottery for this date",
+			err.Error(),
+			"should return correct message",
+		)
+	})
+}
+
+func TestLotteryCutoffAcrossDST(t *testing.T) {
+	// Lisbon moves from UTC to UTC+1 at 01:00 UTC on 30 March 2025.
+	site := &domain.Site{Id: "site-1", LotteryCutoffHours: 12, TimeZone: "Europe/Lisbon"}
+
+	t.Run("should close at local time before the transition", func(t *testing.T) {
+		date, _ := time.Parse("2006-01-02", "2025-03-30")
+
+		assert.Equal(
+			t,
+			"2025-03-29T12:00:00Z",
+			lotteryCutoff(site, date).UTC().Format(time.RFC3339),
+			"should close at noon local time",
+		)
+	})
+
+	t.Run("should close at local time after the transition", func(t *testing.T) {
+		date, _ := time.Parse("2006-01-02", "2025-03-31")
+
+		assert.Equal(
+			t,
+			"2025-03-30T11:00:00Z",
+			lotteryCutoff(site, date).UTC().Format(time.RFC3339),
+			"should close at noon local time",
+		)
+	})
+}
+
+func TestLotteryDate(t *testing.T) {
+	t.Run("should keep the requested day for sites west of UTC", func(t *testing.T) {
+		site := &domain.Site{Id: "site-1", TimeZone: "America/Sao_Paulo"}
+		date, _ := time.Parse("2006-01-02", "2025-07-10")
+
+		assert.Equal(t, date, lotteryDate(site, date), "should not shift to the previous day")
+	})
+}
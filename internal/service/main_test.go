@@ -0,0 +1,20 @@
+package service
+
+import (
+	"os"
+	"testing"
+
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+// Services sign tokens through the default key manager, which refuses to load
+// without a real SECRET_KEY; tests sign with a throwaway key instead.
+func TestMain(m *testing.M) {
+	manager, err := pkg.NewKeyManager(pkg.AlgorithmHS256, 0)
+	if err != nil {
+		panic(err)
+	}
+	pkg.SetDefaultKeyManager(manager)
+
+	os.Exit(m.Run())
+}
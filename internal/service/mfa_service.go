@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const recoveryCodeCount = 10
+
+type MFAService struct {
+	MFARepository          domain.MFARepositoryInterface
+	UserRepository         domain.UserRepositoryInterface
+	TokenRepository        domain.TokenRepositoryInterface
+	LoginAttemptRepository domain.LoginAttemptRepositoryInterface
+	AuditLogRepository     domain.AuditLogRepositoryInterface
+	Throttle               domain.LoginThrottlePolicy
+	Issuer                 string
+}
+
+func (repo *MFAService) EnrollMFAService(ctx context.Context, userId string) (*domain.MFAEnrollment, error) {
+	log := pkg.GetLogger()
+
+	user, err := repo.UserRepository.FindUserById(ctx, userId)
+	if err != nil {
+		log.Error("Error to find user by id: %v", err)
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, pkg.NewNotFoundError("user not found")
+	}
+
+	secret, err := pkg.GenerateTOTPSecret()
+	if err != nil {
+		log.Error("Error generating TOTP secret: %v", err)
+		return nil, pkg.NewInternalServerError("failed to generate mfa secret", err)
+	}
+
+	encrypted, err := pkg.EncryptSecret(secret)
+	if err != nil {
+		log.Error("Error encrypting TOTP secret: %v", err)
+		return nil, pkg.NewInternalServerError("failed to generate mfa secret", err)
+	}
+
+	saved, err := repo.MFARepository.SavePendingMFA(ctx, userId, encrypted)
+	if err != nil {
+		log.Error("Error saving pending mfa: %v", err)
+		return nil, err
+	}
+
+	if !saved {
+		return nil, pkg.NewConflictError("two-factor authentication is already enabled", nil)
+	}
+
+	uri := pkg.TOTPURI(repo.Issuer, user.Email, secret)
+
+	png, err := qrcode.Encode(uri, qrcode.Medium, 256)
+	if err != nil {
+		log.Error("Error generating QR code: %v", err)
+		return nil, pkg.NewInternalServerError("failed to generate QR code", err)
+	}
+
+	log.Info("MFA enrollment started for user: %s", userId)
+	return &domain.MFAEnrollment{
+		Secret:     secret,
+		OtpauthURI: uri,
+		QRCode:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+func (repo *MFAService) ConfirmMFAService(
+	ctx context.Context,
+	userId string,
+	data domain.ConfirmMFA,
+) (*domain.MFARecoveryCodes, error) {
+	log := pkg.GetLogger()
+
+	mfa, err := repo.MFARepository.FindUserMFA(ctx, userId)
+	if err != nil {
+		log.Error("Error to find user mfa: %v", err)
+		return nil, err
+	}
+
+	if mfa == nil {
+		return nil, pkg.NewBadRequestError("two-factor enrollment has not been started")
+	}
+
+	if mfa.EnabledAt != nil {
+		return nil, pkg.NewConflictError("two-factor authentication is already enabled", nil)
+	}
+
+	step, ok, err := validateMFACode(mfa, data.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, pkg.NewBadRequestError("invalid verification code")
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			log.Error("Error generating recovery code: %v", err)
+			return nil, pkg.NewInternalServerError("failed to generate recovery codes", err)
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, pkg.HashToken(normalizeRecoveryCode(code)))
+	}
+
+	enabled, err := repo.MFARepository.EnableMFA(ctx, userId, step, hashes)
+	if err != nil {
+		log.Error("Error enabling mfa: %v", err)
+		return nil, err
+	}
+
+	if !enabled {
+		return nil, pkg.NewConflictError("two-factor authentication is already enabled", nil)
+	}
+
+	log.Info("MFA enabled for user: %s", userId)
+	return &domain.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+func (repo *MFAService) VerifyMFALoginService(
+	ctx context.Context,
+	data domain.VerifyMFALogin,
+) (*domain.LoginResponse, error) {
+	log := pkg.GetLogger()
+
+	claims, err := pkg.ValidateMFAChallenge(data.MfaToken)
+	if err != nil {
+		return nil, pkg.NewUnauthorizedError("invalid or expired mfa token")
+	}
+
+	user, err := repo.UserRepository.FindUserById(ctx, claims.UserId)
+	if err != nil {
+		log.Error("Error to find user by id: %v", err)
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, pkg.NewUnauthorizedError("invalid or expired mfa token")
+	}
+
+	if err := checkUserActive(user); err != nil {
+		return nil, err
+	}
+
+	mfa, err := repo.MFARepository.FindUserMFA(ctx, user.Id)
+	if err != nil {
+		log.Error("Error to find user mfa: %v", err)
+		return nil, err
+	}
+
+	if mfa == nil || mfa.EnabledAt == nil {
+		return nil, pkg.NewUnauthorizedError("invalid or expired mfa token")
+	}
+
+	// Wrong codes count as failed logins, so guesses share the password throttle.
+	throttle := &LoginService{
+		LoginAttemptRepository: repo.LoginAttemptRepository,
+		AuditLogRepository:     repo.AuditLogRepository,
+		Throttle:               repo.Throttle,
+	}
+	credentials := domain.Credentials{Email: user.Email, IpAddress: data.IpAddress}
+
+	if err := checkLoginThrottle(ctx, throttle, credentials); err != nil {
+		return nil, err
+	}
+
+	verified, err := verifyMFALoginCode(ctx, repo, mfa, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !verified {
+		log.Info("Invalid MFA code for user: %s", user.Id)
+		recordLoginAudit(ctx, throttle, domain.AuditActionLoginFailed, credentials, user)
+		if err := recordLoginAttempt(ctx, throttle, credentials, user, false); err != nil {
+			return nil, err
+		}
+		return nil, pkg.NewUnauthorizedError("invalid verification code")
+	}
+
+	if err := recordLoginAttempt(ctx, throttle, credentials, user, true); err != nil {
+		return nil, err
+	}
+
+	log.Info("MFA verified for user: %s", user.Id)
+	return issueLoginTokens(ctx, repo.TokenRepository, user)
+}
+
+func (repo *MFAService) ResetMFAService(ctx context.Context, adminId string, userId string) error {
+	log := pkg.GetLogger()
+
+	user, err := repo.UserRepository.FindUserById(ctx, userId)
+	if err != nil {
+		log.Error("Error to find user by id: %v", err)
+		return err
+	}
+
+	if user == nil {
+		return pkg.NewNotFoundError("user not found")
+	}
+
+	deleted, err := repo.MFARepository.DeleteUserMFA(ctx, user.Id)
+	if err != nil {
+		log.Error("Error deleting user mfa: %v", err)
+		return err
+	}
+
+	if !deleted {
+		return pkg.NewNotFoundError("two-factor authentication is not configured for user")
+	}
+
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:    &adminId,
+		Action:     domain.AuditActionUserMFAReset,
+		TargetType: domain.AuditTargetUser,
+		TargetId:   user.Id,
+		Before:     map[string]any{"mfa_enabled": true},
+		After:      map[string]any{"mfa_enabled": false},
+	})
+
+	log.Info("MFA reset for user %s by admin %s", userId, adminId)
+	return nil
+}
+
+func verifyMFALoginCode(
+	ctx context.Context,
+	repo *MFAService,
+	mfa *domain.UserMFA,
+	data domain.VerifyMFALogin,
+) (bool, error) {
+	log := pkg.GetLogger()
+
+	if data.Code == "" {
+		used, err := repo.MFARepository.UseRecoveryCode(
+			ctx,
+			mfa.UserId,
+			pkg.HashToken(normalizeRecoveryCode(data.RecoveryCode)),
+		)
+		if err != nil {
+			log.Error("Error using recovery code: %v", err)
+			return false, err
+		}
+
+		if used {
+			log.Warn("Recovery code used by user: %s", mfa.UserId)
+		}
+		return used, nil
+	}
+
+	step, ok, err := validateMFACode(mfa, data.Code)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	updated, err := repo.MFARepository.UpdateMFALastUsedStep(ctx, mfa.UserId, step)
+	if err != nil {
+		log.Error("Error updating mfa step: %v", err)
+		return false, err
+	}
+
+	return updated, nil
+}
+
+func validateMFACode(mfa *domain.UserMFA, code string) (int64, bool, error) {
+	secret, err := pkg.DecryptSecret(mfa.Secret)
+	if err != nil {
+		pkg.GetLogger().Error("Error decrypting TOTP secret: %v", err)
+		return 0, false, pkg.NewInternalServerError("failed to read mfa secret", err)
+	}
+
+	step, ok := pkg.ValidateTOTP(secret, code, time.Now())
+	return step, ok, nil
+}
+
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
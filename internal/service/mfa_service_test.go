@@ -0,0 +1,375 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const testEncryptionKey = "test-encryption-key-0123456789abcdef"
+
+type mfaRepo struct {
+	FindUserMFAFunc           func(ctx context.Context, userId string) (*domain.UserMFA, error)
+	SavePendingMFAFunc        func(ctx context.Context, userId string, secret string) (bool, error)
+	EnableMFAFunc             func(ctx context.Context, userId string, step int64, hashes []string) (bool, error)
+	UpdateMFALastUsedStepFunc func(ctx context.Context, userId string, step int64) (bool, error)
+	UseRecoveryCodeFunc       func(ctx context.Context, userId string, codeHash string) (bool, error)
+	DeleteUserMFAFunc         func(ctx context.Context, userId string) (bool, error)
+}
+
+func (r *mfaRepo) FindUserMFA(ctx context.Context, userId string) (*domain.UserMFA, error) {
+	return r.FindUserMFAFunc(ctx, userId)
+}
+
+func (r *mfaRepo) SavePendingMFA(ctx context.Context, userId string, secret string) (bool, error) {
+	return r.SavePendingMFAFunc(ctx, userId, secret)
+}
+
+func (r *mfaRepo) EnableMFA(ctx context.Context, userId string, step int64, hashes []string) (bool, error) {
+	return r.EnableMFAFunc(ctx, userId, step, hashes)
+}
+
+func (r *mfaRepo) UpdateMFALastUsedStep(ctx context.Context, userId string, step int64) (bool, error) {
+	return r.UpdateMFALastUsedStepFunc(ctx, userId, step)
+}
+
+func (r *mfaRepo) UseRecoveryCode(ctx context.Context, userId string, codeHash string) (bool, error) {
+	return r.UseRecoveryCodeFunc(ctx, userId, codeHash)
+}
+
+func (r *mfaRepo) DeleteUserMFA(ctx context.Context, userId string) (bool, error) {
+	return r.DeleteUserMFAFunc(ctx, userId)
+}
+
+func TestEnrollMFAService(t *testing.T) {
+	t.Setenv("SECRET_ENCRYPTION_KEY", testEncryptionKey)
+
+	users := &userRepo{
+		findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+			return &domain.User{Id: id, Email: "test@test.com"}, nil
+		},
+	}
+
+	t.Run("should store encrypted secret and return otpauth uri", func(t *testing.T) {
+		var stored string
+
+		mfa := &mfaRepo{
+			SavePendingMFAFunc: func(ctx context.Context, userId string, secret string) (bool, error) {
+				stored = secret
+				return true, nil
+			},
+		}
+
+		ctx := context.Background()
+		mfaService := MFAService{MFARepository: mfa, UserRepository: users, Issuer: "Desks"}
+		enrollment, err := mfaService.EnrollMFAService(ctx, "user-1")
+
+		assert.NoError(t, err, "should not return error")
+		assert.NotEqual(t, enrollment.Secret, stored, "should not store the plain secret")
+		assert.Contains(t, enrollment.OtpauthURI, "otpauth://totp/Desks:test@test.com", "should label the account")
+		assert.Contains(t, enrollment.QRCode, "data:image/png;base64,", "should return a QR code")
+
+		secret, _ := pkg.DecryptSecret(stored)
+		assert.Equal(t, enrollment.Secret, secret, "should store the encrypted secret")
+	})
+
+	t.Run("should return conflict when already enabled", func(t *testing.T) {
+		mfa := &mfaRepo{
+			SavePendingMFAFunc: func(ctx context.Context, userId string, secret string) (bool, error) {
+				return false, nil
+			},
+		}
+
+		ctx := context.Background()
+		mfaService := MFAService{MFARepository: mfa, UserRepository: users}
+		_, err := mfaService.EnrollMFAService(ctx, "user-1")
+
+		assert.IsType(t, &pkg.ConflictError{}, err, "should be conflict")
+	})
+}
+
+func TestConfirmMFAService(t *testing.T) {
+	t.Setenv("SECRET_ENCRYPTION_KEY", testEncryptionKey)
+
+	secret, _ := pkg.GenerateTOTPSecret()
+	encrypted, _ := pkg.EncryptSecret(secret)
+
+	t.Run("should enable mfa and return hashed recovery codes", func(t *testing.T) {
+		var storedHashes []string
+
+		mfa := &mfaRepo{
+			FindUserMFAFunc: func(ctx context.Context, userId string) (*domain.UserMFA, error) {
+				return &domain.UserMFA{UserId: userId, Secret: encrypted}, nil
+			},
+			EnableMFAFunc: func(ctx context.Context, userId string, step int64, hashes []string) (bool, error) {
+				storedHashes = hashes
+				return true, nil
+			},
+		}
+		code, _ := pkg.TOTPCode(secret, pkg.TOTPStep(time.Now()))
+
+		ctx := context.Background()
+		mfaService := MFAService{MFARepository: mfa}
+		codes, err := mfaService.ConfirmMFAService(ctx, "user-1", domain.ConfirmMFA{Code: code})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Len(t, codes.RecoveryCodes, recoveryCodeCount, "should return recovery codes")
+		assert.Equal(t, pkg.HashToken(normalizeRecoveryCode(codes.RecoveryCodes[0])), storedHashes[0])
+	})
+
+	t.Run("should reject invalid code", func(t *testing.T) {
+		mfa := &mfaRepo{
+			FindUserMFAFunc: func(ctx context.Context, userId string) (*domain.UserMFA, error) {
+				return &domain.UserMFA{UserId: userId, Secret: encrypted}, nil
+			},
+		}
+		code, _ := pkg.TOTPCode(secret, pkg.TOTPStep(time.Now())+10)
+
+		ctx := context.Background()
+		mfaService := MFAService{MFARepository: mfa}
+		_, err := mfaService.ConfirmMFAService(ctx, "user-1", domain.ConfirmMFA{Code: code})
+
+		assert.IsType(t, &pkg.BadRequestError{}, err, "should be bad request")
+	})
+}
+
+func TestVerifyMFALoginService(t *testing.T) {
+	t.Setenv("SECRET_ENCRYPTION_KEY", testEncryptionKey)
+
+	secret, _ := pkg.GenerateTOTPSecret()
+	encrypted, _ := pkg.EncryptSecret(secret)
+	enabledAt := time.Now()
+	challenge, _ := pkg.GenerateMFAChallenge("user-1")
+
+	users := &userRepo{
+		findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+			return &domain.User{Id: id, Email: "test@test.com"}, nil
+		},
+	}
+	tokens := &tokenRepo{
+		SaveRefreshTokenFunc: func(ctx context.Context, token domain.CreateRefreshToken) error {
+			return nil
+		},
+	}
+
+	t.Run("should issue tokens for valid code", func(t *testing.T) {
+		mfa := &mfaRepo{
+			FindUserMFAFunc: func(ctx context.Context, userId string) (*domain.UserMFA, error) {
+				return &domain.UserMFA{UserId: userId, Secret: encrypted, EnabledAt: &enabledAt}, nil
+			},
+			UpdateMFALastUsedStepFunc: func(ctx context.Context, userId string, step int64) (bool, error) {
+				return true, nil
+			},
+		}
+		code, _ := pkg.TOTPCode(secret, pkg.TOTPStep(time.Now()))
+
+		ctx := context.Background()
+		mfaService := MFAService{MFARepository: mfa, UserRepository: users, TokenRepository: tokens}
+		response, err := mfaService.VerifyMFALoginService(ctx, domain.VerifyMFALogin{MfaToken: challenge, Code: code})
+
+		assert.NoError(t, err, "should not return error")
+		assert.NotEmpty(t, response.Token, "should issue an access token")
+		assert.NotEmpty(t, response.RefreshToken, "should issue a refresh token")
+	})
+
+	t.Run("should reject replayed code", func(t *testing.T) {
+		mfa := &mfaRepo{
+			FindUserMFAFunc: func(ctx context.Context, userId string) (*domain.UserMFA, error) {
+				return &domain.UserMFA{UserId: userId, Secret: encrypted, EnabledAt: &enabledAt}, nil
+			},
+			UpdateMFALastUsedStepFunc: func(ctx context.Context, userId string, step int64) (bool, error) {
+				return false, nil
+			},
+		}
+		code, _ := pkg.TOTPCode(secret, pkg.TOTPStep(time.Now()))
+
+		ctx := context.Background()
+		mfaService := MFAService{MFARepository: mfa, UserRepository: users, TokenRepository: tokens}
+		_, err := mfaService.VerifyMFALoginService(ctx, domain.VerifyMFALogin{MfaToken: challenge, Code: code})
+
+		assert.IsType(t, &pkg.UnauthorizedError{}, err, "should be unauthorized")
+	})
+
+	t.Run("should record wrong codes as failed logins", func(t *testing.T) {
+		attempts := []domain.LoginAttempt{}
+
+		mfa := &mfaRepo{
+			FindUserMFAFunc: func(ctx context.Context, userId string) (*domain.UserMFA, error) {
+				return &domain.UserMFA{UserId: userId, Secret: encrypted, EnabledAt: &enabledAt}, nil
+			},
+		}
+		loginAttempts := &loginAttemptRepo{
+			SaveLoginAttemptFunc: func(ctx context.Context, attempt domain.LoginAttempt) error {
+				attempts = append(attempts, attempt)
+				return nil
+			},
+			CountAccountFailuresFunc: func(ctx context.Context, email string, since time.Time) (*domain.LoginFailures, error) {
+				return &domain.LoginFailures{}, nil
+			},
+			CountIPFailuresFunc: func(ctx context.Context, ipAddress string, since time.Time) (*domain.LoginFailures, error) {
+				return &domain.LoginFailures{}, nil
+			},
+		}
+
+		ctx := context.Background()
+		mfaService := MFAService{
+			MFARepository:          mfa,
+			UserRepository:         users,
+			TokenRepository:        tokens,
+			LoginAttemptRepository: loginAttempts,
+			Throttle:               domain.LoginThrottlePolicy{MaxAccountFailures: 5, Window: time.Hour},
+		}
+		_, err := mfaService.VerifyMFALoginService(ctx, domain.VerifyMFALogin{
+			MfaToken:  challenge,
+			Code:      "000000",
+			IpAddress: "10.0.0.1",
+		})
+
+		assert.IsType(t, &pkg.UnauthorizedError{}, err, "should be unauthorized")
+		assert.Len(t, attempts, 1, "should record the attempt")
+		assert.False(t, attempts[0].Succeeded, "should record a failure")
+		assert.Equal(t, "test@test.com", attempts[0].Email, "should count against the account")
+	})
+
+	t.Run("should refuse codes while the account is locked out", func(t *testing.T) {
+		lastFailedAt := time.Now()
+
+		loginAttempts := &loginAttemptRepo{
+			CountAccountFailuresFunc: func(ctx context.Context, email string, since time.Time) (*domain.LoginFailures, error) {
+				return &domain.LoginFailures{Count: 5, LastFailedAt: &lastFailedAt}, nil
+			},
+			CountIPFailuresFunc: func(ctx context.Context, ipAddress string, since time.Time) (*domain.LoginFailures, error) {
+				return &domain.LoginFailures{}, nil
+			},
+		}
+		mfa := &mfaRepo{
+			FindUserMFAFunc: func(ctx context.Context, userId string) (*domain.UserMFA, error) {
+				return &domain.UserMFA{UserId: userId, Secret: encrypted, EnabledAt: &enabledAt}, nil
+			},
+		}
+		code, _ := pkg.TOTPCode(secret, pkg.TOTPStep(time.Now()))
+
+		ctx := context.Background()
+		mfaService := MFAService{
+			MFARepository:          mfa,
+			UserRepository:         users,
+			TokenRepository:        tokens,
+			LoginAttemptRepository: loginAttempts,
+			Throttle: domain.LoginThrottlePolicy{
+				MaxAccountFailures: 5,
+				Window:             time.Hour,
+				BaseLockout:        time.Minute,
+				MaxLockout:         time.Hour,
+			},
+		}
+		_, err := mfaService.VerifyMFALoginService(ctx, domain.VerifyMFALogin{MfaToken: challenge, Code: code})
+
+		assert.IsType(t, &pkg.TooManyRequestsError{}, err, "should be throttled")
+	})
+
+	t.Run("should accept recovery code", func(t *testing.T) {
+		var usedHash string
+
+		mfa := &mfaRepo{
+			FindUserMFAFunc: func(ctx context.Context, userId string) (*domain.UserMFA, error) {
+				return &domain.UserMFA{UserId: userId, Secret: encrypted, EnabledAt: &enabledAt}, nil
+			},
+			UseRecoveryCodeFunc: func(ctx context.Context, userId string, codeHash string) (bool, error) {
+				usedHash = codeHash
+				return true, nil
+			},
+		}
+
+		ctx := context.Background()
+		mfaService := MFAService{MFARepository: mfa, UserRepository: users, TokenRepository: tokens}
+		_, err := mfaService.VerifyMFALoginService(ctx, domain.VerifyMFALogin{
+			MfaToken:     challenge,
+			RecoveryCode: "ABCDE-FGHIJ",
+		})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, pkg.HashToken("abcdefghij"), usedHash, "should normalize recovery code")
+	})
+
+	t.Run("should reject access token as challenge", func(t *testing.T) {
+		token, _ := pkg.GenerateJWT("user-1", "test@test.com", domain.RoleUser, domain.DefaultOrganisationId)
+
+		ctx := context.Background()
+		mfaService := MFAService{UserRepository: users}
+		_, err := mfaService.VerifyMFALoginService(ctx, domain.VerifyMFALogin{MfaToken: token, Code: "123456"})
+
+		assert.IsType(t, &pkg.UnauthorizedError{}, err, "should be unauthorized")
+	})
+}
+
+func TestResetMFAService(t *testing.T) {
+	t.Run("should reset mfa and audit the admin", func(t *testing.T) {
+		var audited domain.CreateAuditLog
+
+		mfa := &mfaRepo{
+			DeleteUserMFAFunc: func(ctx context.Context, userId string) (bool, error) {
+				return true, nil
+			},
+		}
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id}, nil
+			},
+		}
+		audits := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = entry
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		mfaService := MFAService{MFARepository: mfa, UserRepository: users, AuditLogRepository: audits}
+		err := mfaService.ResetMFAService(ctx, "admin-1", "user-1")
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, domain.AuditActionUserMFAReset, audited.Action, "should audit the reset")
+		assert.Equal(t, "admin-1", *audited.ActorId, "should record the admin as actor")
+		assert.Equal(t, "user-1", audited.TargetId, "should record the target user")
+	})
+
+	t.Run("should return not found when mfa is not configured", func(t *testing.T) {
+		mfa := &mfaRepo{
+			DeleteUserMFAFunc: func(ctx context.Context, userId string) (bool, error) {
+				return false, nil
+			},
+		}
+
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id}, nil
+			},
+		}
+
+		ctx := context.Background()
+		mfaService := MFAService{MFARepository: mfa, UserRepository: users}
+		err := mfaService.ResetMFAService(ctx, "admin-1", "user-1")
+
+		assert.IsType(t, &pkg.NotFoundError{}, err, "should be not found")
+	})
+
+	t.Run("should not reset users outside the admin's tenant", func(t *testing.T) {
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return nil, nil
+			},
+		}
+
+		ctx := context.Background()
+		mfaService := MFAService{MFARepository: &mfaRepo{}, UserRepository: users}
+		err := mfaService.ResetMFAService(ctx, "admin-1", "user-in-other-tenant")
+
+		assert.IsType(t, &pkg.NotFoundError{}, err, "should be not found")
+		assert.Equal(t, "user not found", err.Error(), "should return correct message")
+	})
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type OIDCService struct {
+	UserRepository         domain.UserRepositoryInterface
+	UserIdentityRepository domain.UserIdentityRepositoryInterface
+	TokenRepository        domain.TokenRepositoryInterface
+	Provider               domain.OIDCProviderInterface
+}
+
+func (repo *OIDCService) StartOIDCLoginService() (*domain.OIDCAuthRequest, error) {
+	log := pkg.GetLogger()
+
+	values := make([]string, 3)
+	for i := range values {
+		value, err := pkg.GenerateOpaqueToken()
+		if err != nil {
+			log.Error("Error generating OIDC login parameters: %v", err)
+			return nil, pkg.NewInternalServerError("failed to start OIDC login", err)
+		}
+		values[i] = value
+	}
+
+	state, nonce, verifier := values[0], values[1], values[2]
+
+	return &domain.OIDCAuthRequest{
+		URL:      repo.Provider.AuthCodeURL(state, nonce, verifier),
+		State:    state,
+		Nonce:    nonce,
+		Verifier: verifier,
+	}, nil
+}
+
+func (repo *OIDCService) CompleteOIDCLoginService(
+	ctx context.Context,
+	data domain.OIDCCallback,
+) (*domain.LoginResponse, error) {
+	log := pkg.GetLogger()
+
+	if data.Code == "" || data.ExpectedState == "" ||
+		subtle.ConstantTimeCompare([]byte(data.State), []byte(data.ExpectedState)) != 1 {
+		log.Warn("OIDC callback with invalid state")
+		return nil, pkg.NewUnauthorizedError("invalid OIDC login state")
+	}
+
+	identity, err := repo.Provider.Exchange(ctx, data.Code, data.Verifier, data.Nonce)
+	if err != nil {
+		log.Warn("OIDC code exchange failed: %v", err)
+		return nil, pkg.NewUnauthorizedError("OIDC login failed")
+	}
+
+	user, err := findOrProvisionOIDCUser(ctx, repo, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkUserActive(user); err != nil {
+		log.Info("OIDC login attempt for deactivated user: %s", user.Id)
+		return nil, err
+	}
+
+	log.Info("User %s logged in via OIDC", user.Id)
+	return issueLoginTokens(ctx, repo.TokenRepository, user)
+}
+
+func findOrProvisionOIDCUser(
+	ctx context.Context,
+	repo *OIDCService,
+	identity *domain.OIDCIdentity,
+) (*domain.User, error) {
+	log := pkg.GetLogger()
+	provider := repo.Provider.Issuer()
+
+	user, err := repo.UserIdentityRepository.FindUserByIdentity(ctx, provider, identity.Subject)
+	if err != nil {
+		log.Error("Error to find user by identity: %v", err)
+		return nil, err
+	}
+
+	if user != nil {
+		return user, nil
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		log.Warn("OIDC identity %s has no verified email", identity.Subject)
+		return nil, pkg.NewForbiddenError("identity provider did not return a verified email")
+	}
+
+	email := identity.Email
+	link := domain.CreateUserIdentity{Provider: provider, Subject: identity.Subject}
+
+	user, err = repo.UserRepository.FindUserByEmail(ctx, email)
+	if err != nil {
+		log.Error("Error to find user by email: %v", err)
+		return nil, err
+	}
+
+	if user != nil {
+		link.UserId = user.Id
+		if err := repo.UserIdentityRepository.LinkIdentity(ctx, link); err != nil {
+			log.Error("Error to link user identity: %v", err)
+			return nil, err
+		}
+
+		log.Info("Linked OIDC identity to existing user: %s", user.Id)
+		return user, nil
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = email
+	}
+
+	user, err = repo.UserIdentityRepository.SaveUserWithIdentity(ctx, domain.CreateUser{
+		Name:  name,
+		Email: email,
+	}, link)
+	if err != nil {
+		log.Error("Error to provision OIDC user: %v", err)
+		return nil, err
+	}
+
+	log.Info("Provisioned user %s from OIDC login", user.Id)
+	return user, nil
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type oidcProviderMock struct {
+	ExchangeFunc func(ctx context.Context, code, verifier, nonce string) (*domain.OIDCIdentity, error)
+}
+
+func (p *oidcProviderMock) Issuer() string {
+	return "https://idp.example.com"
+}
+
+func (p *oidcProviderMock) AuthCodeURL(state, nonce, verifier string) string {
+	return "https://idp.example.com/authorize?state=" + state
+}
+
+func (p *oidcProviderMock) Exchange(
+	ctx context.Context,
+	code string,
+	verifier string,
+	nonce string,
+) (*domain.OIDCIdentity, error) {
+	return p.ExchangeFunc(ctx, code, verifier, nonce)
+}
+
+type userIdentityRepo struct {
+	FindUserByIdentityFunc   func(ctx context.Context, provider, subject string) (*domain.User, error)
+	LinkIdentityFunc         func(ctx context.Context, identity domain.CreateUserIdentity) error
+	SaveUserWithIdentityFunc func(ctx context.Context, user domain.CreateUser, identity domain.CreateUserIdentity) (*domain.User, error)
+}
+
+func (r *userIdentityRepo) FindUserByIdentity(ctx context.Context, provider, subject string) (*domain.User, error) {
+	return r.FindUserByIdentityFunc(ctx, provider, subject)
+}
+
+func (r *userIdentityRepo) LinkIdentity(ctx context.Context, identity domain.CreateUserIdentity) error {
+	return r.LinkIdentityFunc(ctx, identity)
+}
+
+func (r *userIdentityRepo) SaveUserWithIdentity(
+	ctx context.Context,
+	user domain.CreateUser,
+	identity domain.CreateUserIdentity,
+) (*domain.User, error) {
+	return r.SaveUserWithIdentityFunc(ctx, user, identity)
+}
+
+func TestStartOIDCLoginService(t *testing.T) {
+	t.Run("should generate distinct state, nonce and verifier", func(t *testing.T) {
+		oidcService := OIDCService{Provider: &oidcProviderMock{}}
+
+		request, err := oidcService.StartOIDCLoginService()
+
+		assert.NoError(t, err, "should not return error")
+		assert.NotEqual(t, request.State, request.Nonce, "should use distinct values")
+		assert.NotEqual(t, request.State, request.Verifier, "should use distinct values")
+		assert.Contains(t, request.URL, request.State, "should carry the state")
+	})
+}
+
+func TestCompleteOIDCLoginService(t *testing.T) {
+	callback := domain.OIDCCallback{
+		Code:          "code",
+		State:         "state",
+		ExpectedState: "state",
+		Nonce:         "nonce",
+		Verifier:      "verifier",
+	}
+	identity := &domain.OIDCIdentity{
+		Subject:       "subject-1",
+		Email:         "jane@example.com",
+		EmailVerified: true,
+		Name:          "Jane Doe",
+	}
+	provider := &oidcProviderMock{
+		ExchangeFunc: func(ctx context.Context, code, verifier, nonce string) (*domain.OIDCIdentity, error) {
+			return identity, nil
+		},
+	}
+	tokens := &tokenRepo{
+		SaveRefreshTokenFunc: func(ctx context.Context, token domain.CreateRefreshToken) error {
+			return nil
+		},
+	}
+
+	t.Run("should login user already linked to the identity", func(t *testing.T) {
+		identities := &userIdentityRepo{
+			FindUserByIdentityFunc: func(ctx context.Context, provider, subject string) (*domain.User, error) {
+				return &domain.User{Id: "user-1", Email: "jane@example.com"}, nil
+			},
+		}
+
+		ctx := context.Background()
+		oidcService := OIDCService{
+			UserIdentityRepository: identities,
+			TokenRepository:        tokens,
+			Provider:               provider,
+		}
+		response, err := oidcService.CompleteOIDCLoginService(ctx, callback)
+
+		assert.NoError(t, err, "should not return error")
+		assert.NotEmpty(t, response.Token, "should issue an access token")
+		assert.NotEmpty(t, response.RefreshToken, "should issue a refresh token")
+	})
+
+	t.Run("should link identity to existing user by email", func(t *testing.T) {
+		var linked domain.CreateUserIdentity
+
+		identities := &userIdentityRepo{
+			FindUserByIdentityFunc: func(ctx context.Context, provider, subject string) (*domain.User, error) {
+				return nil, nil
+			},
+			LinkIdentityFunc: func(ctx context.Context, identity domain.CreateUserIdentity) error {
+				linked = identity
+				return nil
+			},
+		}
+		users := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return &domain.User{Id: "user-1", Email: email}, nil
+			},
+		}
+
+		ctx := context.Background()
+		oidcService := OIDCService{
+			UserRepository:         users,
+			UserIdentityRepository: identities,
+			TokenRepository:        tokens,
+			Provider:               provider,
+		}
+		_, err := oidcService.CompleteOIDCLoginService(ctx, callback)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, domain.CreateUserIdentity{
+			UserId:   "user-1",
+			Provider: "https://idp.example.com",
+			Subject:  "subject-1",
+		}, linked, "should link the identity")
+	})
+
+	t.Run("should provision a new user on first login", func(t *testing.T) {
+		var provisioned domain.CreateUser
+
+		identities := &userIdentityRepo{
+			FindUserByIdentityFunc: func(ctx context.Context, provider, subject string) (*domain.User, error) {
+				return nil, nil
+			},
+			SaveUserWithIdentityFunc: func(
+				ctx context.Context,
+				user domain.CreateUser,
+				identity domain.CreateUserIdentity,
+			) (*domain.User, error) {
+				provisioned = user
+				return &domain.User{Id: "user-2", Name: user.Name, Email: user.Email}, nil
+			},
+		}
+		users := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return nil, nil
+			},
+		}
+
+		ctx := context.Background()
+		oidcService := OIDCService{
+			UserRepository:         users,
+			UserIdentityRepository: identities,
+			TokenRepository:        tokens,
+			Provider:               provider,
+		}
+		_, err := oidcService.CompleteOIDCLoginService(ctx, callback)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "Jane Doe", provisioned.Name, "should use the identity name")
+		assert.Empty(t, provisioned.Password, "should not set a password")
+	})
+
+	t.Run("should reject unverified email", func(t *testing.T) {
+		unverified := &oidcProviderMock{
+			ExchangeFunc: func(ctx context.Context, code, verifier, nonce string) (*domain.OIDCIdentity, error) {
+				return &domain.OIDCIdentity{Subject: "subject-1", Email: "jane@example.com"}, nil
+			},
+		}
+		identities := &userIdentityRepo{
+			FindUserByIdentityFunc: func(ctx context.Context, provider, subject string) (*domain.User, error) {
+				return nil, nil
+			},
+		}
+
+		ctx := context.Background()
+		oidcService := OIDCService{UserIdentityRepository: identities, Provider: unverified}
+		_, err := oidcService.CompleteOIDCLoginService(ctx, callback)
+
+		assert.Error(t, err, "should return erro")
+		assert.IsType(t, &pkg.ForbiddenError{}, err, "should be forbidden")
+	})
+
+	t.Run("should reject state mismatch", func(t *testing.T) {
+		data := callback
+		data.State = "forged"
+
+		ctx := context.Background()
+		oidcService := OIDCService{Provider: provider}
+		_, err := oidcService.CompleteOIDCLoginService(ctx, data)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "invalid OIDC login state", err.Error(), "should return correct message")
+	})
+
+	t.Run("should reject failed code exchange", func(t *testing.T) {
+		failing := &oidcProviderMock{
+			ExchangeFunc: func(ctx context.Context, code, verifier, nonce string) (*domain.OIDCIdentity, error) {
+				return nil, errors.New("invalid_grant")
+			},
+		}
+
+		ctx := context.Background()
+		oidcService := OIDCService{Provider: failing}
+		_, err := oidcService.CompleteOIDCLoginService(ctx, callback)
+
+		assert.Error(t, err, "should return erro")
+		assert.IsType(t, &pkg.UnauthorizedError{}, err, "should be unauthorized")
+	})
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const minPersonalInfoLength = 3
+
+func checkPasswordPolicy(
+	ctx context.Context,
+	policy domain.PasswordPolicy,
+	breached domain.BreachedPasswordCheckerInterface,
+	password string,
+	email string,
+	name string,
+) error {
+	log := pkg.GetLogger()
+
+	violations := passwordPolicyViolations(policy, password, email, name)
+
+	if breached != nil {
+		found, err := breached.IsBreached(ctx, password)
+		if err != nil {
+			log.Error("Error checking breached passwords: %v", err)
+			return pkg.NewInternalServerError("failed to check password", err)
+		}
+
+		if found {
+			violations = append(violations, passwordViolation(
+				"breached",
+				"password has appeared in a known data breach",
+			))
+		}
+	}
+
+	if len(violations) > 0 {
+		return pkg.NewValidationError("password does not meet requirements", violations)
+	}
+
+	return nil
+}
+
+func passwordPolicyViolations(
+	policy domain.PasswordPolicy,
+	password string,
+	email string,
+	name string,
+) []map[string]string {
+	violations := []map[string]string{}
+
+	if utf8.RuneCountInString(password) < policy.MinLength {
+		violations = append(violations, passwordViolation(
+			"min_length",
+			fmt.Sprintf("password must be at least %d characters long", policy.MinLength),
+		))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	if policy.RequireUppercase && !hasUpper {
+		violations = append(violations, passwordViolation("uppercase", "password must contain an uppercase letter"))
+	}
+
+	if policy.RequireLowercase && !hasLower {
+		violations = append(violations, passwordViolation("lowercase", "password must contain a lowercase letter"))
+	}
+
+	if policy.RequireDigit && !hasDigit {
+		violations = append(violations, passwordViolation("digit", "password must contain a digit"))
+	}
+
+	if policy.RequireSymbol && !hasSymbol {
+		violations = append(violations, passwordViolation("symbol", "password must contain a symbol"))
+	}
+
+	if policy.DisallowPersonalInfo && containsPersonalInfo(password, email, name) {
+		violations = append(violations, passwordViolation(
+			"personal_info",
+			"password must not contain your name or email address",
+		))
+	}
+
+	return violations
+}
+
+func containsPersonalInfo(password string, email string, name string) bool {
+	lowered := strings.ToLower(password)
+
+	separator := func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}
+
+	localPart, _, _ := strings.Cut(strings.ToLower(email), "@")
+
+	parts := strings.FieldsFunc(strings.ToLower(name), separator)
+	parts = append(parts, strings.FieldsFunc(localPart, separator)...)
+	parts = append(parts, localPart)
+
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) >= minPersonalInfoLength && strings.Contains(lowered, part) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func passwordViolation(tag string, message string) map[string]string {
+	return map[string]string{
+		"field":   "password",
+		"tag":     tag,
+		"message": message,
+	}
+}
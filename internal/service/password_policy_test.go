@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+type breachedPasswordsMock struct {
+	breached map[string]bool
+}
+
+func (b *breachedPasswordsMock) IsBreached(ctx context.Context, password string) (bool, error) {
+	return b.breached[password], nil
+}
+
+func TestPasswordPolicyViolations(t *testing.T) {
+	policy := domain.PasswordPolicy{
+		MinLength:            10,
+		RequireUppercase:     true,
+		RequireLowercase:     true,
+		RequireDigit:         true,
+		RequireSymbol:        true,
+		DisallowPersonalInfo: true,
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		expected []string
+	}{
+		{name: "valid password", password: "Tr0ub4dor&3x", expected: []string{}},
+		{name: "too short", password: "Ab1!", expected: []string{"min_length"}},
+		{name: "missing classes", password: "correcthorsebattery", expected: []string{"uppercase", "digit", "symbol"}},
+		{name: "contains name", password: "Marguerite#2025", expected: []string{"personal_info"}},
+		{name: "contains email local part", password: "Xmdupont99!x", expected: []string{"personal_info"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := passwordPolicyViolations(policy, tt.password, "m.dupont@example.com", "Marguerite Dupont")
+
+			tags := []string{}
+			for _, violation := range violations {
+				tags = append(tags, violation["tag"])
+			}
+			assert.Equal(t, tt.expected, tags)
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const (
+	exportPageSize         = 500
+	erasedUserName         = "Erased user"
+	erasedGuestName        = "Erased guest"
+	erasedEmailDomain      = "erased.invalid"
+	accountErasureTokenTTL = time.Hour
+)
+
+type PrivacyService struct {
+	UserRepository         domain.UserRepositoryInterface
+	ReservationRepository  domain.ReservationRepositoryInterface
+	AuditLogRepository     domain.AuditLogRepositoryInterface
+	OrganisationRepository domain.OrganisationRepositoryInterface
+	UserTokenRepository    domain.UserTokenRepositoryInterface
+	MailSender             domain.MailSenderInterface
+	BaseURL                string
+	Retention              domain.RetentionPolicy
+}
+
+func (repo *PrivacyService) ExportUserDataService(
+	ctx context.Context,
+	userId string,
+) (*domain.UserDataExport, error) {
+	log := pkg.GetLogger()
+
+	user, err := findPrivacyUser(ctx, repo, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations := []domain.Reservation{}
+	for offset := 0; ; offset += exportPageSize {
+		page, total, err := repo.ReservationRepository.FindReservationsByUser(ctx, userId, offset, exportPageSize)
+		if err != nil {
+			log.Error("Error finding user reservations: %v", err)
+			return nil, err
+		}
+
+		reservations = append(reservations, page...)
+		if len(page) == 0 || offset+len(page) >= total {
+			break
+		}
+	}
+
+	auditLogs, err := repo.AuditLogRepository.FindAuditLogsByUser(ctx, userId)
+	if err != nil {
+		log.Error("Error finding user audit logs: %v", err)
+		return nil, err
+	}
+
+	log.Info("Data export generated for user: %s", userId)
+
+	return &domain.UserDataExport{
+		ExportedAt:   time.Now().UTC(),
+		Profile:      user,
+		Reservations: reservations,
+		AuditLogs:    auditLogs,
+	}, nil
+}
+
+// EraseAccountService erases the caller's account once they re-enter their
+// password. Accounts without a password are mailed a confirmation link instead
+// and erased by ConfirmAccountErasureService; erased reports which happened.
+func (repo *PrivacyService) EraseAccountService(
+	ctx context.Context,
+	userId string,
+	data domain.EraseAccount,
+) (bool, error) {
+	log := pkg.GetLogger()
+
+	user, err := findPrivacyUser(ctx, repo, userId)
+	if err != nil {
+		return false, err
+	}
+
+	if user.Password == "" {
+		return false, sendAccountErasureConfirmation(ctx, repo, user)
+	}
+
+	if !pkg.CheckPasswordHash(data.Password, user.Password) {
+		log.Info("Invalid password on erasure request for user: %s", userId)
+		return false, pkg.NewBadRequestError("password is incorrect")
+	}
+
+	return true, eraseUser(ctx, repo, userId, userId)
+}
+
+func (repo *PrivacyService) ConfirmAccountErasureService(
+	ctx context.Context,
+	userId string,
+	data domain.ConfirmAccountErasure,
+) error {
+	log := pkg.GetLogger()
+
+	pending, err := repo.UserTokenRepository.FindUserToken(
+		ctx,
+		domain.UserTokenPurposeAccountErasure,
+		pkg.HashToken(data.Token),
+	)
+	if err != nil {
+		log.Error("Error finding account erasure token: %v", err)
+		return err
+	}
+
+	if pending == nil || pending.UserId != userId {
+		return pkg.NewBadRequestError("invalid or expired token")
+	}
+
+	if _, err := consumeUserToken(ctx, repo.UserTokenRepository, domain.UserTokenPurposeAccountErasure, data.Token); err != nil {
+		return err
+	}
+
+	return eraseUser(ctx, repo, userId, userId)
+}
+
+func (repo *PrivacyService) EraseUserService(ctx context.Context, actorId string, userId string) error {
+	if actorId == userId {
+		return pkg.NewBadRequestError("admins cannot erase their own account")
+	}
+
+	if _, err := findPrivacyUser(ctx, repo, userId); err != nil {
+		return err
+	}
+
+	return eraseUser(ctx, repo, actorId, userId)
+}
+
+func (repo *PrivacyService) GetReservationRetentionService(ctx context.Context) (*domain.ReservationRetention, error) {
+	organisation, err := findPrivacyOrganisation(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.reservationRetention(organisation), nil
+}
+
+func (repo *PrivacyService) UpdateReservationRetentionService(
+	ctx context.Context,
+	actorId string,
+	data domain.UpdateReservationRetention,
+) (*domain.ReservationRetention, error) {
+	log := pkg.GetLogger()
+
+	organisation, err := findPrivacyOrganisation(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	before := repo.reservationRetention(organisation)
+
+	updated, err := repo.OrganisationRepository.UpdateReservationRetention(ctx, data.RetentionDays)
+	if err != nil {
+		log.Error("Error updating reservation retention: %v", err)
+		return nil, err
+	}
+
+	if !updated {
+		return nil, pkg.NewNotFoundError("organisation not found")
+	}
+
+	organisation.ReservationRetentionDays = data.RetentionDays
+	after := repo.reservationRetention(organisation)
+
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:    &actorId,
+		Action:     domain.AuditActionRetentionChanged,
+		TargetType: domain.AuditTargetOrganisation,
+		TargetId:   organisation.Id,
+		Before:     map[string]any{"retention_days": before.RetentionDays, "overridden": before.Overridden},
+		After:      map[string]any{"retention_days": after.RetentionDays, "overridden": after.Overridden},
+	})
+
+	log.Info("Reservation retention for organisation %s set to %d days", organisation.Id, after.RetentionDays)
+	return after, nil
+}
+
+// An empty actorId marks a purge run by the scheduled retention job.
+func (repo *PrivacyService) PurgeExpiredReservationsService(
+	ctx context.Context,
+	actorId string,
+	now time.Time,
+) (*domain.ReservationPurge, error) {
+	organisation, err := findPrivacyOrganisation(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return purgeExpiredReservations(ctx, repo, actorId, repo.reservationRetention(organisation), now)
+}
+
+// PurgeAllExpiredReservationsService runs the retention purge for every
+// organisation in turn, each under its own tenant, for the scheduled job.
+func (repo *PrivacyService) PurgeAllExpiredReservationsService(ctx context.Context, now time.Time) (int, error) {
+	log := pkg.GetLogger()
+
+	organisations, err := repo.OrganisationRepository.FindOrganisations(ctx)
+	if err != nil {
+		log.Error("Error finding organisations: %v", err)
+		return 0, err
+	}
+
+	total := 0
+	for _, organisation := range organisations {
+		tenantCtx := utils.SetContextValue(ctx, utils.AuthTenantKey, organisation.Id)
+
+		purge, err := purgeExpiredReservations(tenantCtx, repo, "", repo.reservationRetention(&organisation), now)
+		if err != nil {
+			log.Error("Error purging reservations for organisation %s: %v", organisation.Id, err)
+			continue
+		}
+
+		total += purge.DeletedReservations
+	}
+
+	return total, nil
+}
+
+// The organisation's own setting wins over the server-wide default.
+func (repo *PrivacyService) reservationRetention(organisation *domain.Organisation) *domain.ReservationRetention {
+	defaultDays := int(repo.Retention.ReservationRetention / (24 * time.Hour))
+
+	if organisation.ReservationRetentionDays == nil {
+		return &domain.ReservationRetention{RetentionDays: defaultDays, DefaultRetentionDays: defaultDays}
+	}
+
+	return &domain.ReservationRetention{
+		RetentionDays:        *organisation.ReservationRetentionDays,
+		DefaultRetentionDays: defaultDays,
+		Overridden:           true,
+	}
+}
+
+func purgeExpiredReservations(
+	ctx context.Context,
+	repo *PrivacyService,
+	actorId string,
+	retention *domain.ReservationRetention,
+	now time.Time,
+) (*domain.ReservationPurge, error) {
+	log := pkg.GetLogger()
+
+	purge := &domain.ReservationPurge{RetentionDays: retention.RetentionDays}
+	if retention.RetentionDays <= 0 {
+		return purge, nil
+	}
+
+	before := now.AddDate(0, 0, -retention.RetentionDays).Truncate(24 * time.Hour)
+
+	deleted, err := repo.ReservationRepository.DeleteReservationsBefore(ctx, before)
+	if err != nil {
+		log.Error("Error purging expired reservations: %v", err)
+		return nil, err
+	}
+	purge.DeletedReservations = deleted
+
+	entry := domain.CreateAuditLog{
+		Action:     domain.AuditActionReservationsPurged,
+		TargetType: domain.AuditTargetReservation,
+		Details: map[string]any{
+			"before":               before.Format("2006-01-02"),
+			"retention_days":       retention.RetentionDays,
+			"deleted_reservations": deleted,
+		},
+	}
+	if actorId != "" {
+		entry.ActorId = &actorId
+	}
+	recordAudit(ctx, repo.AuditLogRepository, entry)
+
+	log.Info("Purged %d reservations older than %s", deleted, before.Format("2006-01-02"))
+	return purge, nil
+}
+
+func erasedUserPseudonym(userId string) domain.UserPseudonym {
+	return domain.UserPseudonym{
+		Name:             erasedUserName,
+		Email:            "erased-" + userId + "@" + erasedEmailDomain,
+		GuestName:        erasedGuestName,
+		GuestEmailDomain: erasedEmailDomain,
+	}
+}
+
+func eraseUser(ctx context.Context, repo *PrivacyService, actorId string, userId string) error {
+	log := pkg.GetLogger()
+
+	erased, err := repo.UserRepository.AnonymizeUser(ctx, userId, erasedUserPseudonym(userId))
+	if err != nil {
+		log.Error("Error anonymizing user: %v", err)
+		return err
+	}
+
+	if !erased {
+		return pkg.NewConflictError("user has already been erased", nil)
+	}
+
+	entry := domain.CreateAuditLog{
+		Action:     domain.AuditActionUserErased,
+		TargetType: domain.AuditTargetUser,
+		TargetId:   userId,
+	}
+	if actorId != userId {
+		entry.ActorId = &actorId
+	}
+
+	if err := repo.AuditLogRepository.SaveAuditLog(ctx, entry); err != nil {
+		log.Error("Error saving erasure audit log: %v", err)
+	}
+
+	log.Info("User %s erased", userId)
+	return nil
+}
+
+func sendAccountErasureConfirmation(ctx context.Context, repo *PrivacyService, user *domain.User) error {
+	log := pkg.GetLogger()
+
+	token, err := issueUserToken(ctx, repo.UserTokenRepository, user.Id, domain.UserTokenPurposeAccountErasure, accountErasureTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Use the link below to permanently erase your account. It expires in %s.\n\n"+
+			"If you did not ask for this, you can ignore this email.\n\n%s",
+		accountErasureTokenTTL,
+		accountLink(repo.BaseURL, "/confirm-erasure", token),
+	)
+
+	if err := repo.MailSender.SendMail(ctx, user.Email, "Confirm account erasure", body); err != nil {
+		log.Error("Error sending account erasure mail: %v", err)
+		return pkg.NewInternalServerError("failed to send account erasure mail", err)
+	}
+
+	log.Info("Account erasure token issued for user: %s", user.Id)
+	return nil
+}
+
+func findPrivacyUser(ctx context.Context, repo *PrivacyService, userId string) (*domain.User, error) {
+	log := pkg.GetLogger()
+
+	user, err := repo.UserRepository.FindUserById(ctx, userId)
+	if err != nil {
+		log.Error("Error to find user by id: %v", err)
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, pkg.NewNotFoundError("user not found")
+	}
+
+	return user, nil
+}
+
+func findPrivacyOrganisation(ctx context.Context, repo *PrivacyService) (*domain.Organisation, error) {
+	log := pkg.GetLogger()
+
+	organisation, err := repo.OrganisationRepository.FindOrganisation(ctx)
+	if err != nil {
+		log.Error("Error to find organisation: %v", err)
+		return nil, err
+	}
+
+	if organisation == nil {
+		return nil, pkg.NewNotFoundError("organisation not found")
+	}
+
+	return organisation, nil
+}
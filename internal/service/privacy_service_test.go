@@ -0,0 +1,451 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	"github.com/tufee/desk-reservation-go/internal/utils"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type organisationRepo struct {
+	FindOrganisationsFunc          func(ctx context.Context) ([]domain.Organisation, error)
+	FindOrganisationFunc           func(ctx context.Context) (*domain.Organisation, error)
+	UpdateReservationRetentionFunc func(ctx context.Context, days *int) (bool, error)
+}
+
+func (r *organisationRepo) FindOrganisations(ctx context.Context) ([]domain.Organisation, error) {
+	return r.FindOrganisationsFunc(ctx)
+}
+
+func (r *organisationRepo) FindOrganisation(ctx context.Context) (*domain.Organisation, error) {
+	return r.FindOrganisationFunc(ctx)
+}
+
+func (r *organisationRepo) UpdateReservationRetention(ctx context.Context, days *int) (bool, error) {
+	return r.UpdateReservationRetentionFunc(ctx, days)
+}
+
+func organisationWithRetention(days *int) *organisationRepo {
+	return &organisationRepo{
+		FindOrganisationFunc: func(ctx context.Context) (*domain.Organisation, error) {
+			return &domain.Organisation{Id: "org-a", ReservationRetentionDays: days}, nil
+		},
+	}
+}
+
+func TestExportUserDataService(t *testing.T) {
+	userId := "1a162e27-45ff-4632-817a-a79e88c8f878"
+
+	t.Run("should collect profile, every reservation page and audit entries", func(t *testing.T) {
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id, Email: "jane@example.com"}, nil
+			},
+		}
+		offsets := []int{}
+		reservations := &reservationRepo{
+			FindReservationsByUserFunc: func(
+				ctx context.Context,
+				userId string,
+				offset int,
+				limit int,
+			) ([]domain.Reservation, int, error) {
+				offsets = append(offsets, offset)
+				page := make([]domain.Reservation, min(limit, 600-offset))
+				return page, 600, nil
+			},
+		}
+		audit := &auditLogRepo{
+			FindAuditLogsByUserFunc: func(ctx context.Context, userId string) ([]domain.AuditLog, error) {
+				return []domain.AuditLog{{Action: domain.AuditActionLoginLockout}}, nil
+			},
+		}
+
+		ctx := context.Background()
+		privacyService := PrivacyService{
+			UserRepository:        users,
+			ReservationRepository: reservations,
+			AuditLogRepository:    audit,
+		}
+		export, err := privacyService.ExportUserDataService(ctx, userId)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, []int{0, 500}, offsets, "should page through reservations")
+		assert.Len(t, export.Reservations, 600, "should include every reservation")
+		assert.Len(t, export.AuditLogs, 1, "should include audit entries")
+		assert.Equal(t, "jane@example.com", export.Profile.Email, "should include profile")
+	})
+}
+
+func TestEraseAccountService(t *testing.T) {
+	userId := "1a162e27-45ff-4632-817a-a79e88c8f878"
+	hash, _ := pkg.HashPassword("Correct-horse-1")
+
+	t.Run("should pseudonymise user and record erasure", func(t *testing.T) {
+		var pseudonym domain.UserPseudonym
+		var entry domain.CreateAuditLog
+
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id, Password: hash}, nil
+			},
+			anonymizeUserFunc: func(ctx context.Context, id string, p domain.UserPseudonym) (bool, error) {
+				pseudonym = p
+				return true, nil
+			},
+		}
+		audit := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, e domain.CreateAuditLog) error {
+				entry = e
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		privacyService := PrivacyService{UserRepository: users, AuditLogRepository: audit}
+		erased, err := privacyService.EraseAccountService(ctx, userId, domain.EraseAccount{Password: "Correct-horse-1"})
+
+		assert.NoError(t, err, "should not return error")
+		assert.True(t, erased, "should erase immediately")
+		assert.Equal(t, "erased-"+userId+"@erased.invalid", pseudonym.Email, "should pseudonymise email")
+		assert.NotContains(t, pseudonym.Name, "Jane", "should pseudonymise name")
+		assert.Equal(t, "Erased guest", pseudonym.GuestName, "should pseudonymise hosted guests")
+		assert.Equal(t, domain.AuditActionUserErased, entry.Action, "should record erasure")
+		assert.Nil(t, entry.ActorId, "self erasure should not keep an actor reference")
+	})
+
+	t.Run("should reject wrong password", func(t *testing.T) {
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id, Password: hash}, nil
+			},
+		}
+
+		ctx := context.Background()
+		privacyService := PrivacyService{UserRepository: users}
+		_, err := privacyService.EraseAccountService(ctx, userId, domain.EraseAccount{Password: "wrong"})
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "password is incorrect", err.Error(), "should return correct message")
+	})
+
+	t.Run("should mail a confirmation link to accounts without a password", func(t *testing.T) {
+		var saved domain.CreateUserToken
+
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id, Email: "jane@example.com"}, nil
+			},
+			anonymizeUserFunc: func(ctx context.Context, id string, p domain.UserPseudonym) (bool, error) {
+				t.Fatal("should not erase before confirmation")
+				return false, nil
+			},
+		}
+		tokens := &userTokenRepo{
+			SaveUserTokenFunc: func(ctx context.Context, token domain.CreateUserToken) error {
+				saved = token
+				return nil
+			},
+		}
+		mailer := &mailSenderMock{}
+
+		ctx := context.Background()
+		privacyService := PrivacyService{
+			UserRepository:      users,
+			UserTokenRepository: tokens,
+			MailSender:          mailer,
+			BaseURL:             "https://desks.example.com",
+		}
+		erased, err := privacyService.EraseAccountService(ctx, userId, domain.EraseAccount{})
+
+		assert.NoError(t, err, "should not return error")
+		assert.False(t, erased, "should wait for confirmation")
+		assert.Equal(t, domain.UserTokenPurposeAccountErasure, saved.Purpose, "should use erasure purpose")
+		assert.Len(t, mailer.sent, 1, "should send one mail")
+		assert.Contains(t, mailer.sent[0], "https://desks.example.com/confirm-erasure?token=", "should link to confirmation")
+	})
+
+	t.Run("should reject already erased user", func(t *testing.T) {
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id}, nil
+			},
+			anonymizeUserFunc: func(ctx context.Context, id string, p domain.UserPseudonym) (bool, error) {
+				return false, nil
+			},
+		}
+
+		ctx := context.Background()
+		privacyService := PrivacyService{UserRepository: users}
+		err := privacyService.EraseUserService(ctx, "admin-1", userId)
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "user has already been erased", err.Error(), "should return correct message")
+	})
+}
+
+func TestConfirmAccountErasureService(t *testing.T) {
+	userId := "1a162e27-45ff-4632-817a-a79e88c8f878"
+	data := domain.ConfirmAccountErasure{Token: "token"}
+
+	t.Run("should erase the account with a valid token", func(t *testing.T) {
+		var erasedId string
+
+		users := &userRepo{
+			anonymizeUserFunc: func(ctx context.Context, id string, p domain.UserPseudonym) (bool, error) {
+				erasedId = id
+				return true, nil
+			},
+		}
+		tokens := &userTokenRepo{
+			FindUserTokenFunc: func(ctx context.Context, purpose string, tokenHash string) (*domain.UserToken, error) {
+				return &domain.UserToken{UserId: userId}, nil
+			},
+			ConsumeUserTokenFunc: func(ctx context.Context, purpose string, tokenHash string) (*domain.UserToken, error) {
+				assert.Equal(t, domain.UserTokenPurposeAccountErasure, purpose)
+				assert.Equal(t, pkg.HashToken("token"), tokenHash)
+				return &domain.UserToken{UserId: userId}, nil
+			},
+		}
+
+		audit := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, e domain.CreateAuditLog) error {
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		privacyService := PrivacyService{UserRepository: users, UserTokenRepository: tokens, AuditLogRepository: audit}
+		err := privacyService.ConfirmAccountErasureService(ctx, userId, data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, userId, erasedId, "should erase the caller")
+	})
+
+	t.Run("should reject another user's token", func(t *testing.T) {
+		tokens := &userTokenRepo{
+			FindUserTokenFunc: func(ctx context.Context, purpose string, tokenHash string) (*domain.UserToken, error) {
+				return &domain.UserToken{UserId: "someone-else"}, nil
+			},
+		}
+
+		ctx := context.Background()
+		privacyService := PrivacyService{UserTokenRepository: tokens}
+		err := privacyService.ConfirmAccountErasureService(ctx, userId, data)
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "invalid or expired token", err.Error(), "should return correct message")
+	})
+}
+
+func TestPurgeExpiredReservationsService(t *testing.T) {
+	now := time.Date(2025, 7, 8, 15, 30, 0, 0, time.UTC)
+
+	t.Run("should delete reservations before the retention cutoff", func(t *testing.T) {
+		var cutoff time.Time
+		var audited domain.CreateAuditLog
+
+		reservations := &reservationRepo{
+			DeleteReservationsBeforeFunc: func(ctx context.Context, before time.Time) (int, error) {
+				cutoff = before
+				return 12, nil
+			},
+		}
+
+		audits := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = entry
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		privacyService := PrivacyService{
+			ReservationRepository:  reservations,
+			AuditLogRepository:     audits,
+			OrganisationRepository: organisationWithRetention(nil),
+			Retention:              domain.RetentionPolicy{ReservationRetention: 365 * 24 * time.Hour},
+		}
+		purge, err := privacyService.PurgeExpiredReservationsService(ctx, "admin-1", now)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, 12, purge.DeletedReservations, "should report deleted reservations")
+		assert.Equal(t, 365, purge.RetentionDays, "should report the retention applied")
+		assert.Equal(t, time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC), cutoff, "should cut off at the start of the day")
+		assert.Equal(t, domain.AuditActionReservationsPurged, audited.Action, "should audit the purge")
+		assert.Equal(t, "admin-1", *audited.ActorId, "should record the admin as actor")
+		assert.Equal(t, 12, audited.Details["deleted_reservations"], "should record deleted reservations")
+	})
+
+	t.Run("should keep reservations when retention is disabled", func(t *testing.T) {
+		ctx := context.Background()
+		privacyService := PrivacyService{
+			ReservationRepository:  &reservationRepo{},
+			OrganisationRepository: organisationWithRetention(nil),
+		}
+		purge, err := privacyService.PurgeExpiredReservationsService(ctx, "admin-1", now)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, 0, purge.DeletedReservations, "should not delete anything")
+	})
+
+	t.Run("should prefer the organisation retention over the default", func(t *testing.T) {
+		var cutoff time.Time
+		days := 30
+
+		reservations := &reservationRepo{
+			DeleteReservationsBeforeFunc: func(ctx context.Context, before time.Time) (int, error) {
+				cutoff = before
+				return 3, nil
+			},
+		}
+
+		ctx := context.Background()
+		privacyService := PrivacyService{
+			ReservationRepository:  reservations,
+			AuditLogRepository:     &auditLogRepo{SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error { return nil }},
+			OrganisationRepository: organisationWithRetention(&days),
+		}
+		purge, err := privacyService.PurgeExpiredReservationsService(ctx, "admin-1", now)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, 30, purge.RetentionDays, "should apply the organisation retention")
+		assert.Equal(t, time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC), cutoff, "should cut off 30 days back")
+	})
+}
+
+func TestPurgeAllExpiredReservationsService(t *testing.T) {
+	now := time.Date(2025, 7, 8, 15, 30, 0, 0, time.UTC)
+
+	t.Run("should purge each organisation under its own tenant", func(t *testing.T) {
+		tenants := []string{}
+		keepForever := 0
+
+		organisations := &organisationRepo{
+			FindOrganisationsFunc: func(ctx context.Context) ([]domain.Organisation, error) {
+				return []domain.Organisation{{Id: "org-a"}, {Id: "org-b"}, {Id: "org-c", ReservationRetentionDays: &keepForever}}, nil
+			},
+		}
+		reservations := &reservationRepo{
+			DeleteReservationsBeforeFunc: func(ctx context.Context, before time.Time) (int, error) {
+				tenantId, _ := utils.GetContextValue[string](ctx, utils.AuthTenantKey)
+				tenants = append(tenants, tenantId)
+				return 2, nil
+			},
+		}
+
+		ctx := context.Background()
+		privacyService := PrivacyService{
+			ReservationRepository:  reservations,
+			OrganisationRepository: organisations,
+			Retention:              domain.RetentionPolicy{ReservationRetention: 365 * 24 * time.Hour},
+		}
+		deleted, err := privacyService.PurgeAllExpiredReservationsService(ctx, now)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, 4, deleted, "should sum deletions across organisations")
+		assert.Equal(t, []string{"org-a", "org-b"}, tenants, "should scope each purge to one tenant and skip opted-out ones")
+	})
+
+	t.Run("should purge organisations that opted in without a default", func(t *testing.T) {
+		days := 90
+		purged := 0
+
+		organisations := &organisationRepo{
+			FindOrganisationsFunc: func(ctx context.Context) ([]domain.Organisation, error) {
+				return []domain.Organisation{{Id: "org-a"}, {Id: "org-b", ReservationRetentionDays: &days}}, nil
+			},
+		}
+		reservations := &reservationRepo{
+			DeleteReservationsBeforeFunc: func(ctx context.Context, before time.Time) (int, error) {
+				purged++
+				return 5, nil
+			},
+		}
+
+		ctx := context.Background()
+		privacyService := PrivacyService{ReservationRepository: reservations, OrganisationRepository: organisations}
+		deleted, err := privacyService.PurgeAllExpiredReservationsService(ctx, now)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, 1, purged, "should only purge the organisation with retention")
+		assert.Equal(t, 5, deleted, "should report deleted reservations")
+	})
+}
+
+func TestUpdateReservationRetentionService(t *testing.T) {
+	t.Run("should store the override and audit the change", func(t *testing.T) {
+		var stored *int
+		var audited domain.CreateAuditLog
+		days := 30
+
+		organisations := organisationWithRetention(nil)
+		organisations.UpdateReservationRetentionFunc = func(ctx context.Context, days *int) (bool, error) {
+			stored = days
+			return true, nil
+		}
+		audits := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = entry
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		privacyService := PrivacyService{
+			OrganisationRepository: organisations,
+			AuditLogRepository:     audits,
+			Retention:              domain.RetentionPolicy{ReservationRetention: 365 * 24 * time.Hour},
+		}
+		retention, err := privacyService.UpdateReservationRetentionService(ctx, "admin-1", domain.UpdateReservationRetention{RetentionDays: &days})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, 30, *stored, "should store the override")
+		assert.Equal(t, 30, retention.RetentionDays, "should return the new retention")
+		assert.Equal(t, 365, retention.DefaultRetentionDays, "should return the default")
+		assert.True(t, retention.Overridden, "should mark the override")
+		assert.Equal(t, domain.AuditActionRetentionChanged, audited.Action, "should audit the change")
+		assert.Equal(t, 365, audited.Before.(map[string]any)["retention_days"], "should record the previous retention")
+	})
+
+	t.Run("should restore the default when cleared", func(t *testing.T) {
+		days := 30
+
+		organisations := organisationWithRetention(&days)
+		organisations.UpdateReservationRetentionFunc = func(ctx context.Context, days *int) (bool, error) {
+			return true, nil
+		}
+
+		ctx := context.Background()
+		privacyService := PrivacyService{
+			OrganisationRepository: organisations,
+			AuditLogRepository:     &auditLogRepo{SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error { return nil }},
+			Retention:              domain.RetentionPolicy{ReservationRetention: 365 * 24 * time.Hour},
+		}
+		retention, err := privacyService.UpdateReservationRetentionService(ctx, "admin-1", domain.UpdateReservationRetention{})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, 365, retention.RetentionDays, "should fall back to the default")
+		assert.False(t, retention.Overridden, "should drop the override")
+	})
+
+	t.Run("should return not found when the organisation is missing", func(t *testing.T) {
+		organisations := &organisationRepo{
+			FindOrganisationFunc: func(ctx context.Context) (*domain.Organisation, error) {
+				return nil, nil
+			},
+		}
+
+		ctx := context.Background()
+		privacyService := PrivacyService{OrganisationRepository: organisations}
+		_, err := privacyService.UpdateReservationRetentionService(ctx, "admin-1", domain.UpdateReservationRetention{})
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "organisation not found", err.Error(), "should return correct message")
+	})
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type ProfileService struct {
+	UserRepository    domain.UserRepositoryInterface
+	SiteRepository    domain.SiteRepositoryInterface
+	DeskRepository    domain.DeskRepositoryInterface
+	PasswordPolicy    domain.PasswordPolicy
+	BreachedPasswords domain.BreachedPasswordCheckerInterface
+}
+
+func (repo *ProfileService) GetProfileService(ctx context.Context, userId string) (*domain.User, error) {
+	return findProfileUser(ctx, repo, userId)
+}
+
+func (repo *ProfileService) UpdateProfileService(
+	ctx context.Context,
+	userId string,
+	data domain.UpdateProfile,
+) (*domain.User, error) {
+	log := pkg.GetLogger()
+
+	user, err := findProfileUser(ctx, repo, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := buildUserProfile(user, data)
+
+	if err := validateUserProfile(ctx, repo, profile); err != nil {
+		return nil, err
+	}
+
+	updated, err := repo.UserRepository.UpdateUserProfile(ctx, userId, profile)
+	if err != nil {
+		log.Error("Error updating user profile: %v", err)
+		return nil, err
+	}
+
+	if updated == nil {
+		return nil, pkg.NewNotFoundError("user not found")
+	}
+
+	log.Info("Profile updated for user: %s", userId)
+	return updated, nil
+}
+
+func (repo *ProfileService) ChangePasswordService(
+	ctx context.Context,
+	userId string,
+	data domain.ChangePassword,
+) error {
+	log := pkg.GetLogger()
+
+	user, err := findProfileUser(ctx, repo, userId)
+	if err != nil {
+		return err
+	}
+
+	if user.Password == "" {
+		return pkg.NewBadRequestError("account has no local password")
+	}
+
+	if !pkg.CheckPasswordHash(data.CurrentPassword, user.Password) {
+		log.Info("Invalid current password for user: %s", userId)
+		return pkg.NewBadRequestError("current password is incorrect")
+	}
+
+	err = checkPasswordPolicy(ctx, repo.PasswordPolicy, repo.BreachedPasswords, data.Password, user.Email, user.Name)
+	if err != nil {
+		log.Info("Password rejected by policy for user: %s", userId)
+		return err
+	}
+
+	hashedPassword, err := pkg.HashPassword(data.Password)
+	if err != nil {
+		return pkg.NewInternalServerError("error processing user data", err)
+	}
+
+	if err := repo.UserRepository.UpdateUserPassword(ctx, userId, hashedPassword); err != nil {
+		log.Error("Error updating user password: %v", err)
+		return err
+	}
+
+	log.Info("Password changed for user: %s", userId)
+	return nil
+}
+
+func findProfileUser(ctx context.Context, repo *ProfileService, userId string) (*domain.User, error) {
+	log := pkg.GetLogger()
+
+	user, err := repo.UserRepository.FindUserById(ctx, userId)
+	if err != nil {
+		log.Error("Error to find user by id: %v", err)
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, pkg.NewNotFoundError("user not found")
+	}
+
+	return user, nil
+}
+
+func buildUserProfile(user *domain.User, data domain.UpdateProfile) domain.UserProfile {
+	profile := domain.UserProfile{
+		Name:                    user.Name,
+		PreferredSiteId:         user.PreferredSiteId,
+		DefaultDeskId:           user.DefaultDeskId,
+		TimeZone:                user.TimeZone,
+		NotificationPreferences: user.NotificationPreferences,
+	}
+
+	if data.Name != nil {
+		profile.Name = *data.Name
+	}
+
+	if data.PreferredSiteId != nil {
+		profile.PreferredSiteId = optionalId(*data.PreferredSiteId)
+	}
+
+	if data.DefaultDeskId != nil {
+		profile.DefaultDeskId = optionalId(*data.DefaultDeskId)
+	}
+
+	if data.TimeZone != nil {
+		profile.TimeZone = *data.TimeZone
+	}
+
+	if data.NotificationPreferences != nil {
+		profile.NotificationPreferences = *data.NotificationPreferences
+	}
+
+	return profile
+}
+
+func validateUserProfile(ctx context.Context, repo *ProfileService, profile domain.UserProfile) error {
+	log := pkg.GetLogger()
+
+	if err := validateTimeZone(profile.TimeZone); err != nil {
+		return err
+	}
+
+	if profile.PreferredSiteId != nil {
+		site, err := repo.SiteRepository.FindSiteById(ctx, *profile.PreferredSiteId)
+		if err != nil {
+			log.Error("Error to find site by id: %v", err)
+			return err
+		}
+
+		if site == nil {
+			return pkg.NewBadRequestError("preferred site not found")
+		}
+	}
+
+	if profile.DefaultDeskId != nil {
+		desk, err := repo.DeskRepository.FindDeskById(ctx, *profile.DefaultDeskId)
+		if err != nil {
+			log.Error("Error to find desk by id: %v", err)
+			return err
+		}
+
+		if desk == nil {
+			return pkg.NewBadRequestError("default desk not found")
+		}
+
+		if profile.PreferredSiteId != nil && desk.SiteId != nil && *desk.SiteId != *profile.PreferredSiteId {
+			return pkg.NewBadRequestError("default desk is not located at the preferred site")
+		}
+	}
+
+	return nil
+}
+
+func optionalId(id string) *string {
+	if id == "" {
+		return nil
+	}
+
+	return &id
+}
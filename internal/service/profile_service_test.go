@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func profileUser() *domain.User {
+	siteId := "site-1"
+	deskId := "desk-1"
+
+	return &domain.User{
+		Id:              "user-1",
+		Name:            "Jane Doe",
+		Email:           "jane@example.com",
+		Password:        "$2a$10$gG9c609HyTVVIX09MLuTAOpiXpLxJhYRFLS8lUsgxcivhHVu2Uk5.",
+		PreferredSiteId: &siteId,
+		DefaultDeskId:   &deskId,
+		TimeZone:        "UTC",
+		NotificationPreferences: domain.NotificationPreferences{
+			ReservationUpdates: true,
+			ApprovalRequests:   true,
+			Reminders:          true,
+		},
+	}
+}
+
+func TestGetProfileService(t *testing.T) {
+	t.Run("should never serialise the password hash", func(t *testing.T) {
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return profileUser(), nil
+			},
+		}
+
+		ctx := context.Background()
+		profileService := ProfileService{UserRepository: users}
+		user, err := profileService.GetProfileService(ctx, "user-1")
+
+		assert.NoError(t, err, "should not return error")
+
+		body, _ := json.Marshal(user)
+		assert.NotContains(t, string(body), "password", "should not expose the password")
+		assert.NotContains(t, string(body), user.Password, "should not expose the hash")
+	})
+}
+
+func TestUpdateProfileService(t *testing.T) {
+	sites := &siteRepo{
+		FindSiteByIdFunc: func(ctx context.Context, id string) (*domain.Site, error) {
+			return &domain.Site{Id: id}, nil
+		},
+	}
+
+	t.Run("should merge changes and clear default desk", func(t *testing.T) {
+		var saved domain.UserProfile
+
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return profileUser(), nil
+			},
+			updateUserProfileFunc: func(ctx context.Context, id string, profile domain.UserProfile) (*domain.User, error) {
+				saved = profile
+				return &domain.User{Id: id, Name: profile.Name, TimeZone: profile.TimeZone}, nil
+			},
+		}
+		timeZone := "America/Sao_Paulo"
+		noDesk := ""
+
+		ctx := context.Background()
+		profileService := ProfileService{UserRepository: users, SiteRepository: sites}
+		_, err := profileService.UpdateProfileService(ctx, "user-1", domain.UpdateProfile{
+			TimeZone:      &timeZone,
+			DefaultDeskId: &noDesk,
+		})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "Jane Doe", saved.Name, "should keep unchanged fields")
+		assert.Equal(t, "site-1", *saved.PreferredSiteId, "should keep preferred site")
+		assert.Nil(t, saved.DefaultDeskId, "should clear default desk")
+		assert.Equal(t, "America/Sao_Paulo", saved.TimeZone, "should update time zone")
+		assert.True(t, saved.NotificationPreferences.Reminders, "should keep notification preferences")
+	})
+
+	t.Run("should reject unknown time zone", func(t *testing.T) {
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return profileUser(), nil
+			},
+		}
+		timeZone := "Mars/Olympus_Mons"
+
+		ctx := context.Background()
+		profileService := ProfileService{UserRepository: users, SiteRepository: sites}
+		_, err := profileService.UpdateProfileService(ctx, "user-1", domain.UpdateProfile{TimeZone: &timeZone})
+
+		assert.Equal(t, "invalid time zone", err.Error(), "should return correct message")
+	})
+
+	t.Run("should reject default desk at another site", func(t *testing.T) {
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return profileUser(), nil
+			},
+		}
+		desks := &deskRepo{
+			FindDeskByIdFunc: func(ctx context.Context, id string) (*domain.Desk, error) {
+				otherSite := "site-2"
+				return &domain.Desk{Id: id, SiteId: &otherSite}, nil
+			},
+		}
+		deskId := "desk-2"
+
+		ctx := context.Background()
+		profileService := ProfileService{UserRepository: users, SiteRepository: sites, DeskRepository: desks}
+		_, err := profileService.UpdateProfileService(ctx, "user-1", domain.UpdateProfile{DefaultDeskId: &deskId})
+
+		assert.IsType(t, &pkg.BadRequestError{}, err, "should be bad request")
+		assert.Equal(t, "default desk is not located at the preferred site", err.Error())
+	})
+}
+
+func TestChangePasswordService(t *testing.T) {
+	users := &userRepo{
+		findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+			return profileUser(), nil
+		},
+	}
+
+	t.Run("should require the current password", func(t *testing.T) {
+		ctx := context.Background()
+		profileService := ProfileService{UserRepository: users}
+		err := profileService.ChangePasswordService(ctx, "user-1", domain.ChangePassword{
+			CurrentPassword:      "wrong",
+			Password:             "N3w-Passphrase!",
+			PasswordConfirmation: "N3w-Passphrase!",
+		})
+
+		assert.Equal(t, "current password is incorrect", err.Error(), "should return correct message")
+	})
+
+	t.Run("should store new password hash", func(t *testing.T) {
+		var updatedHash string
+
+		users := &userRepo{
+			findUserByIdFunc: users.findUserByIdFunc,
+			updateUserPasswordFunc: func(ctx context.Context, id string, password string) error {
+				updatedHash = password
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		profileService := ProfileService{UserRepository: users, PasswordPolicy: domain.PasswordPolicy{MinLength: 10}}
+		err := profileService.ChangePasswordService(ctx, "user-1", domain.ChangePassword{
+			CurrentPassword:      "senha",
+			Password:             "N3w-Passphrase!",
+			PasswordConfirmation: "N3w-Passphrase!",
+		})
+
+		assert.NoError(t, err, "should not return error")
+		assert.True(t, pkg.CheckPasswordHash("N3w-Passphrase!", updatedHash), "should hash the new password")
+	})
+}
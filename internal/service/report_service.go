@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type ReportService struct {
+	ReportRepository domain.ReportRepositoryInterface
+}
+
+func (repo *ReportService) OccupancyReportService(
+	ctx context.Context,
+	query domain.ReportQuery,
+) ([]domain.OccupancyRow, error) {
+	log := pkg.GetLogger()
+
+	if err := validateReportQuery(&query); err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.ReportRepository.FindOccupancy(ctx, query)
+	if err != nil {
+		log.Error("Error building occupancy report: %v", err)
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (repo *ReportService) CheckInReportService(
+	ctx context.Context,
+	query domain.ReportQuery,
+) ([]domain.CheckInRateRow, error) {
+	log := pkg.GetLogger()
+
+	if err := validateReportQuery(&query); err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.ReportRepository.FindCheckInRates(ctx, query)
+	if err != nil {
+		log.Error("Error building check-in report: %v", err)
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (repo *ReportService) WeekdayReportService(
+	ctx context.Context,
+	query domain.ReportQuery,
+) ([]domain.WeekdayPeakRow, error) {
+	log := pkg.GetLogger()
+
+	if err := validateReportQuery(&query); err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.ReportRepository.FindWeekdayPeaks(ctx, query)
+	if err != nil {
+		log.Error("Error building weekday report: %v", err)
+		return nil, err
+	}
+
+	for i := range rows {
+		rows[i].Name = time.Weekday(rows[i].Weekday % 7).String()
+	}
+
+	return rows, nil
+}
+
+func (repo *ReportService) LeadTimeReportService(
+	ctx context.Context,
+	query domain.ReportQuery,
+) ([]domain.LeadTimeRow, error) {
+	log := pkg.GetLogger()
+
+	if err := validateReportQuery(&query); err != nil {
+		return nil, err
+	}
+
+	counts, err := repo.ReportRepository.FindLeadTimes(ctx, query, domain.LeadTimeThresholds)
+	if err != nil {
+		log.Error("Error building lead time report: %v", err)
+		return nil, err
+	}
+
+	rows := leadTimeBuckets(domain.LeadTimeThresholds)
+
+	total := 0
+	for _, count := range counts {
+		if count.Bucket >= 0 && count.Bucket < len(rows) {
+			rows[count.Bucket].Bookings = count.Bookings
+			total += count.Bookings
+		}
+	}
+
+	if total > 0 {
+		for i := range rows {
+			rows[i].Share = float64(rows[i].Bookings) / float64(total)
+		}
+	}
+
+	return rows, nil
+}
+
+func validateReportQuery(query *domain.ReportQuery) error {
+	if query.GroupBy == "" {
+		query.GroupBy = domain.ReportGroupSite
+	}
+
+	if query.Period == "" {
+		query.Period = domain.ReportPeriodDay
+	}
+
+	groups := []string{domain.ReportGroupDesk, domain.ReportGroupZone, domain.ReportGroupSite}
+	if !slices.Contains(groups, query.GroupBy) {
+		return pkg.NewBadRequestError("invalid group_by, expected desk, zone or site")
+	}
+
+	if query.Period != domain.ReportPeriodDay && query.Period != domain.ReportPeriodWeek {
+		return pkg.NewBadRequestError("invalid period, expected day or week")
+	}
+
+	if query.To.Before(query.From) {
+		return pkg.NewBadRequestError("from must not be after to")
+	}
+
+	if query.To.Sub(query.From) >= domain.MaxReportDays*24*time.Hour {
+		return pkg.NewBadRequestError(fmt.Sprintf("date range cannot exceed %d days", domain.MaxReportDays))
+	}
+
+	return nil
+}
+
+func leadTimeBuckets(thresholds []int) []domain.LeadTimeRow {
+	rows := []domain.LeadTimeRow{}
+
+	for i := 0; i <= len(thresholds); i++ {
+		row := domain.LeadTimeRow{Bucket: i}
+
+		if i > 0 {
+			row.MinDays = thresholds[i-1]
+		}
+
+		if i < len(thresholds) {
+			maxDays := thresholds[i] - 1
+			row.MaxDays = &maxDays
+		}
+
+		row.Label = leadTimeLabel(row.MinDays, row.MaxDays)
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func leadTimeLabel(minDays int, maxDays *int) string {
+	switch {
+	case maxDays == nil:
+		return fmt.Sprintf("%d+ days", minDays)
+	case *maxDays == 0:
+		return "same day"
+	case *maxDays == 1 && minDays == 1:
+		return "1 day"
+	case *maxDays == minDays:
+		return fmt.Sprintf("%d days", minDays)
+	default:
+		return fmt.Sprintf("%d-%d days", minDays, *maxDays)
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+type reportRepo struct {
+	FindOccupancyFunc    func(ctx context.Context, query domain.ReportQuery) ([]domain.OccupancyRow, error)
+	FindCheckInRatesFunc func(ctx context.Context, query domain.ReportQuery) ([]domain.CheckInRateRow, error)
+	FindWeekdayPeaksFunc func(ctx context.Context, query domain.ReportQuery) ([]domain.WeekdayPeakRow, error)
+	FindLeadTimesFunc    func(ctx context.Context, query domain.ReportQuery, thresholds []int) ([]domain.LeadTimeRow, error)
+}
+
+func (r *reportRepo) FindOccupancy(ctx context.Context, query domain.ReportQuery) ([]domain.OccupancyRow, error) {
+	return r.FindOccupancyFunc(ctx, query)
+}
+
+func (r *reportRepo) FindCheckInRates(ctx context.Context, query domain.ReportQuery) ([]domain.CheckInRateRow, error) {
+	return r.FindCheckInRatesFunc(ctx, query)
+}
+
+func (r *reportRepo) FindWeekdayPeaks(ctx context.Context, query domain.ReportQuery) ([]domain.WeekdayPeakRow, error) {
+	return r.FindWeekdayPeaksFunc(ctx, query)
+}
+
+func (r *reportRepo) FindLeadTimes(
+	ctx context.Context,
+	query domain.ReportQuery,
+	thresholds []int,
+) ([]domain.LeadTimeRow, error) {
+	return r.FindLeadTimesFunc(ctx, query, thresholds)
+}
+
+func TestOccupancyReportService(t *testing.T) {
+	from, _ := time.Parse(time.DateOnly, "2025-07-01")
+
+	t.Run("should default to daily occupancy per site", func(t *testing.T) {
+		var query domain.ReportQuery
+
+		mock := &reportRepo{
+			FindOccupancyFunc: func(ctx context.Context, q domain.ReportQuery) ([]domain.OccupancyRow, error) {
+				query = q
+				return []domain.OccupancyRow{{Desks: 10, DeskDays: 10, BookedDeskDays: 4, Occupancy: 0.4}}, nil
+			},
+		}
+
+		ctx := context.Background()
+		reportService := ReportService{ReportRepository: mock}
+		rows, err := reportService.OccupancyReportService(ctx, domain.ReportQuery{From: from, To: from})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, domain.ReportGroupSite, query.GroupBy, "should group by site")
+		assert.Equal(t, domain.ReportPeriodDay, query.Period, "should report per day")
+		assert.Len(t, rows, 1, "should return the rows")
+	})
+
+	t.Run("should reject unknown grouping", func(t *testing.T) {
+		ctx := context.Background()
+		reportService := ReportService{ReportRepository: &reportRepo{}}
+		_, err := reportService.OccupancyReportService(ctx, domain.ReportQuery{From: from, To: from, GroupBy: "floor"})
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "invalid group_by, expected desk, zone or site", err.Error(), "should return correct message")
+	})
+
+	t.Run("should reject ranges over a year", func(t *testing.T) {
+		ctx := context.Background()
+		reportService := ReportService{ReportRepository: &reportRepo{}}
+		_, err := reportService.OccupancyReportService(ctx, domain.ReportQuery{From: from, To: from.AddDate(0, 0, 366)})
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "date range cannot exceed 366 days", err.Error(), "should return correct message")
+	})
+
+	t.Run("should reject inverted ranges", func(t *testing.T) {
+		ctx := context.Background()
+		reportService := ReportService{ReportRepository: &reportRepo{}}
+		_, err := reportService.OccupancyReportService(ctx, domain.ReportQuery{From: from, To: from.AddDate(0, 0, -1)})
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "from must not be after to", err.Error(), "should return correct message")
+	})
+}
+
+func TestWeekdayReportService(t *testing.T) {
+	mock := &reportRepo{
+		FindWeekdayPeaksFunc: func(ctx context.Context, q domain.ReportQuery) ([]domain.WeekdayPeakRow, error) {
+			return []domain.WeekdayPeakRow{{Weekday: 1, Bookings: 12}, {Weekday: 7, Bookings: 1}}, nil
+		},
+	}
+
+	ctx := context.Background()
+	reportService := ReportService{ReportRepository: mock}
+	rows, err := reportService.WeekdayReportService(ctx, domain.ReportQuery{From: time.Now(), To: time.Now()})
+
+	assert.NoError(t, err, "should not return error")
+	assert.Equal(t, "Monday", rows[0].Name, "should name ISO weekday 1")
+	assert.Equal(t, "Sunday", rows[1].Name, "should name ISO weekday 7")
+}
+
+func TestLeadTimeReportService(t *testing.T) {
+	var thresholds []int
+
+	mock := &reportRepo{
+		FindLeadTimesFunc: func(ctx context.Context, q domain.ReportQuery, th []int) ([]domain.LeadTimeRow, error) {
+			thresholds = th
+			return []domain.LeadTimeRow{{Bucket: 0, Bookings: 1}, {Bucket: 3, Bookings: 3}}, nil
+		},
+	}
+
+	ctx := context.Background()
+	reportService := ReportService{ReportRepository: mock}
+	rows, err := reportService.LeadTimeReportService(ctx, domain.ReportQuery{From: time.Now(), To: time.Now()})
+
+	assert.NoError(t, err, "should not return error")
+	assert.Equal(t, domain.LeadTimeThresholds, thresholds, "should bucket by the shared thresholds")
+	assert.Len(t, rows, len(domain.LeadTimeThresholds)+1, "should return every bucket")
+	assert.Equal(t, "same day", rows[0].Label)
+	assert.Equal(t, "1 day", rows[1].Label)
+	assert.Equal(t, "4-7 days", rows[3].Label)
+	assert.Equal(t, "31+ days", rows[6].Label)
+	assert.Nil(t, rows[6].MaxDays, "should leave the last bucket open")
+	assert.Equal(t, 0.25, rows[0].Share, "should share bookings across buckets")
+	assert.Equal(t, 0.75, rows[3].Share, "should share bookings across buckets")
+	assert.Equal(t, 0, rows[1].Bookings, "should keep empty buckets")
+}
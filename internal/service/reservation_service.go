@@ -2,39 +2,216 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/tufee/desk-reservation-go/internal/domain"
 	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
 )
 
+const maxReservationRangeDays = 31
+
 type ReservationService struct {
 	ReservationRepository domain.ReservationRepositoryInterface
+	GuestRepository       domain.GuestRepositoryInterface
+	SwapOfferRepository   domain.SwapOfferRepositoryInterface
+	UserRepository        domain.UserRepositoryInterface
+	DeskRepository        domain.DeskRepositoryInterface
+	LotteryRepository     domain.LotteryRepositoryInterface
+	AuditLogRepository    domain.AuditLogRepositoryInterface
+	Notifier              domain.NotifierInterface
+	Policy                domain.ReservationPolicy
 }
 
 func (repo *ReservationService) CreateReservationService(
 	ctx context.Context,
+	actorId string,
 	reservation domain.CreateReservation,
 ) error {
 	log := pkg.GetLogger()
 
 	log.Info("Processing reservation for desk: %s", reservation.DeskId)
 
+	desk, err := findReservableDesk(ctx, repo, reservation.DeskId)
+	if err != nil {
+		return err
+	}
+
+	reservation.Date = domain.LocalDate(reservation.Date, desk.Location())
+
 	isReservationMade, err := checkReservationMade(ctx, repo, reservation)
 	if err != nil {
 		return err
 	}
 
 	if isReservationMade == nil {
-		if err := repo.ReservationRepository.SaveReservation(ctx, reservation); err != nil {
+		if err := checkReservationPolicy(ctx, repo, reservation.UserId, reservation.Date); err != nil {
+			return err
+		}
+
+		if err := checkLotteryAllocation(ctx, repo, desk, reservation.Date); err != nil {
+			return err
+		}
+
+		reservation.Status = resolveReservationStatus(desk)
+		reservation.DailyLimit = repo.Policy.MaxReservationsPerDay
+
+		reservationId, err := repo.ReservationRepository.SaveReservation(ctx, reservation)
+		if err != nil {
 			log.Error("Error saving user to database: %v", err)
 			return err
 		}
+
+		recordReservationCreated(ctx, repo, actorId, reservationId, reservation)
+
+		if reservation.Status == domain.ReservationStatusPending {
+			notifyApprovers(ctx, repo, reservation.DeskId, reservation.Date)
+		}
+
 		log.Info("reservation created successfully")
 		return nil
 	}
 	return pkg.NewBadRequestError("desk is unavailable")
 }
 
+func (repo *ReservationService) CreateReservationRangeService(
+	ctx context.Context,
+	userId string,
+	data domain.CreateReservationRange,
+) error {
+	log := pkg.GetLogger()
+
+	log.Info(
+		"Processing reservation range for desk: %s from %s to %s",
+		data.DeskId,
+		data.StartDate.Format("2006-01-02"),
+		data.EndDate.Format("2006-01-02"),
+	)
+
+	desk, err := findReservableDesk(ctx, repo, data.DeskId)
+	if err != nil {
+		return err
+	}
+
+	reservations := buildReservationRange(data, userId, desk.Location())
+	if len(reservations) == 0 {
+		return pkg.NewBadRequestError("date range has no bookable days")
+	}
+
+	if len(reservations) > maxReservationRangeDays {
+		return pkg.NewBadRequestError("date range is too long")
+	}
+
+	conflicts := []domain.ReservationConflict{}
+
+	for _, reservation := range reservations {
+		err := checkLotteryAllocation(ctx, repo, desk, reservation.Date)
+		if err == nil {
+			err = checkReservationPolicy(ctx, repo, reservation.UserId, reservation.Date)
+		}
+
+		if err == nil {
+			continue
+		}
+
+		if _, ok := err.(*pkg.BadRequestError); !ok {
+			return err
+		}
+
+		conflicts = append(conflicts, domain.ReservationConflict{
+			Date:   reservation.Date.Format("2006-01-02"),
+			Reason: err.Error(),
+		})
+	}
+
+	if len(conflicts) > 0 {
+		log.Info("Reservation range rejected by policy for user: %s", userId)
+		return pkg.NewConflictError("some days could not be booked", conflicts)
+	}
+
+	status := resolveReservationStatus(desk)
+	for i := range reservations {
+		reservations[i].Status = status
+		reservations[i].DailyLimit = repo.Policy.MaxReservationsPerDay
+	}
+
+	reservationIds, unavailableDays, err := repo.ReservationRepository.SaveReservations(ctx, reservations)
+	if err != nil {
+		log.Error("Error saving reservation range to database: %v", err)
+		return err
+	}
+
+	for _, day := range unavailableDays {
+		conflicts = append(conflicts, domain.ReservationConflict{
+			Date:   day.Format("2006-01-02"),
+			Reason: "desk is unavailable",
+		})
+	}
+
+	if len(conflicts) > 0 {
+		log.Info("Reservation range has unavailable days for desk: %s", data.DeskId)
+		return pkg.NewConflictError("some days could not be booked", conflicts)
+	}
+
+	for i, reservationId := range reservationIds {
+		recordReservationCreated(ctx, repo, userId, reservationId, reservations[i])
+	}
+
+	if status == domain.ReservationStatusPending {
+		notifyApprovers(ctx, repo, data.DeskId, data.StartDate)
+	}
+
+	log.Info("reservation range created successfully")
+	return nil
+}
+
+func buildReservationRange(
+	data domain.CreateReservationRange,
+	userId string,
+	loc *time.Location,
+) []domain.CreateReservation {
+	reservations := []domain.CreateReservation{}
+	endDate := domain.LocalDate(data.EndDate, loc)
+
+	for day := domain.LocalDate(data.StartDate, loc); !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		if data.SkipWeekends && (day.Weekday() == time.Saturday || day.Weekday() == time.Sunday) {
+			continue
+		}
+
+		reservations = append(reservations, domain.CreateReservation{
+			DeskId: data.DeskId,
+			UserId: userId,
+			Date:   day,
+		})
+
+		if len(reservations) > maxReservationRangeDays {
+			break
+		}
+	}
+
+	return reservations
+}
+
+func recordReservationCreated(
+	ctx context.Context,
+	repo *ReservationService,
+	actorId string,
+	reservationId string,
+	reservation domain.CreateReservation,
+) {
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:    &actorId,
+		Action:     domain.AuditActionReservationCreated,
+		TargetType: domain.AuditTargetReservation,
+		TargetId:   reservationId,
+		After: reservationSnapshot(domain.Reservation{
+			DeskId: reservation.DeskId,
+			UserId: reservation.UserId,
+			Date:   reservation.Date,
+			Status: reservation.Status,
+		}),
+	})
+}
+
 func checkReservationMade(
 	ctx context.Context,
 	repo *ReservationService,
@@ -55,3 +232,106 @@ func checkReservationMade(
 
 	return reservation, nil
 }
+
+func (repo *ReservationService) CreateGuestReservationService(
+	ctx context.Context,
+	hostId string,
+	data domain.CreateGuestReservation,
+) error {
+	log := pkg.GetLogger()
+
+	log.Info("Processing guest reservation for desk: %s hosted by: %s", data.DeskId, hostId)
+
+	desk, err := findReservableDesk(ctx, repo, data.DeskId)
+	if err != nil {
+		return err
+	}
+
+	reservation := domain.CreateReservation{
+		DeskId: data.DeskId,
+		UserId: hostId,
+		Date:   domain.LocalDate(data.Date, desk.Location()),
+	}
+
+	isReservationMade, err := checkReservationMade(ctx, repo, reservation)
+	if err != nil {
+		return err
+	}
+
+	if isReservationMade != nil {
+		return pkg.NewBadRequestError("desk is unavailable")
+	}
+
+	if err := checkReservationPolicy(ctx, repo, hostId, reservation.Date); err != nil {
+		return err
+	}
+
+	if err := checkLotteryAllocation(ctx, repo, desk, reservation.Date); err != nil {
+		return err
+	}
+
+	reservation.Status = resolveReservationStatus(desk)
+	reservation.DailyLimit = repo.Policy.MaxReservationsPerDay
+
+	guest := domain.CreateGuest{
+		Name:   data.GuestName,
+		Email:  data.GuestEmail,
+		HostId: hostId,
+	}
+
+	reservationId, err := repo.GuestRepository.SaveGuestReservation(ctx, guest, reservation)
+	if err != nil {
+		log.Error("Error saving guest reservation to database: %v", err)
+		return err
+	}
+
+	recordReservationCreated(ctx, repo, hostId, reservationId, reservation)
+
+	if reservation.Status == domain.ReservationStatusPending {
+		notifyApprovers(ctx, repo, reservation.DeskId, reservation.Date)
+	}
+
+	log.Info("guest reservation created successfully")
+	return nil
+}
+
+func (repo *ReservationService) ListGuestVisitsService(
+	ctx context.Context,
+	date *time.Time,
+) ([]domain.GuestVisit, error) {
+	log := pkg.GetLogger()
+
+	visits, err := repo.GuestRepository.FindGuestVisitsByDate(ctx, date)
+	if err != nil {
+		log.Error("Error to find guest visits: %v", err)
+		return nil, err
+	}
+
+	return visits, nil
+}
+
+func checkReservationPolicy(
+	ctx context.Context,
+	repo *ReservationService,
+	userId string,
+	date time.Time,
+) error {
+	log := pkg.GetLogger()
+
+	if repo.Policy.MaxReservationsPerDay <= 0 {
+		return nil
+	}
+
+	count, err := repo.ReservationRepository.CountUserReservationsByDate(ctx, userId, date)
+	if err != nil {
+		log.Error("Error to count reservations: %v", err)
+		return err
+	}
+
+	if count >= repo.Policy.MaxReservationsPerDay {
+		log.Info("Daily reservation limit reached for user: %s", userId)
+		return pkg.NewBadRequestError("daily reservation limit reached")
+	}
+
+	return nil
+}
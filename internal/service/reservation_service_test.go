@@ -9,11 +9,25 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
 )
 
 type reservationRepo struct {
-	FindReservationFunc func(ctx context.Context, reservation domain.CreateReservation) (*domain.Reservation, error)
-	SaveReservationFunc func(ctx context.Context, reservation domain.CreateReservation) error
+	FindReservationFunc             func(ctx context.Context, reservation domain.CreateReservation) (*domain.Reservation, error)
+	SaveReservationFunc             func(ctx context.Context, reservation domain.CreateReservation) (string, error)
+	SaveReservationsFunc            func(ctx context.Context, reservations []domain.CreateReservation) ([]string, []time.Time, error)
+	CountUserReservationsByDateFunc func(ctx context.Context, userId string, date time.Time) (int, error)
+	FindReservationByIdFunc         func(ctx context.Context, id string) (*domain.Reservation, error)
+	TransferReservationFunc         func(ctx context.Context, transfer domain.ReservationTransfer) (bool, error)
+	SwapReservationOwnersFunc       func(ctx context.Context, swap domain.ReservationSwap) (bool, error)
+	FindPendingApprovalsFunc        func(ctx context.Context) ([]domain.Reservation, error)
+	ReviewReservationFunc           func(ctx context.Context, id string, status string, reviewerId string) (bool, error)
+	FindReservationsByUserFunc      func(ctx context.Context, userId string, offset int, limit int) ([]domain.Reservation, int, error)
+	DeleteReservationsBeforeFunc    func(ctx context.Context, before time.Time) (int, error)
+	CancelReservationFunc           func(ctx context.Context, id string, actorId string) (bool, error)
+	CheckInReservationFunc          func(ctx context.Context, id string) (bool, error)
+	FindReservationHistoryFunc      func(ctx context.Context, id string) ([]domain.ReservationStatusChange, error)
+	MoveReservationFunc             func(ctx context.Context, move domain.MoveReservation) (bool, error)
 }
 
 func (r *reservationRepo) FindReservation(
@@ -26,10 +40,158 @@ func (r *reservationRepo) FindReservation(
 func (r *reservationRepo) SaveReservation(
 	ctx context.Context,
 	reservation domain.CreateReservation,
-) error {
+) (string, error) {
 	return r.SaveReservationFunc(ctx, reservation)
 }
 
+func (r *reservationRepo) SaveReservations(
+	ctx context.Context,
+	reservations []domain.CreateReservation,
+) ([]string, []time.Time, error) {
+	return r.SaveReservationsFunc(ctx, reservations)
+}
+
+func (r *reservationRepo) CountUserReservationsByDate(
+	ctx context.Context,
+	userId string,
+	date time.Time,
+) (int, error) {
+	return r.CountUserReservationsByDateFunc(ctx, userId, date)
+}
+
+func (r *reservationRepo) FindReservationById(
+	ctx context.Context,
+	id string,
+) (*domain.Reservation, error) {
+	return r.FindReservationByIdFunc(ctx, id)
+}
+
+func (r *reservationRepo) TransferReservation(
+	ctx context.Context,
+	transfer domain.ReservationTransfer,
+) (bool, error) {
+	return r.TransferReservationFunc(ctx, transfer)
+}
+
+func (r *reservationRepo) SwapReservationOwners(
+	ctx context.Context,
+	swap domain.ReservationSwap,
+) (bool, error) {
+	return r.SwapReservationOwnersFunc(ctx, swap)
+}
+
+func (r *reservationRepo) FindPendingApprovals(ctx context.Context) ([]domain.Reservation, error) {
+	return r.FindPendingApprovalsFunc(ctx)
+}
+
+func (r *reservationRepo) ReviewReservation(
+	ctx context.Context,
+	id string,
+	status string,
+	reviewerId string,
+) (bool, error) {
+	return r.ReviewReservationFunc(ctx, id, status, reviewerId)
+}
+
+func (r *reservationRepo) FindReservationsByUser(
+	ctx context.Context,
+	userId string,
+	offset int,
+	limit int,
+) ([]domain.Reservation, int, error) {
+	return r.FindReservationsByUserFunc(ctx, userId, offset, limit)
+}
+
+func (r *reservationRepo) DeleteReservationsBefore(ctx context.Context, before time.Time) (int, error) {
+	return r.DeleteReservationsBeforeFunc(ctx, before)
+}
+
+func (r *reservationRepo) CancelReservation(ctx context.Context, id string, actorId string) (bool, error) {
+	return r.CancelReservationFunc(ctx, id, actorId)
+}
+
+func (r *reservationRepo) CheckInReservation(ctx context.Context, id string) (bool, error) {
+	return r.CheckInReservationFunc(ctx, id)
+}
+
+func (r *reservationRepo) FindReservationHistory(
+	ctx context.Context,
+	id string,
+) ([]domain.ReservationStatusChange, error) {
+	return r.FindReservationHistoryFunc(ctx, id)
+}
+
+func (r *reservationRepo) MoveReservation(ctx context.Context, move domain.MoveReservation) (bool, error) {
+	return r.MoveReservationFunc(ctx, move)
+}
+
+type deskRepo struct {
+	FindDeskByIdFunc             func(ctx context.Context, id string) (*domain.Desk, error)
+	UpdateDeskApprovalFunc       func(ctx context.Context, id string, requiresApproval bool) (*bool, error)
+	UpdateZoneApprovalFunc       func(ctx context.Context, id string, requiresApproval bool) (*bool, error)
+	FindAvailableDesksBySiteFunc func(ctx context.Context, siteId string, date time.Time) ([]domain.Desk, error)
+}
+
+func (r *deskRepo) FindDeskById(ctx context.Context, id string) (*domain.Desk, error) {
+	return r.FindDeskByIdFunc(ctx, id)
+}
+
+func (r *deskRepo) UpdateDeskApproval(
+	ctx context.Context,
+	id string,
+	requiresApproval bool,
+) (*bool, error) {
+	return r.UpdateDeskApprovalFunc(ctx, id, requiresApproval)
+}
+
+func (r *deskRepo) UpdateZoneApproval(
+	ctx context.Context,
+	id string,
+	requiresApproval bool,
+) (*bool, error) {
+	return r.UpdateZoneApprovalFunc(ctx, id, requiresApproval)
+}
+
+func (r *deskRepo) FindAvailableDesksBySite(
+	ctx context.Context,
+	siteId string,
+	date time.Time,
+) ([]domain.Desk, error) {
+	return r.FindAvailableDesksBySiteFunc(ctx, siteId, date)
+}
+
+func openDeskRepo() *deskRepo {
+	return &deskRepo{
+		FindDeskByIdFunc: func(ctx context.Context, id string) (*domain.Desk, error) {
+			return &domain.Desk{Id: id, Number: 1}, nil
+		},
+	}
+}
+
+type guestRepo struct {
+	SaveGuestReservationFunc func(
+		ctx context.Context,
+		guest domain.CreateGuest,
+		reservation domain.CreateReservation,
+	) (string, error)
+	FindGuestVisitsByDateFunc func(ctx context.Context, date *time.Time) ([]domain.GuestVisit, error)
+}
+
+func (r *guestRepo) SaveGuestReservation(
+	ctx context.Context,
+	guest domain.CreateGuest,
+	reservation domain.CreateReservation,
+) (string, error) {
+	return r.SaveGuestReservationFunc(ctx, guest, reservation)
+}
+
+func (r *guestRepo) FindGuestVisitsByDate(
+	ctx context.Context,
+	date *time.Time,
+) ([]domain.GuestVisit, error) {
+	return r.FindGuestVisitsByDateFunc(ctx, date)
+}
+
 func TestCreateReservationService(t *testing.T) {
 	t.Run("should create reservation successfully", func(t *testing.T) {
 		parsedTime, _ := time.Parse(time.RFC3339, "2025-06-05T00:54:07Z")
@@ -50,14 +212,14 @@ func TestCreateReservationService(t *testing.T) {
 			SaveReservationFunc: func(
 				ctx context.Context,
 				reservation domain.CreateReservation,
-			) error {
-				return nil
+			) (string, error) {
+				return "reservation-id", nil
 			},
 		}
 
 		ctx := context.Background()
-		reservation := ReservationService{ReservationRepository: mock}
-		err := reservation.CreateReservationService(ctx, data)
+		reservation := ReservationService{ReservationRepository: mock, DeskRepository: openDeskRepo()}
+		err := reservation.CreateReservationService(ctx, data.UserId, data)
 
 		assert.NoError(t, err, "should not return error")
 		assert.Equal(t, nil, err, "should create reservation successfully")
@@ -82,14 +244,14 @@ func TestCreateReservationService(t *testing.T) {
 			SaveReservationFunc: func(
 				ctx context.Context,
 				reservation domain.CreateReservation,
-			) error {
-				return nil
+			) (string, error) {
+				return "reservation-id", nil
 			},
 		}
 
 		ctx := context.Background()
-		reservation := ReservationService{ReservationRepository: mock}
-		err := reservation.CreateReservationService(ctx, data)
+		reservation := ReservationService{ReservationRepository: mock, DeskRepository: openDeskRepo()}
+		err := reservation.CreateReservationService(ctx, data.UserId, data)
 
 		assert.Error(t, err, "should return erro")
 		assert.Equal(t, err.Error(), "desk is unavailable", "should return correct message")
@@ -114,14 +276,14 @@ func TestCreateReservationService(t *testing.T) {
 			SaveReservationFunc: func(
 				ctx context.Context,
 				reservation domain.CreateReservation,
-			) error {
-				return nil
+			) (string, error) {
+				return "reservation-id", nil
 			},
 		}
 
 		ctx := context.Background()
-		reservation := ReservationService{ReservationRepository: mock}
-		err := reservation.CreateReservationService(ctx, data)
+		reservation := ReservationService{ReservationRepository: mock, DeskRepository: openDeskRepo()}
+		err := reservation.CreateReservationService(ctx, data.UserId, data)
 
 		assert.Error(t, err, "should return erro")
 		assert.Equal(
@@ -131,4 +293,470 @@ func TestCreateReservationService(t *testing.T) {
 			"should fail to find reservation",
 		)
 	})
+
+	t.Run("should return daily reservation limit reached", func(t *testing.T) {
+		parsedTime, _ := time.Parse(time.RFC3339, "2025-06-05T00:54:07Z")
+
+		data := domain.CreateReservation{
+			DeskId: "48b8c429-be55-470f-a245-651fc3c75a6b",
+			UserId: "1a162e27-45ff-4632-817a-a79e88c8f878",
+			Date:   parsedTime,
+		}
+
+		mock := &reservationRepo{
+			FindReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (*domain.Reservation, error) {
+				return nil, nil
+			},
+			CountUserReservationsByDateFunc: func(
+				ctx context.Context,
+				userId string,
+				date time.Time,
+			) (int, error) {
+				return 2, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			Policy:                domain.ReservationPolicy{MaxReservationsPerDay: 2},
+		}
+		err := reservation.CreateReservationService(ctx, data.UserId, data)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "daily reservation limit reached", err.Error(), "should return correct message")
+	})
+
+	t.Run("should audit the authenticated caller as actor", func(t *testing.T) {
+		parsedTime, _ := time.Parse(time.RFC3339, "2025-06-05T00:54:07Z")
+		actorId := "0b9f1c2e-7d8a-4e3b-9c6f-2a1d5e8f7b4c"
+		var entry domain.CreateAuditLog
+
+		data := domain.CreateReservation{
+			DeskId: "48b8c429-be55-470f-a245-651fc3c75a6b",
+			UserId: "1a162e27-45ff-4632-817a-a79e88c8f878",
+			Date:   parsedTime,
+		}
+
+		mock := &reservationRepo{
+			FindReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (*domain.Reservation, error) {
+				return nil, nil
+			},
+			SaveReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (string, error) {
+				return "reservation-id", nil
+			},
+		}
+		audits := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, e domain.CreateAuditLog) error {
+				entry = e
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			AuditLogRepository:    audits,
+		}
+		err := reservation.CreateReservationService(ctx, actorId, data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, actorId, *entry.ActorId, "should not trust the body's user id as actor")
+	})
+}
+
+func TestCreateGuestReservationService(t *testing.T) {
+	parsedTime, _ := time.Parse(time.RFC3339, "2025-06-05T00:54:07Z")
+	hostId := "1a162e27-45ff-4632-817a-a79e88c8f878"
+
+	data := domain.CreateGuestReservation{
+		DeskId:     "48b8c429-be55-470f-a245-651fc3c75a6b",
+		Date:       parsedTime,
+		GuestName:  "Guest User",
+		GuestEmail: "guest@example.com",
+	}
+
+	t.Run("should create guest reservation on behalf of host", func(t *testing.T) {
+		var savedGuest domain.CreateGuest
+		var savedReservation domain.CreateReservation
+
+		mock := &reservationRepo{
+			FindReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (*domain.Reservation, error) {
+				return nil, nil
+			},
+			CountUserReservationsByDateFunc: func(
+				ctx context.Context,
+				userId string,
+				date time.Time,
+			) (int, error) {
+				return 1, nil
+			},
+		}
+		guests := &guestRepo{
+			SaveGuestReservationFunc: func(
+				ctx context.Context,
+				guest domain.CreateGuest,
+				reservation domain.CreateReservation,
+			) (string, error) {
+				savedGuest = guest
+				savedReservation = reservation
+				return "reservation-id", nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			GuestRepository:       guests,
+			DeskRepository:        openDeskRepo(),
+			Policy:                domain.ReservationPolicy{MaxReservationsPerDay: 2},
+		}
+		err := reservation.CreateGuestReservationService(ctx, hostId, data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, hostId, savedGuest.HostId, "guest should be hosted by the user")
+		assert.Equal(t, hostId, savedReservation.UserId, "reservation should belong to the host")
+		assert.Equal(t, data.GuestName, savedGuest.Name, "should save guest name")
+	})
+
+	t.Run("should count guest reservation against host limit", func(t *testing.T) {
+		var countedUserId string
+
+		mock := &reservationRepo{
+			FindReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (*domain.Reservation, error) {
+				return nil, nil
+			},
+			CountUserReservationsByDateFunc: func(
+				ctx context.Context,
+				userId string,
+				date time.Time,
+			) (int, error) {
+				countedUserId = userId
+				return 2, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			GuestRepository:       &guestRepo{},
+			DeskRepository:        openDeskRepo(),
+			Policy:                domain.ReservationPolicy{MaxReservationsPerDay: 2},
+		}
+		err := reservation.CreateGuestReservationService(ctx, hostId, data)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, hostId, countedUserId, "should count reservations of the host")
+		assert.Equal(t, "daily reservation limit reached", err.Error(), "should return correct message")
+	})
+
+	t.Run("should return desk unavailable", func(t *testing.T) {
+		mock := &reservationRepo{
+			FindReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (*domain.Reservation, error) {
+				return &domain.Reservation{}, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			GuestRepository:       &guestRepo{},
+			DeskRepository:        openDeskRepo(),
+		}
+		err := reservation.CreateGuestReservationService(ctx, hostId, data)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "desk is unavailable", err.Error(), "should return correct message")
+	})
+}
+
+func TestCreateReservationRangeService(t *testing.T) {
+	startDate, _ := time.Parse(time.RFC3339, "2025-06-02T00:00:00Z")
+	endDate, _ := time.Parse(time.RFC3339, "2025-06-08T00:00:00Z")
+	userId := "1a162e27-45ff-4632-817a-a79e88c8f878"
+
+	data := domain.CreateReservationRange{
+		DeskId:       "48b8c429-be55-470f-a245-651fc3c75a6b",
+		StartDate:    startDate,
+		EndDate:      endDate,
+		SkipWeekends: true,
+	}
+
+	t.Run("should book every weekday of the range", func(t *testing.T) {
+		var saved []domain.CreateReservation
+
+		mock := &reservationRepo{
+			SaveReservationsFunc: func(
+				ctx context.Context,
+				reservations []domain.CreateReservation,
+			) ([]string, []time.Time, error) {
+				saved = reservations
+				return nil, nil, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{ReservationRepository: mock, DeskRepository: openDeskRepo()}
+		err := reservation.CreateReservationRangeService(ctx, userId, data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Len(t, saved, 5, "should book five weekdays")
+		assert.Equal(t, "2025-06-06", saved[4].Date.Format("2006-01-02"), "should end on friday")
+		assert.Equal(t, userId, saved[0].UserId, "should book for the authenticated user")
+	})
+
+	t.Run("should audit each booked reservation", func(t *testing.T) {
+		var entries []domain.CreateAuditLog
+
+		mock := &reservationRepo{
+			SaveReservationsFunc: func(
+				ctx context.Context,
+				reservations []domain.CreateReservation,
+			) ([]string, []time.Time, error) {
+				ids := []string{}
+				for _, r := range reservations {
+					ids = append(ids, "reservation-"+r.Date.Format("2006-01-02"))
+				}
+				return ids, nil, nil
+			},
+		}
+		audit := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				entries = append(entries, entry)
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			AuditLogRepository:    audit,
+		}
+		err := reservation.CreateReservationRangeService(ctx, userId, data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Len(t, entries, 5, "should record one entry per reservation")
+		assert.Equal(t, domain.AuditActionReservationCreated, entries[0].Action, "should record a creation")
+		assert.Equal(t, "reservation-2025-06-02", entries[0].TargetId, "should target the first reservation")
+		assert.Equal(t, "reservation-2025-06-06", entries[4].TargetId, "should target the last reservation")
+	})
+
+	t.Run("should return conflicting days", func(t *testing.T) {
+		mock := &reservationRepo{
+			SaveReservationsFunc: func(
+				ctx context.Context,
+				reservations []domain.CreateReservation,
+			) ([]string, []time.Time, error) {
+				return nil, []time.Time{reservations[2].Date}, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{ReservationRepository: mock, DeskRepository: openDeskRepo()}
+		err := reservation.CreateReservationRangeService(ctx, userId, data)
+
+		conflictErr, ok := err.(*pkg.ConflictError)
+		assert.True(t, ok, "should return conflict error")
+		assert.Equal(
+			t,
+			[]domain.ReservationConflict{{Date: "2025-06-04", Reason: "desk is unavailable"}},
+			conflictErr.Conflicts,
+			"should list the unavailable day",
+		)
+	})
+
+	t.Run("should not save when policy rejects a day", func(t *testing.T) {
+		saveCalled := false
+
+		mock := &reservationRepo{
+			CountUserReservationsByDateFunc: func(
+				ctx context.Context,
+				userId string,
+				date time.Time,
+			) (int, error) {
+				if date.Weekday() == time.Tuesday {
+					return 1, nil
+				}
+				return 0, nil
+			},
+			SaveReservationsFunc: func(
+				ctx context.Context,
+				reservations []domain.CreateReservation,
+			) ([]string, []time.Time, error) {
+				saveCalled = true
+				return nil, nil, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			Policy:                domain.ReservationPolicy{MaxReservationsPerDay: 1},
+		}
+		err := reservation.CreateReservationRangeService(ctx, userId, data)
+
+		conflictErr, ok := err.(*pkg.ConflictError)
+		assert.True(t, ok, "should return conflict error")
+		assert.False(t, saveCalled, "should not save any reservation")
+		assert.Equal(
+			t,
+			[]domain.ReservationConflict{{Date: "2025-06-03", Reason: "daily reservation limit reached"}},
+			conflictErr.Conflicts,
+			"should list the day over the limit",
+		)
+	})
+
+	t.Run("should reject ranges that are too long", func(t *testing.T) {
+		longRange := data
+		longRange.EndDate = startDate.AddDate(0, 3, 0)
+
+		ctx := context.Background()
+		reservation := ReservationService{ReservationRepository: &reservationRepo{}, DeskRepository: openDeskRepo()}
+		err := reservation.CreateReservationRangeService(ctx, userId, longRange)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "date range is too long", err.Error(), "should return correct message")
+	})
+}
+
+func siteDeskRepo(timeZone string) *deskRepo {
+	return &deskRepo{
+		FindDeskByIdFunc: func(ctx context.Context, id string) (*domain.Desk, error) {
+			return &domain.Desk{Id: id, Number: 1, TimeZone: timeZone}, nil
+		},
+	}
+}
+
+func TestCreateReservationInSiteTimeZone(t *testing.T) {
+	t.Run("should book the site's local day for a late evening booking", func(t *testing.T) {
+		var saved domain.CreateReservation
+
+		// 23:00 on 5 June in São Paulo (UTC-3) is already 6 June in UTC.
+		parsedTime, _ := time.Parse(time.RFC3339, "2025-06-05T23:00:00-03:00")
+		data := domain.CreateReservation{
+			DeskId: "48b8c429-be55-470f-a245-651fc3c75a6b",
+			UserId: "1a162e27-45ff-4632-817a-a79e88c8f878",
+			Date:   parsedTime.UTC(),
+		}
+
+		mock := &reservationRepo{
+			FindReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (*domain.Reservation, error) {
+				return nil, nil
+			},
+			SaveReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (string, error) {
+				saved = reservation
+				return "reservation-id", nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        siteDeskRepo("America/Sao_Paulo"),
+		}
+		err := reservation.CreateReservationService(ctx, data.UserId, data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "2025-06-05", saved.Date.Format("2006-01-02"), "should book the local day")
+	})
+
+	t.Run("should book every local day across a DST transition", func(t *testing.T) {
+		var saved []domain.CreateReservation
+
+		// Clocks in New York spring forward on 9 March 2025.
+		startDate, _ := time.Parse(time.RFC3339, "2025-03-08T00:00:00-05:00")
+		endDate, _ := time.Parse(time.RFC3339, "2025-03-10T00:00:00-04:00")
+		data := domain.CreateReservationRange{
+			DeskId:    "48b8c429-be55-470f-a245-651fc3c75a6b",
+			StartDate: startDate.UTC(),
+			EndDate:   endDate.UTC(),
+		}
+
+		mock := &reservationRepo{
+			SaveReservationsFunc: func(
+				ctx context.Context,
+				reservations []domain.CreateReservation,
+			) ([]string, []time.Time, error) {
+				saved = reservations
+				return nil, nil, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        siteDeskRepo("America/New_York"),
+		}
+		err := reservation.CreateReservationRangeService(ctx, "1a162e27-45ff-4632-817a-a79e88c8f878", data)
+
+		days := []string{}
+		for _, r := range saved {
+			days = append(days, r.Date.Format("2006-01-02"))
+		}
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, []string{"2025-03-08", "2025-03-09", "2025-03-10"}, days, "should book each local day once")
+	})
+
+	t.Run("should fall back to UTC for desks without a site", func(t *testing.T) {
+		var saved domain.CreateReservation
+
+		parsedTime, _ := time.Parse(time.RFC3339, "2025-06-05T23:00:00-03:00")
+		data := domain.CreateReservation{
+			DeskId: "48b8c429-be55-470f-a245-651fc3c75a6b",
+			UserId: "1a162e27-45ff-4632-817a-a79e88c8f878",
+			Date:   parsedTime,
+		}
+
+		mock := &reservationRepo{
+			FindReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (*domain.Reservation, error) {
+				return nil, nil
+			},
+			SaveReservationFunc: func(
+				ctx context.Context,
+				reservation domain.CreateReservation,
+			) (string, error) {
+				saved = reservation
+				return "reservation-id", nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{ReservationRepository: mock, DeskRepository: openDeskRepo()}
+		err := reservation.CreateReservationService(ctx, data.UserId, data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "2025-06-06", saved.Date.Format("2006-01-02"), "should book the UTC day")
+	})
 }
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func (repo *ReservationService) CancelReservationService(
+	ctx context.Context,
+	actorId string,
+	actorRole string,
+	reservationId string,
+) error {
+	log := pkg.GetLogger()
+
+	log.Info("Processing cancellation of reservation: %s", reservationId)
+
+	reservation, err := findReservation(ctx, repo, reservationId)
+	if err != nil {
+		return err
+	}
+
+	if reservation.UserId != actorId && actorRole != domain.RoleAdmin {
+		return pkg.NewForbiddenError("reservation does not belong to user")
+	}
+
+	desk, err := findReservableDesk(ctx, repo, reservation.DeskId)
+	if err != nil {
+		return err
+	}
+
+	if reservation.Date.Before(domain.LocalDate(time.Now(), desk.Location())) {
+		return pkg.NewBadRequestError("past reservations cannot be cancelled")
+	}
+
+	if err := checkReservationTransition(reservation.Status, domain.ReservationStatusCancelled); err != nil {
+		return err
+	}
+
+	cancelled, err := repo.ReservationRepository.CancelReservation(ctx, reservation.Id, actorId)
+	if err != nil {
+		log.Error("Error to cancel reservation: %v", err)
+		return err
+	}
+
+	if !cancelled {
+		return pkg.NewBadRequestError("reservation is not active")
+	}
+
+	after := *reservation
+	after.Status = domain.ReservationStatusCancelled
+
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:    &actorId,
+		Action:     domain.AuditActionReservationCancelled,
+		TargetType: domain.AuditTargetReservation,
+		TargetId:   reservation.Id,
+		Before:     reservationSnapshot(*reservation),
+		After:      reservationSnapshot(after),
+	})
+
+	promoteLotteryWaitlist(ctx, repo, actorId, desk, reservation)
+
+	log.Info("reservation cancelled successfully")
+	return nil
+}
+
+func (repo *ReservationService) CheckInReservationService(
+	ctx context.Context,
+	userId string,
+	reservationId string,
+) error {
+	log := pkg.GetLogger()
+
+	log.Info("Processing check-in of reservation: %s", reservationId)
+
+	reservation, err := findReservation(ctx, repo, reservationId)
+	if err != nil {
+		return err
+	}
+
+	if reservation.UserId != userId {
+		return pkg.NewForbiddenError("reservation does not belong to user")
+	}
+
+	if reservation.Status != domain.ReservationStatusConfirmed {
+		return pkg.NewBadRequestError("only confirmed reservations can be checked in")
+	}
+
+	if reservation.CheckedInAt != nil {
+		return pkg.NewBadRequestError("reservation is already checked in")
+	}
+
+	desk, err := findReservableDesk(ctx, repo, reservation.DeskId)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if !reservation.Date.Equal(domain.LocalDate(now, desk.Location())) {
+		return pkg.NewBadRequestError("reservations can only be checked in on the day")
+	}
+
+	checkedIn, err := repo.ReservationRepository.CheckInReservation(ctx, reservation.Id)
+	if err != nil {
+		log.Error("Error to check in reservation: %v", err)
+		return err
+	}
+
+	if !checkedIn {
+		return pkg.NewBadRequestError("reservation cannot be checked in")
+	}
+
+	after := *reservation
+	after.CheckedInAt = &now
+
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:    &userId,
+		Action:     domain.AuditActionReservationCheckedIn,
+		TargetType: domain.AuditTargetReservation,
+		TargetId:   reservation.Id,
+		Before:     reservationSnapshot(*reservation),
+		After:      reservationSnapshot(after),
+	})
+
+	log.Info("reservation checked in successfully")
+	return nil
+}
+
+func (repo *ReservationService) GetReservationService(
+	ctx context.Context,
+	userId string,
+	role string,
+	reservationId string,
+) (*domain.ReservationDetails, error) {
+	log := pkg.GetLogger()
+
+	reservation, err := findReservation(ctx, repo, reservationId)
+	if err != nil {
+		return nil, err
+	}
+
+	if reservation.UserId != userId && role != domain.RoleAdmin && role != domain.RoleApprover {
+		return nil, pkg.NewForbiddenError("reservation does not belong to user")
+	}
+
+	history, err := repo.ReservationRepository.FindReservationHistory(ctx, reservation.Id)
+	if err != nil {
+		log.Error("Error to find reservation history: %v", err)
+		return nil, err
+	}
+
+	return &domain.ReservationDetails{Reservation: *reservation, History: history}, nil
+}
+
+func checkReservationTransition(from string, to string) error {
+	if !domain.CanTransitionReservation(from, to) {
+		return pkg.NewBadRequestError(fmt.Sprintf("reservation cannot move from %s to %s", from, to))
+	}
+
+	return nil
+}
+
+func findReservation(ctx context.Context, repo *ReservationService, reservationId string) (*domain.Reservation, error) {
+	log := pkg.GetLogger()
+
+	reservation, err := repo.ReservationRepository.FindReservationById(ctx, reservationId)
+	if err != nil {
+		log.Error("Error to find reservation: %v", err)
+		return nil, err
+	}
+
+	if reservation == nil {
+		return nil, pkg.NewNotFoundError("reservation not found")
+	}
+
+	return reservation, nil
+}
+
+func reservationSnapshot(reservation domain.Reservation) map[string]any {
+	return map[string]any{
+		"desk_id":       reservation.DeskId,
+		"user_id":       reservation.UserId,
+		"guest_id":      reservation.GuestId,
+		"date":          reservation.Date.Format("2006-01-02"),
+		"status":        reservation.Status,
+		"checked_in_at": reservation.CheckedInAt,
+	}
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+func reservationByIdRepo(reservation domain.Reservation) *reservationRepo {
+	return &reservationRepo{
+		FindReservationByIdFunc: func(ctx context.Context, id string) (*domain.Reservation, error) {
+			found := reservation
+			return &found, nil
+		},
+	}
+}
+
+func TestCancelReservationService(t *testing.T) {
+	today := domain.LocalDate(time.Now(), time.UTC)
+
+	t.Run("should cancel and audit the reservation", func(t *testing.T) {
+		var audited []domain.CreateAuditLog
+
+		mock := reservationByIdRepo(domain.Reservation{
+			Id:     "res-1",
+			DeskId: "desk-1",
+			UserId: "user-1",
+			Date:   today,
+			Status: domain.ReservationStatusConfirmed,
+		})
+		mock.CancelReservationFunc = func(ctx context.Context, id string, actorId string) (bool, error) {
+			return true, nil
+		}
+		audit := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = append(audited, entry)
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		reservationService := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			AuditLogRepository:    audit,
+		}
+		err := reservationService.CancelReservationService(ctx, "user-1", domain.RoleUser, "res-1")
+
+		assert.NoError(t, err, "should not return error")
+		assert.Len(t, audited, 1, "should audit the cancellation")
+		assert.Equal(t, domain.AuditActionReservationCancelled, audited[0].Action)
+		assert.Equal(t, domain.ReservationStatusConfirmed, audited[0].Before.(map[string]any)["status"])
+		assert.Equal(t, domain.ReservationStatusCancelled, audited[0].After.(map[string]any)["status"])
+	})
+
+	t.Run("should pass a cancelled lottery desk to the waitlist", func(t *testing.T) {
+		var promotion domain.LotteryPromotion
+		var audited []domain.CreateAuditLog
+		siteId := "site-1"
+
+		mock := reservationByIdRepo(domain.Reservation{
+			Id:     "res-1",
+			DeskId: "desk-1",
+			UserId: "user-1",
+			Date:   today,
+			Status: domain.ReservationStatusConfirmed,
+		})
+		mock.CancelReservationFunc = func(ctx context.Context, id string, actorId string) (bool, error) {
+			return true, nil
+		}
+		desks := &deskRepo{
+			FindDeskByIdFunc: func(ctx context.Context, id string) (*domain.Desk, error) {
+				return &domain.Desk{Id: id, SiteId: &siteId, LotteryEnabled: true}, nil
+			},
+		}
+		lottery := &lotteryRepo{
+			PromoteLotteryWaitlistFunc: func(
+				ctx context.Context,
+				p domain.LotteryPromotion,
+			) (*domain.Reservation, error) {
+				promotion = p
+				return &domain.Reservation{Id: "res-2", DeskId: p.DeskId, UserId: "user-2", Date: p.Date}, nil
+			},
+		}
+		audit := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = append(audited, entry)
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		reservationService := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        desks,
+			LotteryRepository:     lottery,
+			AuditLogRepository:    audit,
+			Policy:                domain.ReservationPolicy{MaxReservationsPerDay: 1},
+		}
+		err := reservationService.CancelReservationService(ctx, "user-1", domain.RoleUser, "res-1")
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "user-1", promotion.FromUserId, "should forfeit the cancelled win")
+		assert.Equal(t, 1, promotion.DailyLimit, "should respect the daily limit")
+		assert.Len(t, audited, 2, "should audit the cancellation and the promotion")
+		assert.Equal(t, "res-2", audited[1].TargetId, "should audit the promoted reservation")
+	})
+
+	t.Run("should not let other users cancel", func(t *testing.T) {
+		mock := reservationByIdRepo(domain.Reservation{Id: "res-1", UserId: "user-1", Date: today})
+
+		ctx := context.Background()
+		reservationService := ReservationService{ReservationRepository: mock, DeskRepository: openDeskRepo()}
+		err := reservationService.CancelReservationService(ctx, "user-2", domain.RoleUser, "res-1")
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "reservation does not belong to user", err.Error(), "should return correct message")
+	})
+
+	t.Run("should not cancel a rejected reservation", func(t *testing.T) {
+		mock := reservationByIdRepo(domain.Reservation{
+			Id:     "res-1",
+			UserId: "user-1",
+			Date:   today,
+			Status: domain.ReservationStatusRejected,
+		})
+
+		ctx := context.Background()
+		reservationService := ReservationService{ReservationRepository: mock, DeskRepository: openDeskRepo()}
+		err := reservationService.CancelReservationService(ctx, "user-1", domain.RoleUser, "res-1")
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "reservation cannot move from rejected to cancelled", err.Error(), "should return correct message")
+	})
+
+	t.Run("should reject past reservations", func(t *testing.T) {
+		mock := reservationByIdRepo(domain.Reservation{Id: "res-1", UserId: "user-1", Date: today.AddDate(0, 0, -1)})
+
+		ctx := context.Background()
+		reservationService := ReservationService{ReservationRepository: mock, DeskRepository: openDeskRepo()}
+		err := reservationService.CancelReservationService(ctx, "admin-1", domain.RoleAdmin, "res-1")
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "past reservations cannot be cancelled", err.Error(), "should return correct message")
+	})
+}
+
+func TestCheckInReservationService(t *testing.T) {
+	today := domain.LocalDate(time.Now(), time.UTC)
+
+	t.Run("should check in and audit the reservation", func(t *testing.T) {
+		var audited []domain.CreateAuditLog
+
+		mock := reservationByIdRepo(domain.Reservation{
+			Id:     "res-1",
+			DeskId: "desk-1",
+			UserId: "user-1",
+			Date:   today,
+			Status: domain.ReservationStatusConfirmed,
+		})
+		mock.CheckInReservationFunc = func(ctx context.Context, id string) (bool, error) {
+			return true, nil
+		}
+		audit := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = append(audited, entry)
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		reservationService := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			AuditLogRepository:    audit,
+		}
+		err := reservationService.CheckInReservationService(ctx, "user-1", "res-1")
+
+		assert.NoError(t, err, "should not return error")
+		assert.Len(t, audited, 1, "should audit the check-in")
+		assert.Equal(t, domain.AuditActionReservationCheckedIn, audited[0].Action)
+		assert.NotNil(t, audited[0].After.(map[string]any)["checked_in_at"], "should snapshot the check-in time")
+	})
+
+	t.Run("should only check in on the day", func(t *testing.T) {
+		mock := reservationByIdRepo(domain.Reservation{
+			Id:     "res-1",
+			UserId: "user-1",
+			Date:   today.AddDate(0, 0, 1),
+			Status: domain.ReservationStatusConfirmed,
+		})
+
+		ctx := context.Background()
+		reservationService := ReservationService{ReservationRepository: mock, DeskRepository: openDeskRepo()}
+		err := reservationService.CheckInReservationService(ctx, "user-1", "res-1")
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "reservations can only be checked in on the day", err.Error(), "should return correct message")
+	})
+
+	t.Run("should reject pending reservations", func(t *testing.T) {
+		mock := reservationByIdRepo(domain.Reservation{
+			Id:     "res-1",
+			UserId: "user-1",
+			Date:   today,
+			Status: domain.ReservationStatusPending,
+		})
+
+		ctx := context.Background()
+		reservationService := ReservationService{ReservationRepository: mock}
+		err := reservationService.CheckInReservationService(ctx, "user-1", "res-1")
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "only confirmed reservations can be checked in", err.Error(), "should return correct message")
+	})
+}
+
+func TestGetReservationService(t *testing.T) {
+	pending := domain.ReservationStatusPending
+
+	t.Run("should return the reservation with its timeline", func(t *testing.T) {
+		mock := reservationByIdRepo(domain.Reservation{
+			Id:     "res-1",
+			UserId: "user-1",
+			Status: domain.ReservationStatusConfirmed,
+		})
+		mock.FindReservationHistoryFunc = func(ctx context.Context, id string) ([]domain.ReservationStatusChange, error) {
+			return []domain.ReservationStatusChange{
+				{ReservationId: id, ToStatus: domain.ReservationStatusPending},
+				{ReservationId: id, FromStatus: &pending, ToStatus: domain.ReservationStatusConfirmed},
+			}, nil
+		}
+
+		ctx := context.Background()
+		reservationService := ReservationService{ReservationRepository: mock}
+		details, err := reservationService.GetReservationService(ctx, "user-1", domain.RoleUser, "res-1")
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "res-1", details.Id, "should return the reservation")
+		assert.Len(t, details.History, 2, "should return the timeline")
+	})
+
+	t.Run("should not show other users' reservations", func(t *testing.T) {
+		mock := reservationByIdRepo(domain.Reservation{Id: "res-1", UserId: "user-1"})
+
+		ctx := context.Background()
+		reservationService := ReservationService{ReservationRepository: mock}
+		_, err := reservationService.GetReservationService(ctx, "user-2", domain.RoleUser, "res-1")
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "reservation does not belong to user", err.Error(), "should return correct message")
+	})
+}
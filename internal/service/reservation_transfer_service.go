@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const swapOfferTTL = 24 * time.Hour
+
+func (repo *ReservationService) TransferReservationService(
+	ctx context.Context,
+	userId string,
+	reservationId string,
+	data domain.TransferReservation,
+) error {
+	log := pkg.GetLogger()
+
+	log.Info("Processing transfer of reservation: %s to: %s", reservationId, data.Email)
+
+	reservation, err := getTransferableReservation(ctx, repo, reservationId)
+	if err != nil {
+		return err
+	}
+
+	if reservation.UserId != userId {
+		return pkg.NewForbiddenError("reservation does not belong to user")
+	}
+
+	colleague, err := repo.UserRepository.FindUserByEmail(ctx, data.Email)
+	if err != nil {
+		log.Error("Error to find colleague by email: %v", err)
+		return err
+	}
+
+	if colleague == nil {
+		return pkg.NewNotFoundError("user not found")
+	}
+
+	if err := checkUserActive(colleague); err != nil {
+		return err
+	}
+
+	if colleague.Id == userId {
+		return pkg.NewBadRequestError("cannot transfer reservation to yourself")
+	}
+
+	transferred, err := repo.ReservationRepository.TransferReservation(ctx, domain.ReservationTransfer{
+		Id:         reservation.Id,
+		FromUserId: userId,
+		ToUserId:   colleague.Id,
+		Date:       reservation.Date,
+		DailyLimit: repo.Policy.MaxReservationsPerDay,
+	})
+	if err != nil {
+		log.Error("Error to transfer reservation: %v", err)
+		return err
+	}
+
+	if !transferred {
+		return pkg.NewBadRequestError("reservation could not be transferred")
+	}
+
+	after := *reservation
+	after.UserId = colleague.Id
+
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:    &userId,
+		Action:     domain.AuditActionReservationTransferred,
+		TargetType: domain.AuditTargetReservation,
+		TargetId:   reservation.Id,
+		Before:     reservationSnapshot(*reservation),
+		After:      reservationSnapshot(after),
+	})
+
+	log.Info("reservation transferred successfully")
+	return nil
+}
+
+func (repo *ReservationService) ProposeSwapService(
+	ctx context.Context,
+	userId string,
+	reservationId string,
+	data domain.ProposeSwap,
+) (*domain.SwapOffer, error) {
+	log := pkg.GetLogger()
+
+	log.Info("Processing swap proposal for reservation: %s", reservationId)
+
+	reservation, err := getTransferableReservation(ctx, repo, reservationId)
+	if err != nil {
+		return nil, err
+	}
+
+	if reservation.UserId != userId {
+		return nil, pkg.NewForbiddenError("reservation does not belong to user")
+	}
+
+	counter, err := getTransferableReservation(ctx, repo, data.CounterReservationId)
+	if err != nil {
+		return nil, err
+	}
+
+	if counter.UserId == userId {
+		return nil, pkg.NewBadRequestError("cannot swap with your own reservation")
+	}
+
+	if reservation.Date.Format("2006-01-02") != counter.Date.Format("2006-01-02") {
+		return nil, pkg.NewBadRequestError("reservations must be on the same day")
+	}
+
+	offer, err := repo.SwapOfferRepository.SaveSwapOffer(ctx, domain.CreateSwapOffer{
+		ReservationId:        reservation.Id,
+		CounterReservationId: counter.Id,
+		FromUserId:           userId,
+		ToUserId:             counter.UserId,
+		ExpiresAt:            time.Now().Add(swapOfferTTL),
+	})
+	if err != nil {
+		log.Error("Error saving swap offer to database: %v", err)
+		return nil, err
+	}
+
+	log.Info("swap offer created successfully")
+	return offer, nil
+}
+
+func (repo *ReservationService) ListSwapOffersService(
+	ctx context.Context,
+	userId string,
+) ([]domain.SwapOffer, error) {
+	log := pkg.GetLogger()
+
+	offers, err := repo.SwapOfferRepository.FindPendingSwapOffersByUser(ctx, userId)
+	if err != nil {
+		log.Error("Error to find swap offers: %v", err)
+		return nil, err
+	}
+
+	return offers, nil
+}
+
+func (repo *ReservationService) AcceptSwapOfferService(
+	ctx context.Context,
+	userId string,
+	offerId string,
+) error {
+	log := pkg.GetLogger()
+
+	log.Info("Processing acceptance of swap offer: %s", offerId)
+
+	offer, err := getPendingSwapOffer(ctx, repo, userId, offerId)
+	if err != nil {
+		return err
+	}
+
+	reservation, err := getTransferableReservation(ctx, repo, offer.ReservationId)
+	if err != nil {
+		return err
+	}
+
+	counter, err := getTransferableReservation(ctx, repo, offer.CounterReservationId)
+	if err != nil {
+		return err
+	}
+
+	if !reservation.Date.Equal(counter.Date) {
+		return pkg.NewBadRequestError("reservations must be on the same day")
+	}
+
+	proposer, err := repo.UserRepository.FindUserById(ctx, offer.FromUserId)
+	if err != nil {
+		log.Error("Error to find swap proposer: %v", err)
+		return err
+	}
+
+	if proposer == nil {
+		return pkg.NewNotFoundError("user not found")
+	}
+
+	if err := checkUserActive(proposer); err != nil {
+		return err
+	}
+
+	swapped, err := repo.ReservationRepository.SwapReservationOwners(ctx, domain.ReservationSwap{
+		Offer:      *offer,
+		Date:       reservation.Date,
+		DailyLimit: repo.Policy.MaxReservationsPerDay,
+	})
+	if err != nil {
+		log.Error("Error to swap reservations: %v", err)
+		return err
+	}
+
+	if !swapped {
+		return pkg.NewBadRequestError("swap offer is no longer valid")
+	}
+
+	owners := []struct{ reservationId, from, to string }{
+		{offer.ReservationId, offer.FromUserId, offer.ToUserId},
+		{offer.CounterReservationId, offer.ToUserId, offer.FromUserId},
+	}
+	for _, owner := range owners {
+		recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+			ActorId:    &userId,
+			Action:     domain.AuditActionReservationSwapped,
+			TargetType: domain.AuditTargetReservation,
+			TargetId:   owner.reservationId,
+			Before:     map[string]any{"user_id": owner.from},
+			After:      map[string]any{"user_id": owner.to},
+			Details:    map[string]any{"swap_offer_id": offer.Id},
+		})
+	}
+
+	log.Info("swap offer accepted successfully")
+	return nil
+}
+
+func (repo *ReservationService) DeclineSwapOfferService(
+	ctx context.Context,
+	userId string,
+	offerId string,
+) error {
+	log := pkg.GetLogger()
+
+	log.Info("Processing decline of swap offer: %s", offerId)
+
+	offer, err := getPendingSwapOffer(ctx, repo, userId, offerId)
+	if err != nil {
+		return err
+	}
+
+	declined, err := repo.SwapOfferRepository.UpdateSwapOfferStatus(ctx, offer.Id, "declined")
+	if err != nil {
+		log.Error("Error to decline swap offer: %v", err)
+		return err
+	}
+
+	if !declined {
+		return pkg.NewBadRequestError("swap offer is no longer pending")
+	}
+
+	log.Info("swap offer declined successfully")
+	return nil
+}
+
+func getTransferableReservation(
+	ctx context.Context,
+	repo *ReservationService,
+	reservationId string,
+) (*domain.Reservation, error) {
+	log := pkg.GetLogger()
+
+	reservation, err := repo.ReservationRepository.FindReservationById(ctx, reservationId)
+	if err != nil {
+		log.Error("Error to find reservation: %v", err)
+		return nil, err
+	}
+
+	if reservation == nil {
+		return nil, pkg.NewNotFoundError("reservation not found")
+	}
+
+	if reservation.Status != domain.ReservationStatusPending &&
+		reservation.Status != domain.ReservationStatusConfirmed {
+		return nil, pkg.NewBadRequestError("reservation is not active")
+	}
+
+	if reservation.GuestId != nil {
+		return nil, pkg.NewBadRequestError("guest reservations cannot be transferred")
+	}
+
+	desk, err := findReservableDesk(ctx, repo, reservation.DeskId)
+	if err != nil {
+		return nil, err
+	}
+
+	if reservation.Date.Before(domain.LocalDate(time.Now(), desk.Location())) {
+		return nil, pkg.NewBadRequestError("past reservations cannot be transferred")
+	}
+
+	return reservation, nil
+}
+
+func getPendingSwapOffer(
+	ctx context.Context,
+	repo *ReservationService,
+	userId string,
+	offerId string,
+) (*domain.SwapOffer, error) {
+	log := pkg.GetLogger()
+
+	offer, err := repo.SwapOfferRepository.FindSwapOfferById(ctx, offerId)
+	if err != nil {
+		log.Error("Error to find swap offer: %v", err)
+		return nil, err
+	}
+
+	if offer == nil {
+		return nil, pkg.NewNotFoundError("swap offer not found")
+	}
+
+	if offer.ToUserId != userId {
+		return nil, pkg.NewForbiddenError("swap offer is not addressed to user")
+	}
+
+	if offer.Status != "pending" {
+		return nil, pkg.NewBadRequestError("swap offer is no longer pending")
+	}
+
+	if time.Now().After(offer.ExpiresAt) {
+		if _, err := repo.SwapOfferRepository.UpdateSwapOfferStatus(ctx, offer.Id, "expired"); err != nil {
+			log.Error("Error to expire swap offer: %v", err)
+			return nil, err
+		}
+		return nil, pkg.NewBadRequestError("swap offer has expired")
+	}
+
+	return offer, nil
+}
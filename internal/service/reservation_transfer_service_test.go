@@ -0,0 +1,451 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type swapOfferRepo struct {
+	SaveSwapOfferFunc               func(ctx context.Context, offer domain.CreateSwapOffer) (*domain.SwapOffer, error)
+	FindSwapOfferByIdFunc           func(ctx context.Context, id string) (*domain.SwapOffer, error)
+	FindPendingSwapOffersByUserFunc func(ctx context.Context, userId string) ([]domain.SwapOffer, error)
+	UpdateSwapOfferStatusFunc       func(ctx context.Context, id string, status string) (bool, error)
+}
+
+func (r *swapOfferRepo) SaveSwapOffer(
+	ctx context.Context,
+	offer domain.CreateSwapOffer,
+) (*domain.SwapOffer, error) {
+	return r.SaveSwapOfferFunc(ctx, offer)
+}
+
+func (r *swapOfferRepo) FindSwapOfferById(ctx context.Context, id string) (*domain.SwapOffer, error) {
+	return r.FindSwapOfferByIdFunc(ctx, id)
+}
+
+func (r *swapOfferRepo) FindPendingSwapOffersByUser(
+	ctx context.Context,
+	userId string,
+) ([]domain.SwapOffer, error) {
+	return r.FindPendingSwapOffersByUserFunc(ctx, userId)
+}
+
+func (r *swapOfferRepo) UpdateSwapOfferStatus(ctx context.Context, id string, status string) (bool, error) {
+	return r.UpdateSwapOfferStatusFunc(ctx, id, status)
+}
+
+func TestTransferReservationService(t *testing.T) {
+	parsedTime := domain.LocalDate(time.Now(), time.UTC).AddDate(0, 0, 1)
+	ownerId := "1a162e27-45ff-4632-817a-a79e88c8f878"
+	colleagueId := "9c8f1bd3-2e44-4d36-9a1b-0e3b2b0c6b11"
+	data := domain.TransferReservation{Email: "colleague@example.com"}
+
+	findReservation := func(ctx context.Context, id string) (*domain.Reservation, error) {
+		return &domain.Reservation{Id: id, UserId: ownerId, Status: "confirmed", Date: parsedTime}, nil
+	}
+	findColleague := func(ctx context.Context, email string) (*domain.User, error) {
+		return &domain.User{Id: colleagueId, Email: email}, nil
+	}
+
+	t.Run("should transfer reservation to colleague", func(t *testing.T) {
+		var transferredTo string
+		var audited domain.CreateAuditLog
+
+		mock := &reservationRepo{
+			FindReservationByIdFunc: findReservation,
+			TransferReservationFunc: func(ctx context.Context, transfer domain.ReservationTransfer) (bool, error) {
+				transferredTo = transfer.ToUserId
+				return true, nil
+			},
+		}
+
+		audits := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = entry
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			UserRepository:        &userRepo{findUserByEmailFunc: findColleague},
+			AuditLogRepository:    audits,
+		}
+		err := reservation.TransferReservationService(ctx, ownerId, "res-1", data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, colleagueId, transferredTo, "should transfer to the colleague")
+		assert.Equal(t, domain.AuditActionReservationTransferred, audited.Action, "should audit the transfer")
+		assert.Equal(t, ownerId, *audited.ActorId, "should record the owner as actor")
+		assert.Equal(t, colleagueId, audited.After.(map[string]any)["user_id"], "should record the new owner")
+	})
+
+	t.Run("should reject transfer of another user's reservation", func(t *testing.T) {
+		mock := &reservationRepo{FindReservationByIdFunc: findReservation}
+
+		ctx := context.Background()
+		reservation := ReservationService{ReservationRepository: mock, DeskRepository: openDeskRepo()}
+		err := reservation.TransferReservationService(ctx, colleagueId, "res-1", data)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "reservation does not belong to user", err.Error(), "should return correct message")
+	})
+
+	t.Run("should reject transfer when reservation changed meanwhile", func(t *testing.T) {
+		mock := &reservationRepo{
+			FindReservationByIdFunc: findReservation,
+			TransferReservationFunc: func(ctx context.Context, transfer domain.ReservationTransfer) (bool, error) {
+				return false, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			UserRepository:        &userRepo{findUserByEmailFunc: findColleague},
+		}
+		err := reservation.TransferReservationService(ctx, ownerId, "res-1", data)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "reservation could not be transferred", err.Error(), "should return correct message")
+	})
+
+	t.Run("should reject transfer to a deactivated colleague", func(t *testing.T) {
+		deactivatedAt := time.Now()
+		users := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return &domain.User{Id: colleagueId, Email: email, DeactivatedAt: &deactivatedAt}, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: &reservationRepo{FindReservationByIdFunc: findReservation},
+			DeskRepository:        openDeskRepo(),
+			UserRepository:        users,
+		}
+		err := reservation.TransferReservationService(ctx, ownerId, "res-1", data)
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "user account is deactivated", err.Error(), "should return correct message")
+	})
+
+	t.Run("should reject transfer of a past reservation", func(t *testing.T) {
+		mock := &reservationRepo{
+			FindReservationByIdFunc: func(ctx context.Context, id string) (*domain.Reservation, error) {
+				return &domain.Reservation{Id: id, UserId: ownerId, Status: "confirmed", Date: parsedTime.AddDate(0, 0, -3)}, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{ReservationRepository: mock, DeskRepository: openDeskRepo()}
+		err := reservation.TransferReservationService(ctx, ownerId, "res-1", data)
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "past reservations cannot be transferred", err.Error(), "should return correct message")
+	})
+
+	t.Run("should pass the daily limit to the transfer", func(t *testing.T) {
+		var transfer domain.ReservationTransfer
+
+		mock := &reservationRepo{
+			FindReservationByIdFunc: findReservation,
+			TransferReservationFunc: func(ctx context.Context, data domain.ReservationTransfer) (bool, error) {
+				transfer = data
+				return false, pkg.NewBadRequestError("daily reservation limit reached")
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			UserRepository:        &userRepo{findUserByEmailFunc: findColleague},
+			Policy:                domain.ReservationPolicy{MaxReservationsPerDay: 1},
+		}
+		err := reservation.TransferReservationService(ctx, ownerId, "res-1", data)
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, 1, transfer.DailyLimit, "should enforce the limit in the transfer")
+		assert.Equal(t, parsedTime, transfer.Date, "should pin the reservation day")
+	})
+}
+
+func TestProposeSwapService(t *testing.T) {
+	ownerId := "1a162e27-45ff-4632-817a-a79e88c8f878"
+	colleagueId := "9c8f1bd3-2e44-4d36-9a1b-0e3b2b0c6b11"
+
+	t.Run("should create swap offer for reservations on the same day", func(t *testing.T) {
+		day := domain.LocalDate(time.Now(), time.UTC).AddDate(0, 0, 1)
+		var saved domain.CreateSwapOffer
+
+		mock := &reservationRepo{
+			FindReservationByIdFunc: func(ctx context.Context, id string) (*domain.Reservation, error) {
+				if id == "mine" {
+					return &domain.Reservation{Id: id, UserId: ownerId, Status: "confirmed", Date: day}, nil
+				}
+				return &domain.Reservation{Id: id, UserId: colleagueId, Status: "confirmed", Date: day}, nil
+			},
+		}
+		offers := &swapOfferRepo{
+			SaveSwapOfferFunc: func(
+				ctx context.Context,
+				offer domain.CreateSwapOffer,
+			) (*domain.SwapOffer, error) {
+				saved = offer
+				return &domain.SwapOffer{Id: "offer-1", Status: "pending"}, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			SwapOfferRepository:   offers,
+		}
+		offer, err := reservation.ProposeSwapService(
+			ctx,
+			ownerId,
+			"mine",
+			domain.ProposeSwap{CounterReservationId: "theirs"},
+		)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "offer-1", offer.Id, "should return saved offer")
+		assert.Equal(t, colleagueId, saved.ToUserId, "should address the offer to the colleague")
+		assert.True(t, saved.ExpiresAt.After(time.Now()), "should expire in the future")
+	})
+
+	t.Run("should reject swap across different days", func(t *testing.T) {
+		mock := &reservationRepo{
+			FindReservationByIdFunc: func(ctx context.Context, id string) (*domain.Reservation, error) {
+				day := domain.LocalDate(time.Now(), time.UTC).AddDate(0, 0, 1)
+				if id == "mine" {
+					return &domain.Reservation{Id: id, UserId: ownerId, Status: "confirmed", Date: day}, nil
+				}
+				return &domain.Reservation{
+					Id:     id,
+					UserId: colleagueId,
+					Status: "confirmed",
+					Date:   day.AddDate(0, 0, 1),
+				}, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{ReservationRepository: mock, DeskRepository: openDeskRepo()}
+		_, err := reservation.ProposeSwapService(
+			ctx,
+			ownerId,
+			"mine",
+			domain.ProposeSwap{CounterReservationId: "theirs"},
+		)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "reservations must be on the same day", err.Error(), "should return correct message")
+	})
+}
+
+func TestAcceptSwapOfferService(t *testing.T) {
+	colleagueId := "9c8f1bd3-2e44-4d36-9a1b-0e3b2b0c6b11"
+	proposerId := "1a162e27-45ff-4632-817a-a79e88c8f878"
+	day := domain.LocalDate(time.Now(), time.UTC).AddDate(0, 0, 1)
+
+	findReservation := func(ctx context.Context, id string) (*domain.Reservation, error) {
+		if id == "res-1" {
+			return &domain.Reservation{Id: id, UserId: proposerId, Status: "confirmed", Date: day}, nil
+		}
+		return &domain.Reservation{Id: id, UserId: colleagueId, Status: "confirmed", Date: day}, nil
+	}
+	activeProposer := &userRepo{
+		findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+			return &domain.User{Id: id}, nil
+		},
+	}
+	pendingOffer := func() *swapOfferRepo {
+		return &swapOfferRepo{
+			FindSwapOfferByIdFunc: func(ctx context.Context, id string) (*domain.SwapOffer, error) {
+				return &domain.SwapOffer{
+					Id:                   id,
+					ReservationId:        "res-1",
+					CounterReservationId: "res-2",
+					FromUserId:           proposerId,
+					ToUserId:             colleagueId,
+					Status:               "pending",
+					ExpiresAt:            time.Now().Add(time.Hour),
+				}, nil
+			},
+		}
+	}
+
+	t.Run("should swap reservation owners", func(t *testing.T) {
+		audited := []domain.CreateAuditLog{}
+		var swap domain.ReservationSwap
+
+		mock := &reservationRepo{
+			FindReservationByIdFunc: findReservation,
+			SwapReservationOwnersFunc: func(ctx context.Context, data domain.ReservationSwap) (bool, error) {
+				swap = data
+				return true, nil
+			},
+		}
+
+		audits := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = append(audited, entry)
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			UserRepository:        activeProposer,
+			SwapOfferRepository:   pendingOffer(),
+			AuditLogRepository:    audits,
+			Policy:                domain.ReservationPolicy{MaxReservationsPerDay: 1},
+		}
+		err := reservation.AcceptSwapOfferService(ctx, colleagueId, "offer-1")
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, day, swap.Date, "should pin the reservation day")
+		assert.Equal(t, 1, swap.DailyLimit, "should enforce the limit in the swap")
+		assert.Len(t, audited, 2, "should audit both reservations")
+		assert.Equal(t, "res-1", audited[0].TargetId, "should audit the offered reservation")
+		assert.Equal(t, "res-2", audited[1].TargetId, "should audit the counter reservation")
+	})
+
+	t.Run("should reject offers from a deactivated proposer", func(t *testing.T) {
+		deletedAt := time.Now()
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id, DeletedAt: &deletedAt}, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: &reservationRepo{FindReservationByIdFunc: findReservation},
+			DeskRepository:        openDeskRepo(),
+			UserRepository:        users,
+			SwapOfferRepository:   pendingOffer(),
+		}
+		err := reservation.AcceptSwapOfferService(ctx, colleagueId, "offer-1")
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "user account is deactivated", err.Error(), "should return correct message")
+	})
+
+	t.Run("should reject swaps of past reservations", func(t *testing.T) {
+		mock := &reservationRepo{
+			FindReservationByIdFunc: func(ctx context.Context, id string) (*domain.Reservation, error) {
+				return &domain.Reservation{Id: id, Status: "confirmed", Date: day.AddDate(0, 0, -3)}, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			SwapOfferRepository:   pendingOffer(),
+		}
+		err := reservation.AcceptSwapOfferService(ctx, colleagueId, "offer-1")
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "past reservations cannot be transferred", err.Error(), "should return correct message")
+	})
+
+	t.Run("should expire stale offers", func(t *testing.T) {
+		var status string
+
+		offers := &swapOfferRepo{
+			FindSwapOfferByIdFunc: func(ctx context.Context, id string) (*domain.SwapOffer, error) {
+				return &domain.SwapOffer{
+					Id:        id,
+					ToUserId:  colleagueId,
+					Status:    "pending",
+					ExpiresAt: time.Now().Add(-time.Hour),
+				}, nil
+			},
+			UpdateSwapOfferStatusFunc: func(ctx context.Context, id string, newStatus string) (bool, error) {
+				status = newStatus
+				return true, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{SwapOfferRepository: offers}
+		err := reservation.AcceptSwapOfferService(ctx, colleagueId, "offer-1")
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "swap offer has expired", err.Error(), "should return correct message")
+		assert.Equal(t, "expired", status, "should mark offer as expired")
+	})
+
+	t.Run("should reject offers addressed to someone else", func(t *testing.T) {
+		offers := &swapOfferRepo{
+			FindSwapOfferByIdFunc: func(ctx context.Context, id string) (*domain.SwapOffer, error) {
+				return &domain.SwapOffer{Id: id, ToUserId: colleagueId, Status: "pending"}, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{SwapOfferRepository: offers}
+		err := reservation.AcceptSwapOfferService(ctx, "someone-else", "offer-1")
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "swap offer is not addressed to user", err.Error(), "should return correct message")
+	})
+}
+
+func TestDeclineSwapOfferService(t *testing.T) {
+	colleagueId := "9c8f1bd3-2e44-4d36-9a1b-0e3b2b0c6b11"
+	findOffer := func(ctx context.Context, id string) (*domain.SwapOffer, error) {
+		return &domain.SwapOffer{Id: id, ToUserId: colleagueId, Status: "pending", ExpiresAt: time.Now().Add(time.Hour)}, nil
+	}
+
+	t.Run("should decline pending offers", func(t *testing.T) {
+		var status string
+
+		offers := &swapOfferRepo{
+			FindSwapOfferByIdFunc: findOffer,
+			UpdateSwapOfferStatusFunc: func(ctx context.Context, id string, newStatus string) (bool, error) {
+				status = newStatus
+				return true, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{SwapOfferRepository: offers}
+		err := reservation.DeclineSwapOfferService(ctx, colleagueId, "offer-1")
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "declined", status, "should mark offer as declined")
+	})
+
+	t.Run("should not overwrite an offer accepted in the meantime", func(t *testing.T) {
+		offers := &swapOfferRepo{
+			FindSwapOfferByIdFunc: findOffer,
+			UpdateSwapOfferStatusFunc: func(ctx context.Context, id string, newStatus string) (bool, error) {
+				return false, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservation := ReservationService{SwapOfferRepository: offers}
+		err := reservation.DeclineSwapOfferService(ctx, colleagueId, "offer-1")
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "swap offer is no longer pending", err.Error(), "should return correct message")
+	})
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func (repo *ReservationService) UpdateReservationService(
+	ctx context.Context,
+	actorId string,
+	actorRole string,
+	reservationId string,
+	data domain.UpdateReservation,
+) error {
+	log := pkg.GetLogger()
+
+	log.Info("Processing update of reservation: %s", reservationId)
+
+	reservation, err := findReservation(ctx, repo, reservationId)
+	if err != nil {
+		return err
+	}
+
+	if reservation.UserId != actorId && actorRole != domain.RoleAdmin {
+		return pkg.NewForbiddenError("reservation does not belong to user")
+	}
+
+	if reservation.Status != domain.ReservationStatusPending && reservation.Status != domain.ReservationStatusConfirmed {
+		return pkg.NewBadRequestError("reservation is not active")
+	}
+
+	if reservation.CheckedInAt != nil {
+		return pkg.NewBadRequestError("checked in reservations cannot be moved")
+	}
+
+	currentDesk, err := findReservableDesk(ctx, repo, reservation.DeskId)
+	if err != nil {
+		return err
+	}
+
+	if reservation.Date.Before(domain.LocalDate(time.Now(), currentDesk.Location())) {
+		return pkg.NewBadRequestError("past reservations cannot be moved")
+	}
+
+	desk := currentDesk
+	if data.DeskId != nil && *data.DeskId != reservation.DeskId {
+		desk, err = findReservableDesk(ctx, repo, *data.DeskId)
+		if err != nil {
+			return err
+		}
+	}
+
+	date := reservation.Date
+	if data.Date != nil {
+		date = domain.LocalDate(*data.Date, desk.Location())
+	}
+
+	if desk.Id == reservation.DeskId && date.Equal(reservation.Date) {
+		return pkg.NewBadRequestError("reservation already has this desk and date")
+	}
+
+	if date.Before(domain.LocalDate(time.Now(), desk.Location())) {
+		return pkg.NewBadRequestError("reservations cannot be moved into the past")
+	}
+
+	// Staying on the same day keeps the user's count for that day unchanged.
+	if !date.Equal(reservation.Date) {
+		if err := checkReservationPolicy(ctx, repo, reservation.UserId, date); err != nil {
+			return err
+		}
+	}
+
+	if err := checkLotteryAllocation(ctx, repo, desk, date); err != nil {
+		return err
+	}
+
+	status := resolveReservationStatus(desk)
+	if status != reservation.Status {
+		if err := checkReservationTransition(reservation.Status, status); err != nil {
+			return err
+		}
+	}
+
+	moved, err := repo.ReservationRepository.MoveReservation(ctx, domain.MoveReservation{
+		Id:         reservation.Id,
+		UserId:     reservation.UserId,
+		DeskId:     desk.Id,
+		Date:       date,
+		FromStatus: reservation.Status,
+		Status:     status,
+		ActorId:    actorId,
+		DailyLimit: repo.Policy.MaxReservationsPerDay,
+	})
+	if err != nil {
+		log.Error("Error to move reservation: %v", err)
+		return err
+	}
+
+	if !moved {
+		return pkg.NewBadRequestError("desk is unavailable")
+	}
+
+	after := *reservation
+	after.DeskId = desk.Id
+	after.Date = date
+	after.Status = status
+
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:    &actorId,
+		Action:     domain.AuditActionReservationMoved,
+		TargetType: domain.AuditTargetReservation,
+		TargetId:   reservation.Id,
+		Before:     reservationSnapshot(*reservation),
+		After:      reservationSnapshot(after),
+	})
+
+	if status == domain.ReservationStatusPending {
+		notifyApprovers(ctx, repo, desk.Id, date)
+	}
+
+	log.Info("reservation moved successfully")
+	return nil
+}
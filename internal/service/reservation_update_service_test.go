@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+func TestUpdateReservationService(t *testing.T) {
+	today := domain.LocalDate(time.Now(), time.UTC)
+	tomorrow := today.AddDate(0, 0, 1)
+	newDeskId := "desk-2"
+
+	confirmed := domain.Reservation{
+		Id:     "res-1",
+		DeskId: "desk-1",
+		UserId: "user-1",
+		Date:   today,
+		Status: domain.ReservationStatusConfirmed,
+	}
+
+	t.Run("should move reservation to another desk and date", func(t *testing.T) {
+		var move domain.MoveReservation
+		var audited []domain.CreateAuditLog
+
+		mock := reservationByIdRepo(confirmed)
+		mock.MoveReservationFunc = func(ctx context.Context, m domain.MoveReservation) (bool, error) {
+			move = m
+			return true, nil
+		}
+		audit := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = append(audited, entry)
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		reservationService := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			AuditLogRepository:    audit,
+		}
+		err := reservationService.UpdateReservationService(ctx, "user-1", domain.RoleUser, "res-1", domain.UpdateReservation{
+			DeskId: &newDeskId,
+			Date:   &tomorrow,
+		})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, newDeskId, move.DeskId, "should move to the new desk")
+		assert.Equal(t, tomorrow, move.Date, "should move to the new date")
+		assert.Equal(t, domain.ReservationStatusConfirmed, move.FromStatus, "should guard on the current status")
+		assert.Len(t, audited, 1, "should audit the move")
+		assert.Equal(t, domain.AuditActionReservationMoved, audited[0].Action)
+	})
+
+	t.Run("should send reservation back for approval on a restricted desk", func(t *testing.T) {
+		var move domain.MoveReservation
+
+		mock := reservationByIdRepo(confirmed)
+		mock.MoveReservationFunc = func(ctx context.Context, m domain.MoveReservation) (bool, error) {
+			move = m
+			return true, nil
+		}
+		desks := &deskRepo{
+			FindDeskByIdFunc: func(ctx context.Context, id string) (*domain.Desk, error) {
+				return &domain.Desk{Id: id, RequiresApproval: id == newDeskId}, nil
+			},
+		}
+
+		ctx := context.Background()
+		reservationService := ReservationService{ReservationRepository: mock, DeskRepository: desks}
+		err := reservationService.UpdateReservationService(ctx, "user-1", domain.RoleUser, "res-1", domain.UpdateReservation{
+			DeskId: &newDeskId,
+		})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, domain.ReservationStatusPending, move.Status, "should need approval again")
+	})
+
+	t.Run("should keep reservation when new desk is taken", func(t *testing.T) {
+		mock := reservationByIdRepo(confirmed)
+		mock.MoveReservationFunc = func(ctx context.Context, m domain.MoveReservation) (bool, error) {
+			return false, nil
+		}
+
+		ctx := context.Background()
+		reservationService := ReservationService{ReservationRepository: mock, DeskRepository: openDeskRepo()}
+		err := reservationService.UpdateReservationService(ctx, "user-1", domain.RoleUser, "res-1", domain.UpdateReservation{
+			DeskId: &newDeskId,
+		})
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "desk is unavailable", err.Error(), "should return correct message")
+	})
+
+	t.Run("should enforce daily limit on the new date", func(t *testing.T) {
+		mock := reservationByIdRepo(confirmed)
+		mock.CountUserReservationsByDateFunc = func(ctx context.Context, userId string, date time.Time) (int, error) {
+			return 1, nil
+		}
+
+		ctx := context.Background()
+		reservationService := ReservationService{
+			ReservationRepository: mock,
+			DeskRepository:        openDeskRepo(),
+			Policy:                domain.ReservationPolicy{MaxReservationsPerDay: 1},
+		}
+		err := reservationService.UpdateReservationService(ctx, "user-1", domain.RoleUser, "res-1", domain.UpdateReservation{
+			Date: &tomorrow,
+		})
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "daily reservation limit reached", err.Error(), "should return correct message")
+	})
+
+	t.Run("should not move checked in reservations", func(t *testing.T) {
+		checkedIn := confirmed
+		checkedIn.CheckedInAt = &today
+
+		ctx := context.Background()
+		reservationService := ReservationService{ReservationRepository: reservationByIdRepo(checkedIn)}
+		err := reservationService.UpdateReservationService(ctx, "user-1", domain.RoleUser, "res-1", domain.UpdateReservation{
+			Date: &tomorrow,
+		})
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "checked in reservations cannot be moved", err.Error(), "should return correct message")
+	})
+
+	t.Run("should not let other users move the reservation", func(t *testing.T) {
+		ctx := context.Background()
+		reservationService := ReservationService{ReservationRepository: reservationByIdRepo(confirmed)}
+		err := reservationService.UpdateReservationService(ctx, "user-2", domain.RoleUser, "res-1", domain.UpdateReservation{
+			Date: &tomorrow,
+		})
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "reservation does not belong to user", err.Error(), "should return correct message")
+	})
+}
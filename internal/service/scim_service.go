@@ -0,0 +1,558 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const scimMaxPageSize = 100
+
+var (
+	scimUserNameFilter = regexp.MustCompile(`(?i)^\s*userName\s+eq\s+"((?:[^"\\]|\\.)*)"\s*$`)
+	scimMemberPath     = regexp.MustCompile(`(?i)^members\[\s*value\s+eq\s+"([^"]*)"\s*\]$`)
+	scimGroupRoles     = []string{domain.RoleUser, domain.RoleApprover, domain.RoleReception, domain.RoleAdmin}
+)
+
+type ScimService struct {
+	UserRepository     domain.UserRepositoryInterface
+	AuditLogRepository domain.AuditLogRepositoryInterface
+}
+
+func (repo *ScimService) ListScimUsersService(
+	ctx context.Context,
+	query domain.ScimListQuery,
+) (*domain.ScimListResponse, error) {
+	log := pkg.GetLogger()
+
+	startIndex := max(query.StartIndex, 1)
+	count := query.Count
+	if count <= 0 || count > scimMaxPageSize {
+		count = scimMaxPageSize
+	}
+
+	var users []domain.User
+	total := 0
+
+	if query.Filter != "" {
+		userName, err := parseScimUserNameFilter(query.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err := repo.UserRepository.FindUserByEmail(ctx, userName)
+		if err != nil {
+			log.Error("Error to find user by email: %v", err)
+			return nil, err
+		}
+
+		if user != nil {
+			total = 1
+			if startIndex == 1 {
+				users = append(users, *user)
+			}
+		}
+	} else {
+		found, foundTotal, err := repo.UserRepository.ListUsers(ctx, startIndex-1, count)
+		if err != nil {
+			log.Error("Error to list users: %v", err)
+			return nil, err
+		}
+		users, total = found, foundTotal
+	}
+
+	resources := make([]any, 0, len(users))
+	for i := range users {
+		resources = append(resources, toScimUser(&users[i]))
+	}
+
+	return &domain.ScimListResponse{
+		Schemas:      []string{domain.ScimListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+func (repo *ScimService) GetScimUserService(ctx context.Context, id string) (*domain.ScimUser, error) {
+	user, err := getScimUser(ctx, repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toScimUser(user), nil
+}
+
+func (repo *ScimService) CreateScimUserService(
+	ctx context.Context,
+	data domain.ScimUser,
+) (*domain.ScimUser, error) {
+	log := pkg.GetLogger()
+
+	provision := domain.ProvisionUser{
+		Name:  scimDisplayName(data),
+		Email: scimPrimaryEmail(data),
+	}
+	if data.ExternalId != "" {
+		provision.ExternalId = &data.ExternalId
+	}
+
+	if _, err := mail.ParseAddress(provision.Email); err != nil {
+		return nil, pkg.NewBadRequestError("userName or primary email must be a valid email address")
+	}
+
+	existing, err := repo.UserRepository.FindUserByEmail(ctx, provision.Email)
+	if err != nil {
+		log.Error("Error to find user by email: %v", err)
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, pkg.NewConflictError("user already exists", nil)
+	}
+
+	user, err := repo.UserRepository.SaveProvisionedUser(ctx, provision)
+	if err != nil {
+		log.Error("Error to save provisioned user: %v", err)
+		return nil, err
+	}
+
+	log.Info("Provisioned user %s via SCIM", user.Id)
+
+	if data.Active != nil && !*data.Active {
+		return repo.setScimUserActive(ctx, user, false)
+	}
+
+	return toScimUser(user), nil
+}
+
+func (repo *ScimService) PatchScimUserService(
+	ctx context.Context,
+	id string,
+	patch domain.ScimPatch,
+) (*domain.ScimUser, error) {
+	log := pkg.GetLogger()
+
+	user, err := getScimUser(ctx, repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	provision := domain.ProvisionUser{Name: user.Name, Email: user.Email, ExternalId: user.ExternalId}
+	var active *bool
+
+	for _, operation := range patch.Operations {
+		switch strings.ToLower(operation.Op) {
+		case "add", "replace":
+			if err := applyScimUserPatch(&provision, &active, operation.Path, operation.Value); err != nil {
+				return nil, err
+			}
+		case "remove":
+			if !strings.EqualFold(operation.Path, "externalId") {
+				return nil, pkg.NewBadRequestError("unsupported remove path: " + operation.Path)
+			}
+			provision.ExternalId = nil
+		default:
+			return nil, pkg.NewBadRequestError("unsupported patch operation: " + operation.Op)
+		}
+	}
+
+	if _, err := mail.ParseAddress(provision.Email); err != nil {
+		return nil, pkg.NewBadRequestError("userName must be a valid email address")
+	}
+
+	updated, err := repo.UserRepository.UpdateProvisionedUser(ctx, id, provision)
+	if err != nil {
+		log.Error("Error to update provisioned user: %v", err)
+		return nil, err
+	}
+
+	if updated == nil {
+		return nil, pkg.NewNotFoundError("user not found")
+	}
+
+	if active != nil {
+		return repo.setScimUserActive(ctx, updated, *active)
+	}
+
+	return toScimUser(updated), nil
+}
+
+func (repo *ScimService) DeactivateScimUserService(ctx context.Context, id string) error {
+	user, err := getScimUser(ctx, repo, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.setScimUserActive(ctx, user, false)
+	return err
+}
+
+func (repo *ScimService) ListScimGroupsService(ctx context.Context) (*domain.ScimListResponse, error) {
+	resources := make([]any, 0, len(scimGroupRoles))
+
+	for _, role := range scimGroupRoles {
+		group, err := repo.GetScimGroupService(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, group)
+	}
+
+	return &domain.ScimListResponse{
+		Schemas:      []string{domain.ScimListResponseSchema},
+		TotalResults: len(resources),
+		StartIndex:   1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+func (repo *ScimService) GetScimGroupService(ctx context.Context, id string) (*domain.ScimGroup, error) {
+	log := pkg.GetLogger()
+
+	if !slices.Contains(scimGroupRoles, id) {
+		return nil, pkg.NewNotFoundError("group not found")
+	}
+
+	users, err := repo.UserRepository.FindUsersByRole(ctx, id)
+	if err != nil {
+		log.Error("Error to find users by role: %v", err)
+		return nil, err
+	}
+
+	members := make([]domain.ScimMember, 0, len(users))
+	for _, user := range users {
+		members = append(members, domain.ScimMember{Value: user.Id, Display: user.Name})
+	}
+
+	return &domain.ScimGroup{
+		Schemas:     []string{domain.ScimGroupSchema},
+		Id:          id,
+		DisplayName: id,
+		Members:     members,
+		Meta: &domain.ScimMeta{
+			ResourceType: "Group",
+			Location:     "/scim/v2/Groups/" + id,
+		},
+	}, nil
+}
+
+func (repo *ScimService) PatchScimGroupService(
+	ctx context.Context,
+	id string,
+	patch domain.ScimPatch,
+) (*domain.ScimGroup, error) {
+	log := pkg.GetLogger()
+
+	group, err := repo.GetScimGroupService(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	current := map[string]bool{}
+	for _, member := range group.Members {
+		current[member.Value] = true
+	}
+
+	for _, operation := range patch.Operations {
+		op := strings.ToLower(operation.Op)
+
+		if op == "remove" {
+			matches := scimMemberPath.FindStringSubmatch(operation.Path)
+			if matches == nil {
+				return nil, pkg.NewBadRequestError("unsupported remove path: " + operation.Path)
+			}
+			if err := repo.removeScimGroupMember(ctx, id, matches[1], current); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if (op != "add" && op != "replace") || !strings.EqualFold(operation.Path, "members") {
+			return nil, pkg.NewBadRequestError("unsupported group patch: " + operation.Op + " " + operation.Path)
+		}
+
+		var members []domain.ScimMember
+		if err := json.Unmarshal(operation.Value, &members); err != nil {
+			return nil, pkg.NewBadRequestError("members must be a list of member references")
+		}
+
+		requested := map[string]bool{}
+		for _, member := range members {
+			requested[member.Value] = true
+			if err := repo.setScimUserRole(ctx, member.Value, id); err != nil {
+				return nil, err
+			}
+			current[member.Value] = true
+		}
+
+		if op == "replace" {
+			for memberId := range current {
+				if !requested[memberId] {
+					if err := repo.removeScimGroupMember(ctx, id, memberId, current); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+
+	log.Info("Updated SCIM group %s membership", id)
+	return repo.GetScimGroupService(ctx, id)
+}
+
+func (repo *ScimService) setScimUserActive(
+	ctx context.Context,
+	user *domain.User,
+	active bool,
+) (*domain.ScimUser, error) {
+	log := pkg.GetLogger()
+
+	action := ""
+	details := map[string]any{"source": "scim"}
+
+	if active && user.DeactivatedAt != nil {
+		if _, err := repo.UserRepository.ReactivateUser(ctx, user.Id); err != nil {
+			log.Error("Error to reactivate user: %v", err)
+			return nil, err
+		}
+		action = domain.AuditActionUserReactivated
+		log.Info("Reactivated user %s via SCIM", user.Id)
+	}
+
+	if !active && user.DeactivatedAt == nil {
+		cancelled, err := repo.UserRepository.DeactivateUser(ctx, user.Id)
+		if err != nil {
+			log.Error("Error to deactivate user: %v", err)
+			return nil, err
+		}
+		action = domain.AuditActionUserDeactivated
+		details["cancelled_reservations"] = cancelled
+		log.Info("Deactivated user %s via SCIM, cancelled %d reservations", user.Id, cancelled)
+	}
+
+	updated, err := getScimUser(ctx, repo, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if action != "" {
+		recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+			Action:     action,
+			TargetType: domain.AuditTargetUser,
+			TargetId:   user.Id,
+			Before:     user,
+			After:      updated,
+			Details:    details,
+		})
+	}
+
+	return toScimUser(updated), nil
+}
+
+func (repo *ScimService) setScimUserRole(ctx context.Context, userId string, role string) error {
+	log := pkg.GetLogger()
+
+	user, err := repo.UserRepository.FindUserById(ctx, userId)
+	if err != nil {
+		log.Error("Error to find user by id: %v", err)
+		return err
+	}
+
+	if user == nil {
+		return pkg.NewNotFoundError("user not found: " + userId)
+	}
+
+	if user.Role == role {
+		return nil
+	}
+
+	updated, err := repo.UserRepository.UpdateUserRole(ctx, userId, role)
+	if err != nil {
+		log.Error("Error to update user role: %v", err)
+		return err
+	}
+
+	if !updated {
+		return pkg.NewNotFoundError("user not found: " + userId)
+	}
+
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		Action:     domain.AuditActionUserRoleChanged,
+		TargetType: domain.AuditTargetUser,
+		TargetId:   userId,
+		Before:     map[string]any{"role": user.Role},
+		After:      map[string]any{"role": role},
+		Details:    map[string]any{"source": "scim"},
+	})
+
+	return nil
+}
+
+func (repo *ScimService) removeScimGroupMember(
+	ctx context.Context,
+	role string,
+	userId string,
+	current map[string]bool,
+) error {
+	if !current[userId] || role == domain.RoleUser {
+		return nil
+	}
+
+	delete(current, userId)
+	return repo.setScimUserRole(ctx, userId, domain.RoleUser)
+}
+
+func getScimUser(ctx context.Context, repo *ScimService, id string) (*domain.User, error) {
+	log := pkg.GetLogger()
+
+	user, err := repo.UserRepository.FindUserById(ctx, id)
+	if err != nil {
+		log.Error("Error to find user by id: %v", err)
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, pkg.NewNotFoundError("user not found")
+	}
+
+	return user, nil
+}
+
+func applyScimUserPatch(
+	provision *domain.ProvisionUser,
+	active **bool,
+	path string,
+	value json.RawMessage,
+) error {
+	if path == "" {
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(value, &attributes); err != nil {
+			return pkg.NewBadRequestError("patch value must be an object when path is omitted")
+		}
+
+		for attribute, attributeValue := range attributes {
+			if err := applyScimUserPatch(provision, active, attribute, attributeValue); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	attribute := strings.ToLower(path)
+
+	switch {
+	case attribute == "active":
+		var enabled bool
+		if err := json.Unmarshal(value, &enabled); err != nil {
+			return pkg.NewBadRequestError("active must be a boolean")
+		}
+		*active = &enabled
+	case attribute == "username":
+		return unmarshalScimString(value, &provision.Email, path)
+	case attribute == "displayname", attribute == "name.formatted":
+		return unmarshalScimString(value, &provision.Name, path)
+	case attribute == "externalid":
+		var externalId string
+		if err := unmarshalScimString(value, &externalId, path); err != nil {
+			return err
+		}
+		provision.ExternalId = &externalId
+	case attribute == "emails":
+		var emails []domain.ScimEmail
+		if err := json.Unmarshal(value, &emails); err != nil {
+			return pkg.NewBadRequestError("emails must be a list")
+		}
+		if email := scimPrimaryEmail(domain.ScimUser{Emails: emails}); email != "" {
+			provision.Email = email
+		}
+	case strings.HasPrefix(attribute, "emails["):
+		return unmarshalScimString(value, &provision.Email, path)
+	}
+
+	return nil
+}
+
+func unmarshalScimString(value json.RawMessage, target *string, path string) error {
+	if err := json.Unmarshal(value, target); err != nil {
+		return pkg.NewBadRequestError(path + " must be a string")
+	}
+
+	return nil
+}
+
+func parseScimUserNameFilter(filter string) (string, error) {
+	matches := scimUserNameFilter.FindStringSubmatch(filter)
+	if matches == nil {
+		return "", pkg.NewBadRequestError("unsupported filter, only userName eq is supported")
+	}
+
+	var userName string
+	if err := json.Unmarshal([]byte(`"`+matches[1]+`"`), &userName); err != nil {
+		return "", pkg.NewBadRequestError("invalid filter value")
+	}
+
+	return userName, nil
+}
+
+func scimPrimaryEmail(data domain.ScimUser) string {
+	for _, email := range data.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+
+	if len(data.Emails) > 0 && data.UserName == "" {
+		return data.Emails[0].Value
+	}
+
+	return data.UserName
+}
+
+func scimDisplayName(data domain.ScimUser) string {
+	switch {
+	case data.DisplayName != "":
+		return data.DisplayName
+	case data.Name != nil && data.Name.Formatted != "":
+		return data.Name.Formatted
+	case data.Name != nil && (data.Name.GivenName != "" || data.Name.FamilyName != ""):
+		return strings.TrimSpace(data.Name.GivenName + " " + data.Name.FamilyName)
+	}
+
+	return data.UserName
+}
+
+func toScimUser(user *domain.User) *domain.ScimUser {
+	active := user.DeactivatedAt == nil
+
+	scimUser := &domain.ScimUser{
+		Schemas:     []string{domain.ScimUserSchema},
+		Id:          user.Id,
+		UserName:    user.Email,
+		Name:        &domain.ScimName{Formatted: user.Name},
+		DisplayName: user.Name,
+		Emails:      []domain.ScimEmail{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Groups:      []domain.ScimMember{{Value: user.Role, Display: user.Role}},
+		Meta: &domain.ScimMeta{
+			ResourceType: "User",
+			Created:      &user.Created_at,
+			LastModified: &user.Updated_at,
+			Location:     "/scim/v2/Users/" + user.Id,
+		},
+	}
+
+	if user.ExternalId != nil {
+		scimUser.ExternalId = *user.ExternalId
+	}
+
+	return scimUser
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+func TestListScimUsersService(t *testing.T) {
+	t.Run("should filter users by userName", func(t *testing.T) {
+		var lookedUp string
+
+		users := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				lookedUp = email
+				return &domain.User{Id: "user-1", Email: email, Role: domain.RoleUser}, nil
+			},
+		}
+
+		ctx := context.Background()
+		scimService := ScimService{UserRepository: users}
+		response, err := scimService.ListScimUsersService(ctx, domain.ScimListQuery{
+			Filter: `userName eq "jane@example.com"`,
+		})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "jane@example.com", lookedUp, "should look up by userName")
+		assert.Equal(t, 1, response.TotalResults, "should return one result")
+		assert.Equal(t, "jane@example.com", response.Resources[0].(*domain.ScimUser).UserName)
+	})
+
+	t.Run("should page through users", func(t *testing.T) {
+		var offset, limit int
+
+		users := &userRepo{
+			listUsersFunc: func(ctx context.Context, o int, l int) ([]domain.User, int, error) {
+				offset, limit = o, l
+				return []domain.User{{Id: "user-3"}}, 3, nil
+			},
+		}
+
+		ctx := context.Background()
+		scimService := ScimService{UserRepository: users}
+		response, err := scimService.ListScimUsersService(ctx, domain.ScimListQuery{StartIndex: 3, Count: 1})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, 2, offset, "should convert startIndex to offset")
+		assert.Equal(t, 1, limit, "should use count as limit")
+		assert.Equal(t, 3, response.TotalResults, "should report total")
+		assert.Equal(t, 1, response.ItemsPerPage, "should report page size")
+	})
+
+	t.Run("should reject unsupported filter", func(t *testing.T) {
+		ctx := context.Background()
+		scimService := ScimService{UserRepository: &userRepo{}}
+		_, err := scimService.ListScimUsersService(ctx, domain.ScimListQuery{Filter: `title co "x"`})
+
+		assert.Error(t, err, "should return erro")
+		assert.IsType(t, &pkg.BadRequestError{}, err, "should be bad request")
+	})
+}
+
+func TestCreateScimUserService(t *testing.T) {
+	data := domain.ScimUser{
+		UserName:   "jane@example.com",
+		ExternalId: "hr-42",
+		Name:       &domain.ScimName{GivenName: "Jane", FamilyName: "Doe"},
+	}
+
+	t.Run("should provision user", func(t *testing.T) {
+		var saved domain.ProvisionUser
+
+		users := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return nil, nil
+			},
+			saveProvisionedFunc: func(ctx context.Context, user domain.ProvisionUser) (*domain.User, error) {
+				saved = user
+				return &domain.User{Id: "user-1", Name: user.Name, Email: user.Email, ExternalId: user.ExternalId}, nil
+			},
+		}
+
+		ctx := context.Background()
+		scimService := ScimService{UserRepository: users}
+		user, err := scimService.CreateScimUserService(ctx, data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "Jane Doe", saved.Name, "should build name from given and family name")
+		assert.Equal(t, "hr-42", *saved.ExternalId, "should store externalId")
+		assert.True(t, *user.Active, "should be active")
+	})
+
+	t.Run("should return conflict for existing user", func(t *testing.T) {
+		users := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return &domain.User{Id: "user-1", Email: email}, nil
+			},
+		}
+
+		ctx := context.Background()
+		scimService := ScimService{UserRepository: users}
+		_, err := scimService.CreateScimUserService(ctx, data)
+
+		assert.Error(t, err, "should return erro")
+		assert.IsType(t, &pkg.ConflictError{}, err, "should be conflict")
+	})
+}
+
+func TestPatchScimUserService(t *testing.T) {
+	deactivatedAt := time.Now()
+
+	t.Run("should deactivate user", func(t *testing.T) {
+		deactivated := false
+		var audited domain.CreateAuditLog
+
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				user := &domain.User{Id: id, Email: "jane@example.com"}
+				if deactivated {
+					user.DeactivatedAt = &deactivatedAt
+				}
+				return user, nil
+			},
+			updateProvisionedFunc: func(ctx context.Context, id string, user domain.ProvisionUser) (*domain.User, error) {
+				return &domain.User{Id: id, Name: user.Name, Email: user.Email}, nil
+			},
+			deactivateUserFunc: func(ctx context.Context, id string) (int, error) {
+				deactivated = true
+				return 2, nil
+			},
+		}
+		patch := domain.ScimPatch{Operations: []domain.ScimPatchOperation{
+			{Op: "Replace", Path: "active", Value: json.RawMessage(`false`)},
+		}}
+
+		audits := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = entry
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		scimService := ScimService{UserRepository: users, AuditLogRepository: audits}
+		user, err := scimService.PatchScimUserService(ctx, "user-1", patch)
+
+		assert.NoError(t, err, "should not return error")
+		assert.True(t, deactivated, "should deactivate the user")
+		assert.False(t, *user.Active, "should report inactive")
+		assert.Equal(t, domain.AuditActionUserDeactivated, audited.Action, "should audit the deactivation")
+		assert.Equal(t, 2, audited.Details["cancelled_reservations"], "should record cancelled reservations")
+	})
+
+	t.Run("should apply attributes without path", func(t *testing.T) {
+		var updated domain.ProvisionUser
+
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id, Name: "Jane", Email: "jane@example.com"}, nil
+			},
+			updateProvisionedFunc: func(ctx context.Context, id string, user domain.ProvisionUser) (*domain.User, error) {
+				updated = user
+				return &domain.User{Id: id, Name: user.Name, Email: user.Email}, nil
+			},
+		}
+		patch := domain.ScimPatch{Operations: []domain.ScimPatchOperation{
+			{Op: "replace", Value: json.RawMessage(`{"displayName":"Jane Smith","userName":"jane.smith@example.com","title":"Engineer"}`)},
+		}}
+
+		ctx := context.Background()
+		scimService := ScimService{UserRepository: users}
+		_, err := scimService.PatchScimUserService(ctx, "user-1", patch)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "Jane Smith", updated.Name, "should update name")
+		assert.Equal(t, "jane.smith@example.com", updated.Email, "should update email")
+	})
+
+	t.Run("should return not found for unknown user", func(t *testing.T) {
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return nil, nil
+			},
+		}
+
+		ctx := context.Background()
+		scimService := ScimService{UserRepository: users}
+		_, err := scimService.PatchScimUserService(ctx, "user-1", domain.ScimPatch{})
+
+		assert.IsType(t, &pkg.NotFoundError{}, err, "should be not found")
+	})
+}
+
+func TestPatchScimGroupService(t *testing.T) {
+	t.Run("should add and remove group members", func(t *testing.T) {
+		roles := map[string]string{"user-1": domain.RoleApprover, "user-2": domain.RoleUser}
+		audited := []domain.CreateAuditLog{}
+
+		users := &userRepo{
+			findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{Id: id, Role: roles[id]}, nil
+			},
+			findUsersByRoleFunc: func(ctx context.Context, role string) ([]domain.User, error) {
+				members := []domain.User{}
+				for id, userRole := range roles {
+					if userRole == role {
+						members = append(members, domain.User{Id: id})
+					}
+				}
+				return members, nil
+			},
+			updateUserRoleFunc: func(ctx context.Context, id string, role string) (bool, error) {
+				roles[id] = role
+				return true, nil
+			},
+		}
+		patch := domain.ScimPatch{Operations: []domain.ScimPatchOperation{
+			{Op: "add", Path: "members", Value: json.RawMessage(`[{"value":"user-2"}]`)},
+			{Op: "remove", Path: `members[value eq "user-1"]`},
+		}}
+
+		audits := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = append(audited, entry)
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		scimService := ScimService{UserRepository: users, AuditLogRepository: audits}
+		group, err := scimService.PatchScimGroupService(ctx, domain.RoleApprover, patch)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, domain.RoleApprover, roles["user-2"], "should grant the role")
+		assert.Equal(t, domain.RoleUser, roles["user-1"], "should revoke the role")
+		assert.Len(t, group.Members, 1, "should return updated members")
+		assert.Len(t, audited, 2, "should audit each role change")
+		assert.Equal(t, domain.AuditActionUserRoleChanged, audited[0].Action, "should record role change")
+		assert.Equal(t, map[string]any{"role": domain.RoleUser}, audited[0].Before, "should record previous role")
+	})
+
+	t.Run("should return not found for unknown group", func(t *testing.T) {
+		ctx := context.Background()
+		scimService := ScimService{UserRepository: &userRepo{}}
+		_, err := scimService.PatchScimGroupService(ctx, "superusers", domain.ScimPatch{})
+
+		assert.IsType(t, &pkg.NotFoundError{}, err, "should be not found")
+	})
+}
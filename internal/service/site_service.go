@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type SiteService struct {
+	SiteRepository     domain.SiteRepositoryInterface
+	AuditLogRepository domain.AuditLogRepositoryInterface
+}
+
+func (repo *SiteService) UpdateSiteTimeZoneService(
+	ctx context.Context,
+	actorId string,
+	siteId string,
+	data domain.UpdateSiteTimeZone,
+) error {
+	log := pkg.GetLogger()
+
+	log.Info("Processing time zone update for site: %s", siteId)
+
+	if err := validateTimeZone(data.TimeZone); err != nil {
+		return err
+	}
+
+	site, err := repo.SiteRepository.FindSiteById(ctx, siteId)
+	if err != nil {
+		log.Error("Error to find site by id: %v", err)
+		return err
+	}
+
+	if site == nil {
+		return pkg.NewNotFoundError("site not found")
+	}
+
+	updated, err := repo.SiteRepository.UpdateSiteTimeZone(ctx, siteId, data.TimeZone)
+	if err != nil {
+		log.Error("Error to update site: %v", err)
+		return err
+	}
+
+	if !updated {
+		return pkg.NewNotFoundError("site not found")
+	}
+
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:    &actorId,
+		Action:     domain.AuditActionSiteUpdated,
+		TargetType: domain.AuditTargetSite,
+		TargetId:   siteId,
+		Before:     map[string]any{"time_zone": site.TimeZone},
+		After:      map[string]any{"time_zone": data.TimeZone},
+	})
+
+	log.Info("site updated successfully")
+	return nil
+}
+
+// validateTimeZone accepts IANA names only; "Local" would follow whatever zone
+// the server happens to run in.
+func validateTimeZone(name string) error {
+	if name == "" || name == "Local" {
+		return pkg.NewBadRequestError("invalid time zone")
+	}
+
+	if _, err := time.LoadLocation(name); err != nil {
+		return pkg.NewBadRequestError("invalid time zone")
+	}
+
+	return nil
+}
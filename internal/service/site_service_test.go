@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+)
+
+func TestUpdateSiteTimeZoneService(t *testing.T) {
+	t.Run("should update the time zone and audit the change", func(t *testing.T) {
+		var saved string
+		var audited domain.CreateAuditLog
+
+		sites := &siteRepo{
+			FindSiteByIdFunc: func(ctx context.Context, id string) (*domain.Site, error) {
+				return &domain.Site{Id: id, TimeZone: "UTC"}, nil
+			},
+			UpdateSiteTimeZoneFunc: func(ctx context.Context, id string, timeZone string) (bool, error) {
+				saved = timeZone
+				return true, nil
+			},
+		}
+		audit := &auditLogRepo{
+			SaveAuditLogFunc: func(ctx context.Context, entry domain.CreateAuditLog) error {
+				audited = entry
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		siteService := SiteService{SiteRepository: sites, AuditLogRepository: audit}
+		err := siteService.UpdateSiteTimeZoneService(ctx, "admin-1", "site-1", domain.UpdateSiteTimeZone{
+			TimeZone: "Europe/Lisbon",
+		})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "Europe/Lisbon", saved, "should store the new time zone")
+		assert.Equal(t, domain.AuditActionSiteUpdated, audited.Action, "should audit the update")
+		assert.Equal(t, "UTC", audited.Before.(map[string]any)["time_zone"], "should record the previous time zone")
+	})
+
+	t.Run("should reject unknown time zones", func(t *testing.T) {
+		for _, timeZone := range []string{"Mars/Olympus_Mons", "Local"} {
+			sites := &siteRepo{
+				FindSiteByIdFunc: func(ctx context.Context, id string) (*domain.Site, error) {
+					t.Fatal("should not load the site")
+					return nil, nil
+				},
+			}
+
+			ctx := context.Background()
+			siteService := SiteService{SiteRepository: sites}
+			err := siteService.UpdateSiteTimeZoneService(ctx, "admin-1", "site-1", domain.UpdateSiteTimeZone{
+				TimeZone: timeZone,
+			})
+
+			assert.Error(t, err, "should reject %s", timeZone)
+			assert.Equal(t, "invalid time zone", err.Error(), "should return correct message")
+		}
+	})
+
+	t.Run("should not update another tenant's site", func(t *testing.T) {
+		sites := &siteRepo{
+			FindSiteByIdFunc: func(ctx context.Context, id string) (*domain.Site, error) {
+				return nil, nil
+			},
+		}
+
+		ctx := context.Background()
+		siteService := SiteService{SiteRepository: sites}
+		err := siteService.UpdateSiteTimeZoneService(ctx, "admin-1", "site-1", domain.UpdateSiteTimeZone{
+			TimeZone: "Europe/Lisbon",
+		})
+
+		assert.Error(t, err, "should return error")
+		assert.Equal(t, "site not found", err.Error(), "should return correct message")
+	})
+}
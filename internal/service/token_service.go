@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+const refreshTokenTTL = 30 * 24 * time.Hour
+
+type TokenService struct {
+	UserRepository  domain.UserRepositoryInterface
+	TokenRepository domain.TokenRepositoryInterface
+}
+
+func (repo *TokenService) RefreshTokenService(
+	ctx context.Context,
+	data domain.RefreshTokenRequest,
+) (*domain.LoginResponse, error) {
+	log := pkg.GetLogger()
+
+	current, err := repo.TokenRepository.FindRefreshToken(ctx, pkg.HashToken(data.RefreshToken))
+	if err != nil {
+		log.Error("Error to find refresh token: %v", err)
+		return nil, err
+	}
+
+	if current == nil {
+		return nil, pkg.NewUnauthorizedError("invalid refresh token")
+	}
+
+	if current.RevokedAt != nil {
+		log.Warn("Refresh token reuse detected for user: %s", current.UserId)
+		if err := repo.TokenRepository.RevokeTokenFamily(ctx, current.FamilyId); err != nil {
+			log.Error("Error to revoke token family: %v", err)
+			return nil, err
+		}
+		return nil, pkg.NewUnauthorizedError("invalid refresh token")
+	}
+
+	if time.Now().After(current.ExpiresAt) {
+		return nil, pkg.NewUnauthorizedError("refresh token has expired")
+	}
+
+	user, err := repo.UserRepository.FindUserById(ctx, current.UserId)
+	if err != nil {
+		log.Error("Error to find user by id: %v", err)
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, pkg.NewUnauthorizedError("invalid refresh token")
+	}
+
+	if err := checkUserActive(user); err != nil {
+		return nil, err
+	}
+
+	refreshToken, record, err := newRefreshToken(user.Id, current.FamilyId)
+	if err != nil {
+		log.Error("Error generating refresh token: %v", err)
+		return nil, pkg.NewInternalServerError("failed to generate refresh token", err)
+	}
+
+	rotated, err := repo.TokenRepository.RotateRefreshToken(ctx, current.Id, record)
+	if err != nil {
+		log.Error("Error to rotate refresh token: %v", err)
+		return nil, err
+	}
+
+	if !rotated {
+		log.Warn("Concurrent refresh token use detected for user: %s", user.Id)
+		if err := repo.TokenRepository.RevokeTokenFamily(ctx, current.FamilyId); err != nil {
+			log.Error("Error to revoke token family: %v", err)
+			return nil, err
+		}
+		return nil, pkg.NewUnauthorizedError("invalid refresh token")
+	}
+
+	log.Info("Refresh token rotated for user: %s", user.Id)
+	return buildLoginResponse(user, refreshToken)
+}
+
+func (repo *TokenService) LogoutService(
+	ctx context.Context,
+	userId string,
+	jti string,
+	expiresAt time.Time,
+	data domain.Logout,
+) error {
+	log := pkg.GetLogger()
+
+	log.Info("Processing logout for user: %s", userId)
+
+	if err := repo.TokenRepository.RevokeAccessToken(ctx, jti, expiresAt); err != nil {
+		log.Error("Error to revoke access token: %v", err)
+		return err
+	}
+
+	if data.RefreshToken == "" {
+		return nil
+	}
+
+	current, err := repo.TokenRepository.FindRefreshToken(ctx, pkg.HashToken(data.RefreshToken))
+	if err != nil {
+		log.Error("Error to find refresh token: %v", err)
+		return err
+	}
+
+	if current == nil || current.UserId != userId {
+		return nil
+	}
+
+	if err := repo.TokenRepository.RevokeTokenFamily(ctx, current.FamilyId); err != nil {
+		log.Error("Error to revoke token family: %v", err)
+		return err
+	}
+
+	log.Info("User %s logged out successfully", userId)
+	return nil
+}
+
+func issueLoginTokens(
+	ctx context.Context,
+	tokenRepository domain.TokenRepositoryInterface,
+	user *domain.User,
+) (*domain.LoginResponse, error) {
+	log := pkg.GetLogger()
+
+	refreshToken, record, err := newRefreshToken(user.Id, "")
+	if err != nil {
+		log.Error("Error generating refresh token: %v", err)
+		return nil, pkg.NewInternalServerError("failed to generate refresh token", err)
+	}
+
+	if err := tokenRepository.SaveRefreshToken(ctx, record); err != nil {
+		log.Error("Error saving refresh token: %v", err)
+		return nil, err
+	}
+
+	return buildLoginResponse(user, refreshToken)
+}
+
+func newRefreshToken(userId, familyId string) (string, domain.CreateRefreshToken, error) {
+	token, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return "", domain.CreateRefreshToken{}, err
+	}
+
+	return token, domain.CreateRefreshToken{
+		UserId:    userId,
+		FamilyId:  familyId,
+		TokenHash: pkg.HashToken(token),
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	}, nil
+}
+
+func buildLoginResponse(user *domain.User, refreshToken string) (*domain.LoginResponse, error) {
+	log := pkg.GetLogger()
+
+	token, err := pkg.GenerateJWT(user.Id, user.Email, user.Role, user.OrganisationId)
+	if err != nil {
+		log.Error("Error generating JWT token: %v", err)
+		return nil, pkg.NewInternalServerError("failed to generate JWT token", err)
+	}
+
+	return &domain.LoginResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(pkg.AccessTokenTTL().Seconds()),
+	}, nil
+}
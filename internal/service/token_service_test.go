@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tufee/desk-reservation-go/internal/domain"
+	pkg "github.com/tufee/desk-reservation-go/pkg/utils"
+)
+
+type tokenRepo struct {
+	SaveRefreshTokenFunc     func(ctx context.Context, token domain.CreateRefreshToken) error
+	FindRefreshTokenFunc     func(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	RotateRefreshTokenFunc   func(ctx context.Context, currentId string, token domain.CreateRefreshToken) (bool, error)
+	RevokeTokenFamilyFunc    func(ctx context.Context, familyId string) error
+	RevokeAccessTokenFunc    func(ctx context.Context, jti string, expiresAt time.Time) error
+	IsAccessTokenRevokedFunc func(ctx context.Context, jti string) (bool, error)
+}
+
+func (r *tokenRepo) SaveRefreshToken(ctx context.Context, token domain.CreateRefreshToken) error {
+	return r.SaveRefreshTokenFunc(ctx, token)
+}
+
+func (r *tokenRepo) FindRefreshToken(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	return r.FindRefreshTokenFunc(ctx, tokenHash)
+}
+
+func (r *tokenRepo) RotateRefreshToken(
+	ctx context.Context,
+	currentId string,
+	token domain.CreateRefreshToken,
+) (bool, error) {
+	return r.RotateRefreshTokenFunc(ctx, currentId, token)
+}
+
+func (r *tokenRepo) RevokeTokenFamily(ctx context.Context, familyId string) error {
+	return r.RevokeTokenFamilyFunc(ctx, familyId)
+}
+
+func (r *tokenRepo) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	return r.RevokeAccessTokenFunc(ctx, jti, expiresAt)
+}
+
+func (r *tokenRepo) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	return r.IsAccessTokenRevokedFunc(ctx, jti)
+}
+
+func TestRefreshTokenService(t *testing.T) {
+	userId := "1a162e27-45ff-4632-817a-a79e88c8f878"
+	data := domain.RefreshTokenRequest{RefreshToken: "refresh-token"}
+	users := &userRepo{
+		findUserByIdFunc: func(ctx context.Context, id string) (*domain.User, error) {
+			return &domain.User{Id: id, Email: "test@example.com", Role: domain.RoleUser}, nil
+		},
+	}
+
+	t.Run("should rotate refresh token in the same family", func(t *testing.T) {
+		var lookedUp string
+		var rotated domain.CreateRefreshToken
+
+		tokens := &tokenRepo{
+			FindRefreshTokenFunc: func(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+				lookedUp = tokenHash
+				return &domain.RefreshToken{
+					Id:        "token-1",
+					UserId:    userId,
+					FamilyId:  "family-1",
+					ExpiresAt: time.Now().Add(time.Hour),
+				}, nil
+			},
+			RotateRefreshTokenFunc: func(
+				ctx context.Context,
+				currentId string,
+				token domain.CreateRefreshToken,
+			) (bool, error) {
+				rotated = token
+				return true, nil
+			},
+		}
+
+		ctx := context.Background()
+		tokenService := TokenService{UserRepository: users, TokenRepository: tokens}
+		response, err := tokenService.RefreshTokenService(ctx, data)
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, pkg.HashToken(data.RefreshToken), lookedUp, "should look up the token hash")
+		assert.Equal(t, "family-1", rotated.FamilyId, "should keep the token family")
+		assert.Equal(t, pkg.HashToken(response.RefreshToken), rotated.TokenHash, "should store only the hash")
+		assert.NotEmpty(t, response.Token, "should issue a new access token")
+	})
+
+	t.Run("should revoke the family when a revoked token is reused", func(t *testing.T) {
+		revokedAt := time.Now().Add(-time.Minute)
+		var revokedFamily string
+
+		tokens := &tokenRepo{
+			FindRefreshTokenFunc: func(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+				return &domain.RefreshToken{
+					Id:        "token-1",
+					UserId:    userId,
+					FamilyId:  "family-1",
+					ExpiresAt: time.Now().Add(time.Hour),
+					RevokedAt: &revokedAt,
+				}, nil
+			},
+			RevokeTokenFamilyFunc: func(ctx context.Context, familyId string) error {
+				revokedFamily = familyId
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		tokenService := TokenService{UserRepository: users, TokenRepository: tokens}
+		_, err := tokenService.RefreshTokenService(ctx, data)
+
+		assert.Error(t, err, "should return erro")
+		assert.IsType(t, &pkg.UnauthorizedError{}, err, "should be unauthorized")
+		assert.Equal(t, "family-1", revokedFamily, "should revoke the whole family")
+	})
+
+	t.Run("should reject expired refresh tokens", func(t *testing.T) {
+		tokens := &tokenRepo{
+			FindRefreshTokenFunc: func(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+				return &domain.RefreshToken{
+					Id:        "token-1",
+					UserId:    userId,
+					FamilyId:  "family-1",
+					ExpiresAt: time.Now().Add(-time.Hour),
+				}, nil
+			},
+		}
+
+		ctx := context.Background()
+		tokenService := TokenService{UserRepository: users, TokenRepository: tokens}
+		_, err := tokenService.RefreshTokenService(ctx, data)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "refresh token has expired", err.Error(), "should return correct message")
+	})
+
+	t.Run("should reject unknown refresh tokens", func(t *testing.T) {
+		tokens := &tokenRepo{
+			FindRefreshTokenFunc: func(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+				return nil, nil
+			},
+		}
+
+		ctx := context.Background()
+		tokenService := TokenService{UserRepository: users, TokenRepository: tokens}
+		_, err := tokenService.RefreshTokenService(ctx, data)
+
+		assert.Error(t, err, "should return erro")
+		assert.Equal(t, "invalid refresh token", err.Error(), "should return correct message")
+	})
+}
+
+func TestLogoutService(t *testing.T) {
+	userId := "1a162e27-45ff-4632-817a-a79e88c8f878"
+	expiresAt := time.Now().Add(time.Minute)
+
+	t.Run("should revoke access token and refresh token family", func(t *testing.T) {
+		var revokedJti, revokedFamily string
+
+		tokens := &tokenRepo{
+			RevokeAccessTokenFunc: func(ctx context.Context, jti string, expires time.Time) error {
+				revokedJti = jti
+				return nil
+			},
+			FindRefreshTokenFunc: func(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+				return &domain.RefreshToken{Id: "token-1", UserId: userId, FamilyId: "family-1"}, nil
+			},
+			RevokeTokenFamilyFunc: func(ctx context.Context, familyId string) error {
+				revokedFamily = familyId
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		tokenService := TokenService{TokenRepository: tokens}
+		err := tokenService.LogoutService(ctx, userId, "jti-1", expiresAt, domain.Logout{RefreshToken: "refresh"})
+
+		assert.NoError(t, err, "should not return error")
+		assert.Equal(t, "jti-1", revokedJti, "should revoke the access token")
+		assert.Equal(t, "family-1", revokedFamily, "should revoke the refresh token family")
+	})
+
+	t.Run("should not revoke refresh tokens of another user", func(t *testing.T) {
+		familyRevoked := false
+
+		tokens := &tokenRepo{
+			RevokeAccessTokenFunc: func(ctx context.Context, jti string, expires time.Time) error {
+				return nil
+			},
+			FindRefreshTokenFunc: func(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+				return &domain.RefreshToken{Id: "token-1", UserId: "someone-else", FamilyId: "family-1"}, nil
+			},
+			RevokeTokenFamilyFunc: func(ctx context.Context, familyId string) error {
+				familyRevoked = true
+				return nil
+			},
+		}
+
+		ctx := context.Background()
+		tokenService := TokenService{TokenRepository: tokens}
+		err := tokenService.LogoutService(ctx, userId, "jti-1", expiresAt, domain.Logout{RefreshToken: "refresh"})
+
+		assert.NoError(t, err, "should not return error")
+		assert.False(t, familyRevoked, "should not touch other users' tokens")
+	})
+}
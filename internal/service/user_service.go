@@ -8,7 +8,10 @@ import (
 )
 
 type UserService struct {
-	UserRepository domain.UserRepositoryInterface
+	UserRepository     domain.UserRepositoryInterface
+	PasswordPolicy     domain.PasswordPolicy
+	BreachedPasswords  domain.BreachedPasswordCheckerInterface
+	AuditLogRepository domain.AuditLogRepositoryInterface
 }
 
 func (repo *UserService) CreateUserService(ctx context.Context, user domain.CreateUser) error {
@@ -20,6 +23,12 @@ func (repo *UserService) CreateUserService(ctx context.Context, user domain.Crea
 		return err
 	}
 
+	err := checkPasswordPolicy(ctx, repo.PasswordPolicy, repo.BreachedPasswords, user.Password, user.Email, user.Name)
+	if err != nil {
+		log.Info("Password rejected by policy for email: %s", user.Email)
+		return err
+	}
+
 	hashedPassword, err := pkg.HashPassword(user.Password)
 	if err != nil {
 		return pkg.NewInternalServerError("error processing user data", err)
@@ -27,11 +36,26 @@ func (repo *UserService) CreateUserService(ctx context.Context, user domain.Crea
 
 	user.Password = hashedPassword
 
-	if err := repo.UserRepository.SaveUser(ctx, user); err != nil {
+	saved, err := repo.UserRepository.SaveUser(ctx, user)
+	if err != nil {
 		log.Error("Error saving user to database: %v", err)
 		return err
 	}
 
+	if saved == nil {
+		log.Warn("User with email %s already exists", user.Email)
+		return pkg.NewBadRequestError("user already exists")
+	}
+
+	recordAudit(ctx, repo.AuditLogRepository, domain.CreateAuditLog{
+		ActorId:        &saved.Id,
+		Action:         domain.AuditActionUserCreated,
+		TargetType:     domain.AuditTargetUser,
+		TargetId:       saved.Id,
+		OrganisationId: saved.OrganisationId,
+		After:          saved,
+	})
+
 	log.Info("Successfully created user with email: %s", user.Email)
 	return nil
 }
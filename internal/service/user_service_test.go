@@ -12,18 +12,106 @@ import (
 )
 
 type userRepo struct {
-	findUserByEmailFunc func(ctx context.Context, email string) (*domain.User, error)
-	saveUserFunc        func(ctx context.Context, user domain.CreateUser) error
+	findUserByEmailFunc     func(ctx context.Context, email string) (*domain.User, error)
+	findUserByIdFunc        func(ctx context.Context, id string) (*domain.User, error)
+	saveUserFunc            func(ctx context.Context, user domain.CreateUser) (*domain.User, error)
+	upsertDirectoryUserFunc func(ctx context.Context, user domain.DirectoryUser) (*domain.User, error)
+	findUsersByRoleFunc     func(ctx context.Context, role string) ([]domain.User, error)
+	listUsersFunc           func(ctx context.Context, offset int, limit int) ([]domain.User, int, error)
+	saveProvisionedFunc     func(ctx context.Context, user domain.ProvisionUser) (*domain.User, error)
+	updateProvisionedFunc   func(ctx context.Context, id string, user domain.ProvisionUser) (*domain.User, error)
+	updateUserRoleFunc      func(ctx context.Context, id string, role string) (bool, error)
+	deactivateUserFunc      func(ctx context.Context, id string) (int, error)
+	reactivateUserFunc      func(ctx context.Context, id string) (bool, error)
+	updateUserPasswordFunc  func(ctx context.Context, id string, password string) error
+	markEmailVerifiedFunc   func(ctx context.Context, id string) error
+	updateUserProfileFunc   func(ctx context.Context, id string, profile domain.UserProfile) (*domain.User, error)
+	searchUsersFunc         func(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error)
+	updateUserTeamFunc      func(ctx context.Context, id string, team *string) (bool, error)
+	softDeleteUserFunc      func(ctx context.Context, id string) (int, error)
+	anonymizeUserFunc       func(ctx context.Context, id string, pseudonym domain.UserPseudonym) (bool, error)
 }
 
 func (m *userRepo) FindUserByEmail(ctx context.Context, email string) (*domain.User, error) {
 	return m.findUserByEmailFunc(ctx, email)
 }
 
-func (m *userRepo) SaveUser(ctx context.Context, user domain.CreateUser) error {
+func (m *userRepo) FindUserById(ctx context.Context, id string) (*domain.User, error) {
+	return m.findUserByIdFunc(ctx, id)
+}
+
+func (m *userRepo) SaveUser(ctx context.Context, user domain.CreateUser) (*domain.User, error) {
 	return m.saveUserFunc(ctx, user)
 }
 
+func (m *userRepo) UpsertDirectoryUser(ctx context.Context, user domain.DirectoryUser) (*domain.User, error) {
+	return m.upsertDirectoryUserFunc(ctx, user)
+}
+
+func (m *userRepo) FindUsersByRole(ctx context.Context, role string) ([]domain.User, error) {
+	return m.findUsersByRoleFunc(ctx, role)
+}
+
+func (m *userRepo) ListUsers(ctx context.Context, offset int, limit int) ([]domain.User, int, error) {
+	return m.listUsersFunc(ctx, offset, limit)
+}
+
+func (m *userRepo) SaveProvisionedUser(ctx context.Context, user domain.ProvisionUser) (*domain.User, error) {
+	return m.saveProvisionedFunc(ctx, user)
+}
+
+func (m *userRepo) UpdateProvisionedUser(
+	ctx context.Context,
+	id string,
+	user domain.ProvisionUser,
+) (*domain.User, error) {
+	return m.updateProvisionedFunc(ctx, id, user)
+}
+
+func (m *userRepo) UpdateUserRole(ctx context.Context, id string, role string) (bool, error) {
+	return m.updateUserRoleFunc(ctx, id, role)
+}
+
+func (m *userRepo) DeactivateUser(ctx context.Context, id string) (int, error) {
+	return m.deactivateUserFunc(ctx, id)
+}
+
+func (m *userRepo) ReactivateUser(ctx context.Context, id string) (bool, error) {
+	return m.reactivateUserFunc(ctx, id)
+}
+
+func (m *userRepo) UpdateUserPassword(ctx context.Context, id string, password string) error {
+	return m.updateUserPasswordFunc(ctx, id, password)
+}
+
+func (m *userRepo) MarkEmailVerified(ctx context.Context, id string) error {
+	return m.markEmailVerifiedFunc(ctx, id)
+}
+
+func (m *userRepo) UpdateUserProfile(
+	ctx context.Context,
+	id string,
+	profile domain.UserProfile,
+) (*domain.User, error) {
+	return m.updateUserProfileFunc(ctx, id, profile)
+}
+
+func (m *userRepo) SearchUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error) {
+	return m.searchUsersFunc(ctx, filter)
+}
+
+func (m *userRepo) UpdateUserTeam(ctx context.Context, id string, team *string) (bool, error) {
+	return m.updateUserTeamFunc(ctx, id, team)
+}
+
+func (m *userRepo) SoftDeleteUser(ctx context.Context, id string) (int, error) {
+	return m.softDeleteUserFunc(ctx, id)
+}
+
+func (m *userRepo) AnonymizeUser(ctx context.Context, id string, pseudonym domain.UserPseudonym) (bool, error) {
+	return m.anonymizeUserFunc(ctx, id, pseudonym)
+}
+
 func TestCreateUserService(t *testing.T) {
 	t.Run("should create user successfully", func(t *testing.T) {
 		ctx := context.Background()
@@ -37,8 +125,8 @@ func TestCreateUserService(t *testing.T) {
 			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
 				return nil, nil
 			},
-			saveUserFunc: func(ctx context.Context, user domain.CreateUser) error {
-				return nil
+			saveUserFunc: func(ctx context.Context, user domain.CreateUser) (*domain.User, error) {
+				return &domain.User{Id: "user-id", Email: user.Email}, nil
 			},
 		}
 
@@ -98,6 +186,28 @@ func TestCreateUserService(t *testing.T) {
 			"should return correct error message",
 		)
 	})
+
+	t.Run("should reject breached password", func(t *testing.T) {
+		user := domain.CreateUser{
+			Name:     "Test User",
+			Email:    "test@example.com",
+			Password: "Summer2025!",
+		}
+
+		mock := &userRepo{
+			findUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+				return nil, nil
+			},
+		}
+		breached := &breachedPasswordsMock{breached: map[string]bool{"Summer2025!": true}}
+
+		ctx := context.Background()
+		userService := UserService{UserRepository: mock, BreachedPasswords: breached}
+		err := userService.CreateUserService(ctx, user)
+
+		assert.IsType(t, &pkg.ValidationError{}, err, "should be validation error")
+		assert.Equal(t, "breached", err.(*pkg.ValidationError).Errors[0]["tag"], "should flag breached password")
+	})
 }
 
 func TestCheckExistingUser(t *testing.T) {
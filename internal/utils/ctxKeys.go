@@ -10,6 +10,14 @@ const (
 	LoginKey             ctxKey = "LoginKey"
 	AuthUserKey          ctxKey = "AuthUser"
 	AuthEmailKey         ctxKey = "AuthEmail"
+	AuthRoleKey          ctxKey = "AuthRole"
+	AuthTokenIdKey       ctxKey = "AuthTokenId"
+	AuthTokenExpiryKey   ctxKey = "AuthTokenExpiry"
+	AuthAPIKeyIdKey      ctxKey = "AuthAPIKeyId"
+	AuthTenantKey        ctxKey = "AuthTenant"
+	SystemScopeKey       ctxKey = "SystemScope"
+	RequestIdKey         ctxKey = "RequestId"
+	ClientIPKey          ctxKey = "ClientIP"
 )
 
 func SetContextValue[T any](ctx context.Context, key ctxKey, value T) context.Context {
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	t.Run("should use remote address by default", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/login", nil)
+		r.RemoteAddr = "10.0.0.5:52311"
+		r.Header.Set("X-Forwarded-For", "203.0.113.9")
+
+		if ip := ClientIP(r); ip != "10.0.0.5" {
+			t.Errorf("expected 10.0.0.5, got %s", ip)
+		}
+	})
+
+	t.Run("should use forwarded address when proxy headers are trusted", func(t *testing.T) {
+		t.Setenv("TRUST_PROXY_HEADERS", "true")
+
+		r := httptest.NewRequest("POST", "/login", nil)
+		r.RemoteAddr = "10.0.0.5:52311"
+		r.Header.Set("X-Forwarded-For", "203.0.113.9, 10.0.0.1")
+
+		if ip := ClientIP(r); ip != "203.0.113.9" {
+			t.Errorf("expected 203.0.113.9, got %s", ip)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+)
+
+func GetEnvInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		GetLogger().Warn("Invalid integer for %s: %s, using %d", key, value, fallback)
+		return fallback
+	}
+
+	return parsed
+}
+
+func GetEnvBool(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		GetLogger().Warn("Invalid boolean for %s: %s, using %t", key, value, fallback)
+		return fallback
+	}
+
+	return parsed
+}
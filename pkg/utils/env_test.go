@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"os"
+	"testing"
+)
+
+func TestGetEnvInt(t *testing.T) {
+	originalValue := os.Getenv("TEST_ENV_INT")
+	defer os.Setenv("TEST_ENV_INT", originalValue)
+
+	t.Run("should parse integer value", func(t *testing.T) {
+		os.Setenv("TEST_ENV_INT", "3")
+
+		value := GetEnvInt("TEST_ENV_INT", 1)
+
+		if value != 3 {
+			t.Errorf("Expected 3, got %d", value)
+		}
+	})
+
+	t.Run("should return fallback when variable is empty", func(t *testing.T) {
+		os.Setenv("TEST_ENV_INT", "")
+
+		value := GetEnvInt("TEST_ENV_INT", 1)
+
+		if value != 1 {
+			t.Errorf("Expected 1, got %d", value)
+		}
+	})
+
+	t.Run("should return fallback when variable is invalid", func(t *testing.T) {
+		os.Setenv("TEST_ENV_INT", "abc")
+
+		value := GetEnvInt("TEST_ENV_INT", 1)
+
+		if value != 1 {
+			t.Errorf("Expected 1, got %d", value)
+		}
+	})
+}
+
+func TestGetEnvBool(t *testing.T) {
+	t.Run("should parse boolean value", func(t *testing.T) {
+		t.Setenv("TEST_ENV_BOOL", "false")
+
+		if value := GetEnvBool("TEST_ENV_BOOL", true); value {
+			t.Errorf("Expected false, got %t", value)
+		}
+	})
+
+	t.Run("should return fallback for invalid value", func(t *testing.T) {
+		t.Setenv("TEST_ENV_BOOL", "maybe")
+
+		if value := GetEnvBool("TEST_ENV_BOOL", true); !value {
+			t.Errorf("Expected true, got %t", value)
+		}
+	})
+}
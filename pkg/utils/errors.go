@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -21,6 +23,42 @@ func NewBadRequestError(message string) *BadRequestError {
 	return &BadRequestError{Message: message}
 }
 
+type UnauthorizedError struct {
+	Message string
+}
+
+func (e *UnauthorizedError) Error() string {
+	return e.Message
+}
+
+func NewUnauthorizedError(message string) *UnauthorizedError {
+	return &UnauthorizedError{Message: message}
+}
+
+type NotFoundError struct {
+	Message string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Message
+}
+
+func NewNotFoundError(message string) *NotFoundError {
+	return &NotFoundError{Message: message}
+}
+
+type ForbiddenError struct {
+	Message string
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Message
+}
+
+func NewForbiddenError(message string) *ForbiddenError {
+	return &ForbiddenError{Message: message}
+}
+
 type InternalServerError struct {
 	Message string
 	Err     error
@@ -41,6 +79,54 @@ func NewInternalServerError(message string, err error) *InternalServerError {
 	}
 }
 
+type ConflictError struct {
+	Message   string
+	Conflicts any
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+func NewConflictError(message string, conflicts any) *ConflictError {
+	return &ConflictError{
+		Message:   message,
+		Conflicts: conflicts,
+	}
+}
+
+type ValidationError struct {
+	Message string
+	Errors  []map[string]string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+func NewValidationError(message string, errors []map[string]string) *ValidationError {
+	return &ValidationError{
+		Message: message,
+		Errors:  errors,
+	}
+}
+
+type TooManyRequestsError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *TooManyRequestsError) Error() string {
+	return e.Message
+}
+
+func NewTooManyRequestsError(message string, retryAfter time.Duration) *TooManyRequestsError {
+	return &TooManyRequestsError{
+		Message:    message,
+		RetryAfter: retryAfter,
+	}
+}
+
 func ParseAndValidateRequest[T any](
 	r *http.Request,
 	data *T,
@@ -88,6 +174,46 @@ func HandleHTTPError(w http.ResponseWriter, err error) {
 			"message": e.Error(),
 		})
 
+	case *ValidationError:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]any{
+			"status":  "error",
+			"message": e.Error(),
+			"errors":  e.Errors,
+		})
+
+	case *UnauthorizedError:
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]any{
+			"message": e.Error(),
+		})
+
+	case *NotFoundError:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]any{
+			"message": e.Error(),
+		})
+
+	case *ForbiddenError:
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]any{
+			"message": e.Error(),
+		})
+
+	case *ConflictError:
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(map[string]any{
+			"message":   e.Error(),
+			"conflicts": e.Conflicts,
+		})
+
+	case *TooManyRequestsError:
+		w.Header().Set("Retry-After", strconv.Itoa(int(e.RetryAfter.Round(time.Second).Seconds())))
+		w.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(w).Encode(map[string]any{
+			"message": e.Error(),
+		})
+
 	case *InternalServerError:
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]any{
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestHandleHTTPError(t *testing.T) {
@@ -32,6 +33,56 @@ func TestHandleHTTPError(t *testing.T) {
 				"message": "processing failed: database error",
 			},
 		},
+		{
+			name:         "unauthorized error",
+			err:          NewUnauthorizedError("invalid refresh token"),
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "invalid refresh token",
+			},
+		},
+		{
+			name:         "not found error",
+			err:          NewNotFoundError("reservation not found"),
+			expectedCode: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "reservation not found",
+			},
+		},
+		{
+			name:         "forbidden error",
+			err:          NewForbiddenError("access denied"),
+			expectedCode: http.StatusForbidden,
+			expectedBody: map[string]any{
+				"message": "access denied",
+			},
+		},
+		{
+			name:         "conflict error",
+			err:          NewConflictError("desk is unavailable", []string{"2025-06-05"}),
+			expectedCode: http.StatusConflict,
+			expectedBody: map[string]any{
+				"message": "desk is unavailable",
+			},
+		},
+		{
+			name: "validation error",
+			err: NewValidationError("password does not meet requirements", []map[string]string{
+				{"field": "password", "tag": "min_length"},
+			}),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": "password does not meet requirements",
+			},
+		},
+		{
+			name:         "too many requests error",
+			err:          NewTooManyRequestsError("too many failed login attempts", time.Minute),
+			expectedCode: http.StatusTooManyRequests,
+			expectedBody: map[string]any{
+				"message": "too many failed login attempts",
+			},
+		},
 		{
 			name:         "default error case",
 			err:          errors.New("unknown error"),
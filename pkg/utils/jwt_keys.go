@@ -0,0 +1,478 @@
+package utils
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	minHMACSecretLength = 32
+)
+
+var (
+	ErrUnknownSigningKey        = errors.New("unknown signing key")
+	ErrUnsupportedSigningMethod = errors.New("unsupported signing algorithm")
+	ErrMissingSigningKeys       = errors.New("JWT_KEYS_DIR or JWT_PRIVATE_KEY must be set for asymmetric algorithms")
+	ErrWeakSecretKey            = errors.New("SECRET_KEY must be set to at least 32 characters for HS256")
+)
+
+type SigningKey struct {
+	Id        string
+	Algorithm string
+	CreatedAt time.Time
+	signKey   any
+	verifyKey any
+}
+
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// KeyManager signs with the newest key and keeps superseded keys around long
+// enough for tokens they signed to expire.
+type KeyManager struct {
+	mu               sync.RWMutex
+	keys             []*SigningKey
+	algorithm        string
+	keysDir          string
+	rotationInterval time.Duration
+}
+
+func NewHMACKey(id string, secret []byte) *SigningKey {
+	return &SigningKey{
+		Id:        id,
+		Algorithm: AlgorithmHS256,
+		CreatedAt: time.Now(),
+		signKey:   secret,
+		verifyKey: secret,
+	}
+}
+
+func GenerateSigningKey(algorithm string) (*SigningKey, error) {
+	id, err := generateKeyId()
+	if err != nil {
+		return nil, err
+	}
+
+	switch algorithm {
+	case AlgorithmRS256:
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		return newAsymmetricKey(id, algorithm, privateKey, &privateKey.PublicKey), nil
+	case AlgorithmEdDSA:
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return newAsymmetricKey(id, algorithm, privateKey, publicKey), nil
+	case AlgorithmHS256:
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		return NewHMACKey(id, secret), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedSigningMethod, algorithm)
+}
+
+func ParsePrivateKeyPEM(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s: no PEM block found", id)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", id, err)
+		}
+	}
+
+	switch privateKey := parsed.(type) {
+	case *rsa.PrivateKey:
+		return newAsymmetricKey(id, AlgorithmRS256, privateKey, &privateKey.PublicKey), nil
+	case ed25519.PrivateKey:
+		return newAsymmetricKey(id, AlgorithmEdDSA, privateKey, privateKey.Public()), nil
+	}
+
+	return nil, fmt.Errorf("key %s: %w", id, ErrUnsupportedSigningMethod)
+}
+
+func NewKeyManager(algorithm string, rotationInterval time.Duration) (*KeyManager, error) {
+	key, err := GenerateSigningKey(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	manager := &KeyManager{algorithm: algorithm, rotationInterval: rotationInterval}
+	manager.AddKey(key)
+
+	return manager, nil
+}
+
+// NewKeyManagerFromDir loads every *.pem in dir and signs with the most
+// recently modified one. Rotating means dropping a new key file into the
+// directory; each rotation tick reloads it, and old files can be removed once
+// tokens they signed have expired.
+func NewKeyManagerFromDir(dir string, rotationInterval time.Duration) (*KeyManager, error) {
+	manager := &KeyManager{keysDir: dir, rotationInterval: rotationInterval}
+	if err := manager.reloadKeys(); err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
+
+// LoadKeyManagerFromEnv prefers JWT_KEYS_DIR, the only source that supports
+// rotation. A key from JWT_PRIVATE_KEY or SECRET_KEY is used until the
+// process is restarted with a different one.
+func LoadKeyManagerFromEnv() (*KeyManager, error) {
+	rotationInterval := time.Duration(GetEnvInt("JWT_KEY_ROTATION_HOURS", 24)) * time.Hour
+
+	if dir := os.Getenv("JWT_KEYS_DIR"); dir != "" {
+		return NewKeyManagerFromDir(dir, rotationInterval)
+	}
+
+	algorithm := os.Getenv("JWT_ALGORITHM")
+	if algorithm == "" || algorithm == AlgorithmHS256 {
+		secret := os.Getenv("SECRET_KEY")
+		if len(secret) < minHMACSecretLength {
+			return nil, ErrWeakSecretKey
+		}
+
+		manager := &KeyManager{algorithm: AlgorithmHS256}
+		manager.AddKey(NewHMACKey("default", []byte(secret)))
+		return manager, nil
+	}
+
+	if algorithm != AlgorithmRS256 && algorithm != AlgorithmEdDSA {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSigningMethod, algorithm)
+	}
+
+	// Keys generated in process would differ between replicas and vanish on
+	// restart, so asymmetric signing needs keys provisioned from outside.
+	privateKey := os.Getenv("JWT_PRIVATE_KEY")
+	if privateKey == "" {
+		return nil, ErrMissingSigningKeys
+	}
+
+	id := os.Getenv("JWT_KEY_ID")
+	if id == "" {
+		id = "default"
+	}
+
+	key, err := ParsePrivateKeyPEM(id, []byte(privateKey))
+	if err != nil {
+		return nil, err
+	}
+	if key.Algorithm != algorithm {
+		return nil, fmt.Errorf("key %s: expected %s key, got %s", id, algorithm, key.Algorithm)
+	}
+
+	manager := &KeyManager{algorithm: algorithm}
+	manager.AddKey(key)
+
+	return manager, nil
+}
+
+func (m *KeyManager) AddKey(key *SigningKey) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keys = append(m.keys, key)
+	m.pruneKeys()
+}
+
+func (m *KeyManager) CurrentKey() *SigningKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.keys) == 0 {
+		return nil
+	}
+
+	return m.keys[len(m.keys)-1]
+}
+
+func (m *KeyManager) Keys() []*SigningKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]*SigningKey(nil), m.keys...)
+}
+
+func (m *KeyManager) Rotate() error {
+	if m.keysDir != "" {
+		return m.reloadKeys()
+	}
+
+	key, err := GenerateSigningKey(m.algorithm)
+	if err != nil {
+		return err
+	}
+
+	m.AddKey(key)
+	GetLogger().Info("Rotated JWT signing key, new kid: %s", key.Id)
+
+	return nil
+}
+
+func (m *KeyManager) StartRotation(ctx context.Context) {
+	if m.rotationInterval <= 0 || m.algorithm == AlgorithmHS256 {
+		return
+	}
+
+	ticker := time.NewTicker(m.rotationInterval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := m.Rotate(); err != nil {
+					GetLogger().Error("Failed to rotate JWT signing key: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (m *KeyManager) Sign(claims jwt.Claims) (string, error) {
+	key := m.CurrentKey()
+	if key == nil {
+		return "", ErrUnknownSigningKey
+	}
+
+	method := jwt.GetSigningMethod(key.Algorithm)
+	if method == nil {
+		return "", ErrUnsupportedSigningMethod
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = key.Id
+
+	return token.SignedString(key.signKey)
+}
+
+func (m *KeyManager) Parse(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		key := m.findKey(kid)
+		if key == nil {
+			return nil, ErrUnknownSigningKey
+		}
+
+		if token.Method.Alg() != key.Algorithm {
+			return nil, ErrUnsupportedSigningMethod
+		}
+
+		return key.verifyKey, nil
+	}, jwt.WithValidMethods(m.algorithms()))
+}
+
+func (m *KeyManager) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+
+	for _, key := range m.Keys() {
+		switch publicKey := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JSONWebKey{
+				Kty: "RSA",
+				Kid: key.Id,
+				Use: "sig",
+				Alg: key.Algorithm,
+				N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JSONWebKey{
+				Kty: "OKP",
+				Kid: key.Id,
+				Use: "sig",
+				Alg: key.Algorithm,
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(publicKey),
+			})
+		}
+	}
+
+	return set
+}
+
+func (m *KeyManager) findKey(id string) *SigningKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, key := range m.keys {
+		if key.Id == id {
+			return key
+		}
+	}
+
+	return nil
+}
+
+func (m *KeyManager) algorithms() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	seen := map[string]bool{}
+	algorithms := []string{}
+
+	for _, key := range m.keys {
+		if !seen[key.Algorithm] {
+			seen[key.Algorithm] = true
+			algorithms = append(algorithms, key.Algorithm)
+		}
+	}
+
+	return algorithms
+}
+
+func (m *KeyManager) pruneKeys() {
+	cutoff := time.Now().Add(-AccessTokenTTL())
+	kept := []*SigningKey{}
+
+	for i, key := range m.keys {
+		if i == len(m.keys)-1 || m.keys[i+1].CreatedAt.After(cutoff) {
+			kept = append(kept, key)
+		}
+	}
+
+	m.keys = kept
+}
+
+func (m *KeyManager) reloadKeys() error {
+	paths, err := filepath.Glob(filepath.Join(m.keysDir, "*.pem"))
+	if err != nil {
+		return err
+	}
+
+	if len(paths) == 0 {
+		return fmt.Errorf("no signing keys found in %s", m.keysDir)
+	}
+
+	keys := make([]*SigningKey, 0, len(paths))
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		id := strings.TrimSuffix(filepath.Base(path), ".pem")
+		key, err := ParsePrivateKeyPEM(id, data)
+		if err != nil {
+			return err
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+
+		key.CreatedAt = info.ModTime()
+		keys = append(keys, key)
+	}
+
+	// File names are free-form kids, so order by age rather than by name.
+	sort.SliceStable(keys, func(i, j int) bool {
+		if keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].Id < keys[j].Id
+		}
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keys = keys
+	m.algorithm = keys[len(keys)-1].Algorithm
+
+	return nil
+}
+
+func newAsymmetricKey(id, algorithm string, signKey crypto.Signer, verifyKey crypto.PublicKey) *SigningKey {
+	return &SigningKey{
+		Id:        id,
+		Algorithm: algorithm,
+		CreatedAt: time.Now(),
+		signKey:   signKey,
+		verifyKey: verifyKey,
+	}
+}
+
+func generateKeyId() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+var (
+	defaultKeyManager    *KeyManager
+	defaultKeyManagerErr error
+	defaultKeyManagerMu  sync.Mutex
+)
+
+func DefaultKeyManager() (*KeyManager, error) {
+	defaultKeyManagerMu.Lock()
+	defer defaultKeyManagerMu.Unlock()
+
+	if defaultKeyManager == nil && defaultKeyManagerErr == nil {
+		defaultKeyManager, defaultKeyManagerErr = LoadKeyManagerFromEnv()
+	}
+
+	return defaultKeyManager, defaultKeyManagerErr
+}
+
+func SetDefaultKeyManager(manager *KeyManager) {
+	defaultKeyManagerMu.Lock()
+	defer defaultKeyManagerMu.Unlock()
+
+	defaultKeyManager = manager
+	defaultKeyManagerErr = nil
+}
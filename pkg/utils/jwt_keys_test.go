@@ -0,0 +1,230 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestKeyManager(t *testing.T) {
+	t.Run("should sign and verify with asymmetric algorithms", func(t *testing.T) {
+		for _, algorithm := range []string{AlgorithmRS256, AlgorithmEdDSA} {
+			manager, err := NewKeyManager(algorithm, 0)
+			if err != nil {
+				t.Fatalf("NewKeyManager(%s) failed: %v", algorithm, err)
+			}
+
+			token, err := manager.Sign(&Claims{UserId: "123"})
+			if err != nil {
+				t.Fatalf("Sign failed: %v", err)
+			}
+
+			claims := &Claims{}
+			parsed, err := manager.Parse(token, claims)
+			if err != nil {
+				t.Fatalf("Parse failed for %s: %v", algorithm, err)
+			}
+			if parsed.Header["kid"] != manager.CurrentKey().Id {
+				t.Errorf("Expected kid %s, got %v", manager.CurrentKey().Id, parsed.Header["kid"])
+			}
+			if claims.UserId != "123" {
+				t.Errorf("Expected userId 123, got %s", claims.UserId)
+			}
+		}
+	})
+
+	t.Run("should keep verifying tokens signed before rotation", func(t *testing.T) {
+		manager, _ := NewKeyManager(AlgorithmEdDSA, 0)
+		oldKey := manager.CurrentKey()
+
+		token, _ := manager.Sign(&Claims{UserId: "123"})
+
+		if err := manager.Rotate(); err != nil {
+			t.Fatalf("Rotate failed: %v", err)
+		}
+		if manager.CurrentKey().Id == oldKey.Id {
+			t.Error("rotation should switch the signing key")
+		}
+
+		if _, err := manager.Parse(token, &Claims{}); err != nil {
+			t.Errorf("token signed with previous key should be valid: %v", err)
+		}
+		if len(manager.JWKS().Keys) != 2 {
+			t.Errorf("Expected 2 published keys, got %d", len(manager.JWKS().Keys))
+		}
+	})
+
+	t.Run("should reject tokens signed with another algorithm", func(t *testing.T) {
+		manager, _ := NewKeyManager(AlgorithmRS256, 0)
+		key := manager.CurrentKey()
+
+		publicKey, _ := x509.MarshalPKIXPublicKey(key.verifyKey)
+		forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{UserId: "123"})
+		forged.Header["kid"] = key.Id
+		token, _ := forged.SignedString(publicKey)
+
+		if _, err := manager.Parse(token, &Claims{}); err == nil {
+			t.Error("expected error for algorithm confusion")
+		}
+	})
+
+	t.Run("should reject unsigned tokens", func(t *testing.T) {
+		manager, _ := NewKeyManager(AlgorithmEdDSA, 0)
+
+		unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, &Claims{UserId: "123"})
+		unsigned.Header["kid"] = manager.CurrentKey().Id
+		token, _ := unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+
+		if _, err := manager.Parse(token, &Claims{}); err == nil {
+			t.Error("expected error for unsigned token")
+		}
+	})
+
+	t.Run("should reject tokens with unknown kid", func(t *testing.T) {
+		manager, _ := NewKeyManager(AlgorithmEdDSA, 0)
+		other, _ := NewKeyManager(AlgorithmEdDSA, 0)
+
+		token, _ := other.Sign(&Claims{UserId: "123"})
+
+		if _, err := manager.Parse(token, &Claims{}); err == nil {
+			t.Error("expected error for unknown kid")
+		}
+	})
+
+	t.Run("should not publish symmetric keys", func(t *testing.T) {
+		manager, _ := NewKeyManager(AlgorithmHS256, 0)
+
+		if len(manager.JWKS().Keys) != 0 {
+			t.Errorf("Expected no published keys, got %d", len(manager.JWKS().Keys))
+		}
+	})
+}
+
+func TestNewKeyManagerFromDir(t *testing.T) {
+	t.Run("should load keys and sign with the last one", func(t *testing.T) {
+		dir := t.TempDir()
+
+		for _, id := range []string{"2025-06-01", "2025-06-20"} {
+			_, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+			der, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+			data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+			if err := os.WriteFile(filepath.Join(dir, id+".pem"), data, 0o600); err != nil {
+				t.Fatalf("failed to write key: %v", err)
+			}
+		}
+
+		manager, err := NewKeyManagerFromDir(dir, 0)
+		if err != nil {
+			t.Fatalf("NewKeyManagerFromDir failed: %v", err)
+		}
+
+		if manager.CurrentKey().Id != "2025-06-20" {
+			t.Errorf("Expected kid 2025-06-20, got %s", manager.CurrentKey().Id)
+		}
+		if manager.CurrentKey().Algorithm != AlgorithmEdDSA {
+			t.Errorf("Expected algorithm EdDSA, got %s", manager.CurrentKey().Algorithm)
+		}
+	})
+
+	t.Run("should sign with the newest key whatever its name", func(t *testing.T) {
+		dir := t.TempDir()
+		created := time.Now().Add(-time.Hour)
+
+		for _, id := range []string{"zz-old", "aa-new"} {
+			_, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+			der, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+			data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+			path := filepath.Join(dir, id+".pem")
+
+			if err := os.WriteFile(path, data, 0o600); err != nil {
+				t.Fatalf("failed to write key: %v", err)
+			}
+			if err := os.Chtimes(path, created, created); err != nil {
+				t.Fatalf("failed to set key time: %v", err)
+			}
+			created = created.Add(30 * time.Minute)
+		}
+
+		manager, err := NewKeyManagerFromDir(dir, 0)
+		if err != nil {
+			t.Fatalf("NewKeyManagerFromDir failed: %v", err)
+		}
+
+		if manager.CurrentKey().Id != "aa-new" {
+			t.Errorf("Expected kid aa-new, got %s", manager.CurrentKey().Id)
+		}
+	})
+
+	t.Run("should fail when directory has no keys", func(t *testing.T) {
+		if _, err := NewKeyManagerFromDir(t.TempDir(), 0); err == nil {
+			t.Error("expected error for empty key directory")
+		}
+	})
+}
+
+func TestLoadKeyManagerFromEnv(t *testing.T) {
+	t.Run("should refuse an empty or short HS256 secret", func(t *testing.T) {
+		for _, secret := range []string{"", "supersecretkey"} {
+			t.Setenv("JWT_ALGORITHM", AlgorithmHS256)
+			t.Setenv("JWT_KEYS_DIR", "")
+			t.Setenv("SECRET_KEY", secret)
+
+			if _, err := LoadKeyManagerFromEnv(); !errors.Is(err, ErrWeakSecretKey) {
+				t.Errorf("expected ErrWeakSecretKey for %q, got %v", secret, err)
+			}
+		}
+	})
+
+	t.Run("should refuse asymmetric algorithms without provisioned keys", func(t *testing.T) {
+		for _, algorithm := range []string{AlgorithmRS256, AlgorithmEdDSA} {
+			t.Setenv("JWT_ALGORITHM", algorithm)
+			t.Setenv("JWT_KEYS_DIR", "")
+			t.Setenv("JWT_PRIVATE_KEY", "")
+
+			if _, err := LoadKeyManagerFromEnv(); !errors.Is(err, ErrMissingSigningKeys) {
+				t.Errorf("expected ErrMissingSigningKeys for %s, got %v", algorithm, err)
+			}
+		}
+	})
+
+	t.Run("should load the private key from the environment", func(t *testing.T) {
+		_, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+		der, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+
+		t.Setenv("JWT_ALGORITHM", AlgorithmEdDSA)
+		t.Setenv("JWT_KEYS_DIR", "")
+		t.Setenv("JWT_KEY_ID", "2025-07-01")
+		t.Setenv("JWT_PRIVATE_KEY", string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+
+		manager, err := LoadKeyManagerFromEnv()
+		if err != nil {
+			t.Fatalf("LoadKeyManagerFromEnv failed: %v", err)
+		}
+
+		if manager.CurrentKey().Id != "2025-07-01" {
+			t.Errorf("Expected kid 2025-07-01, got %s", manager.CurrentKey().Id)
+		}
+	})
+
+	t.Run("should reject a key that does not match the algorithm", func(t *testing.T) {
+		_, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+		der, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+
+		t.Setenv("JWT_ALGORITHM", AlgorithmRS256)
+		t.Setenv("JWT_KEYS_DIR", "")
+		t.Setenv("JWT_PRIVATE_KEY", string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+
+		if _, err := LoadKeyManagerFromEnv(); err == nil {
+			t.Error("expected error for mismatched key")
+		}
+	})
+}
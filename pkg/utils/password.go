@@ -2,7 +2,6 @@ package utils
 
 import (
 	"net/http"
-	"os"
 	"strings"
 	"time"
 
@@ -10,9 +9,17 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	MFAChallengePurpose = "mfa_challenge"
+	MFAChallengeTTL     = 5 * time.Minute
+)
+
 type Claims struct {
-	UserId string `json:"user_id"`
-	Email  string `json:"email"`
+	UserId   string `json:"user_id"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	TenantId string `json:"tenant_id,omitempty"`
+	Purpose  string `json:"purpose,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -29,22 +36,35 @@ func CheckPasswordHash(password, hash string) bool {
 	return err == nil
 }
 
-func GenerateJWT(userId, email string) (string, error) {
-	secretKey := []byte(os.Getenv("SECRET_KEY"))
+func AccessTokenTTL() time.Duration {
+	return time.Duration(GetEnvInt("ACCESS_TOKEN_TTL_MINUTES", 15)) * time.Minute
+}
+
+func GenerateJWT(userId, email, role, tenantId string) (string, error) {
+	keyManager, err := DefaultKeyManager()
+	if err != nil {
+		return "", err
+	}
+
+	tokenId, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
 
 	claims := Claims{
-		UserId: userId,
-		Email:  email,
+		UserId:   userId,
+		Email:    email,
+		Role:     role,
+		TenantId: tenantId,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ID:        tokenId,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL())),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	tokenString, err := token.SignedString(secretKey)
+	tokenString, err := keyManager.Sign(claims)
 	if err != nil {
 		return "", err
 	}
@@ -53,17 +73,60 @@ func GenerateJWT(userId, email string) (string, error) {
 }
 
 func ValidateToken(tokenString string) (*Claims, error) {
-	secretKey := []byte(os.Getenv("SECRET_KEY"))
+	keyManager, err := DefaultKeyManager()
+	if err != nil {
+		return nil, err
+	}
 
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (any, error) {
-		return secretKey, nil
-	})
+	token, err := keyManager.Parse(tokenString, &Claims{})
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(*Claims); ok && token.Valid && claims.Purpose == "" {
+		return claims, nil
+	}
+
+	return nil, jwt.ErrSignatureInvalid
+}
+
+func GenerateMFAChallenge(userId string) (string, error) {
+	keyManager, err := DefaultKeyManager()
+	if err != nil {
+		return "", err
+	}
+
+	tokenId, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	claims := Claims{
+		UserId:  userId,
+		Purpose: MFAChallengePurpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenId,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(MFAChallengeTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return keyManager.Sign(claims)
+}
+
+func ValidateMFAChallenge(tokenString string) (*Claims, error) {
+	keyManager, err := DefaultKeyManager()
+	if err != nil {
+		return nil, err
+	}
 
+	token, err := keyManager.Parse(tokenString, &Claims{})
 	if err != nil {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+	if claims, ok := token.Claims.(*Claims); ok && token.Valid && claims.Purpose == MFAChallengePurpose {
 		return claims, nil
 	}
 
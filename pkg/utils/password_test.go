@@ -52,14 +52,16 @@ func TestCheckPasswordHash(t *testing.T) {
 
 func TestGenerateJWT(t *testing.T) {
 	originalSecretKey := os.Getenv("SECRET_KEY")
-	os.Setenv("SECRET_KEY", "test-secret-key")
+	os.Setenv("SECRET_KEY", "test-secret-key-0123456789abcdef")
 	defer os.Setenv("SECRET_KEY", originalSecretKey)
 
 	t.Run("should generate valid JWT", func(t *testing.T) {
 		userId := "123"
 		email := "test@example.com"
+		role := "admin"
+		tenantId := "00000000-0000-0000-0000-000000000001"
 
-		token, err := GenerateJWT(userId, email)
+		token, err := GenerateJWT(userId, email, role, tenantId)
 
 		if err != nil {
 			t.Errorf("GenerateJWT failed: %v", err)
@@ -78,16 +80,25 @@ func TestGenerateJWT(t *testing.T) {
 		if claims.Email != email {
 			t.Errorf("Expected email %s, got %s", email, claims.Email)
 		}
+		if claims.Role != role {
+			t.Errorf("Expected role %s, got %s", role, claims.Role)
+		}
+		if claims.TenantId != tenantId {
+			t.Errorf("Expected tenantId %s, got %s", tenantId, claims.TenantId)
+		}
+		if claims.ID == "" {
+			t.Error("token should carry a jti claim")
+		}
 	})
 }
 
 func TestValidateToken(t *testing.T) {
 	originalSecretKey := os.Getenv("SECRET_KEY")
-	os.Setenv("SECRET_KEY", "test-secret-key")
+	os.Setenv("SECRET_KEY", "test-secret-key-0123456789abcdef")
 	defer os.Setenv("SECRET_KEY", originalSecretKey)
 
 	t.Run("should validate correct token", func(t *testing.T) {
-		token, _ := GenerateJWT("123", "test@example.com")
+		token, _ := GenerateJWT("123", "test@example.com", "user", "tenant-1")
 
 		claims, err := ValidateToken(token)
 
@@ -115,11 +126,11 @@ func TestValidateToken(t *testing.T) {
 
 func TestExtractToken(t *testing.T) {
 	originalSecretKey := os.Getenv("SECRET_KEY")
-	os.Setenv("SECRET_KEY", "test-secret-key")
+	os.Setenv("SECRET_KEY", "test-secret-key-0123456789abcdef")
 	defer os.Setenv("SECRET_KEY", originalSecretKey)
 
 	t.Run("should extract valid token from header", func(t *testing.T) {
-		token, _ := GenerateJWT("123", "test@example.com")
+		token, _ := GenerateJWT("123", "test@example.com", "user", "tenant-1")
 		req := httptest.NewRequest("GET", "/", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
 		w := httptest.NewRecorder()
@@ -167,3 +178,36 @@ func TestExtractToken(t *testing.T) {
 		}
 	})
 }
+
+func TestMFAChallenge(t *testing.T) {
+	originalSecretKey := os.Getenv("SECRET_KEY")
+	os.Setenv("SECRET_KEY", "test-secret-key-0123456789abcdef")
+	defer os.Setenv("SECRET_KEY", originalSecretKey)
+
+	t.Run("should not be accepted as access token", func(t *testing.T) {
+		challenge, err := GenerateMFAChallenge("123")
+		if err != nil {
+			t.Fatalf("GenerateMFAChallenge failed: %v", err)
+		}
+
+		if _, err := ValidateToken(challenge); err == nil {
+			t.Error("challenge token should not validate as access token")
+		}
+
+		claims, err := ValidateMFAChallenge(challenge)
+		if err != nil {
+			t.Fatalf("ValidateMFAChallenge failed: %v", err)
+		}
+		if claims.UserId != "123" {
+			t.Errorf("Expected userId 123, got %s", claims.UserId)
+		}
+	})
+
+	t.Run("should not accept access token as challenge", func(t *testing.T) {
+		token, _ := GenerateJWT("123", "test@example.com", "user", "tenant-1")
+
+		if _, err := ValidateMFAChallenge(token); err == nil {
+			t.Error("access token should not validate as challenge")
+		}
+	})
+}
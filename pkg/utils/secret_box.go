@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+)
+
+const minSecretEncryptionKeyLength = 32
+
+var errSecretEncryptionKey = errors.New("SECRET_ENCRYPTION_KEY must be set to at least 32 characters")
+
+// ValidateSecretEncryptionKey reports whether the key used to seal stored
+// secrets is configured, so a missing key stops the server at startup.
+func ValidateSecretEncryptionKey() error {
+	if len(os.Getenv("SECRET_ENCRYPTION_KEY")) < minSecretEncryptionKeyLength {
+		return errSecretEncryptionKey
+	}
+
+	return nil
+}
+
+func EncryptSecret(plaintext string) (string, error) {
+	aead, err := secretCipher()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func DecryptSecret(ciphertext string) (string, error) {
+	aead, err := secretCipher()
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("ciphertext too short")
+	}
+
+	nonce, data := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, data, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+func secretCipher() (cipher.AEAD, error) {
+	if err := ValidateSecretEncryptionKey(); err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256([]byte(os.Getenv("SECRET_ENCRYPTION_KEY")))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestEncryptSecret(t *testing.T) {
+	t.Setenv("SECRET_ENCRYPTION_KEY", "test-encryption-key-0123456789abcdef")
+
+	t.Run("should round trip secrets", func(t *testing.T) {
+		ciphertext, err := EncryptSecret("JBSWY3DPEHPK3PXP")
+		if err != nil {
+			t.Fatalf("EncryptSecret failed: %v", err)
+		}
+		if ciphertext == "JBSWY3DPEHPK3PXP" {
+			t.Error("ciphertext should differ from plaintext")
+		}
+
+		plaintext, err := DecryptSecret(ciphertext)
+		if err != nil {
+			t.Fatalf("DecryptSecret failed: %v", err)
+		}
+		if plaintext != "JBSWY3DPEHPK3PXP" {
+			t.Errorf("expected original secret, got %s", plaintext)
+		}
+	})
+
+	t.Run("should reject tampered ciphertext", func(t *testing.T) {
+		ciphertext, _ := EncryptSecret("JBSWY3DPEHPK3PXP")
+		sealed, _ := base64.StdEncoding.DecodeString(ciphertext)
+		sealed[len(sealed)-1] ^= 1
+
+		if _, err := DecryptSecret(base64.StdEncoding.EncodeToString(sealed)); err == nil {
+			t.Error("expected error for tampered ciphertext")
+		}
+	})
+}
+
+func TestValidateSecretEncryptionKey(t *testing.T) {
+	t.Run("should reject a missing key", func(t *testing.T) {
+		t.Setenv("SECRET_ENCRYPTION_KEY", "")
+		t.Setenv("SECRET_KEY", "test-secret-key-0123456789abcdef")
+
+		if err := ValidateSecretEncryptionKey(); err == nil {
+			t.Error("expected error for missing key")
+		}
+		if _, err := EncryptSecret("JBSWY3DPEHPK3PXP"); err == nil {
+			t.Error("expected EncryptSecret to refuse without a key")
+		}
+	})
+
+	t.Run("should reject a short key", func(t *testing.T) {
+		t.Setenv("SECRET_ENCRYPTION_KEY", "short")
+
+		if err := ValidateSecretEncryptionKey(); err == nil {
+			t.Error("expected error for short key")
+		}
+	})
+
+	t.Run("should accept a long enough key", func(t *testing.T) {
+		t.Setenv("SECRET_ENCRYPTION_KEY", "test-encryption-key-0123456789abcdef")
+
+		if err := ValidateSecretEncryptionKey(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestGenerateOpaqueToken(t *testing.T) {
+	t.Run("should generate distinct tokens", func(t *testing.T) {
+		first, err := GenerateOpaqueToken()
+		if err != nil {
+			t.Errorf("GenerateOpaqueToken failed: %v", err)
+		}
+
+		second, _ := GenerateOpaqueToken()
+
+		if first == "" {
+			t.Error("token should not be empty")
+		}
+		if first == second {
+			t.Error("tokens should be unique")
+		}
+	})
+}
+
+func TestHashToken(t *testing.T) {
+	t.Run("should hash tokens deterministically", func(t *testing.T) {
+		hash := HashToken("token")
+
+		if hash != HashToken("token") {
+			t.Error("hash should be deterministic")
+		}
+		if hash == "token" {
+			t.Error("hash should not be equal to original token")
+		}
+		if hash == HashToken("other") {
+			t.Error("different tokens should have different hashes")
+		}
+	})
+}
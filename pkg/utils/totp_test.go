@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 6238 SHA1 test vectors, truncated to six digits.
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := TOTPCode(secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("TOTPCode failed: %v", err)
+		}
+		if code != tt.code {
+			t.Errorf("at %d expected %s, got %s", tt.unix, tt.code, code)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, _ := GenerateTOTPSecret()
+	now := time.Now()
+
+	t.Run("should accept current and adjacent codes", func(t *testing.T) {
+		for _, offset := range []time.Duration{-30 * time.Second, 0, 30 * time.Second} {
+			code, _ := TOTPCode(secret, TOTPStep(now.Add(offset)))
+
+			step, ok := ValidateTOTP(secret, code, now)
+			if !ok {
+				t.Errorf("code at offset %s should be valid", offset)
+			}
+			if step != TOTPStep(now.Add(offset)) {
+				t.Errorf("expected step %d, got %d", TOTPStep(now.Add(offset)), step)
+			}
+		}
+	})
+
+	t.Run("should reject old codes", func(t *testing.T) {
+		code, _ := TOTPCode(secret, TOTPStep(now.Add(-2*time.Minute)))
+
+		if _, ok := ValidateTOTP(secret, code, now); ok {
+			t.Error("old code should be rejected")
+		}
+	})
+
+	t.Run("should reject malformed codes", func(t *testing.T) {
+		if _, ok := ValidateTOTP(secret, "12345", now); ok {
+			t.Error("short code should be rejected")
+		}
+	})
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("Desk Reservation", "jane@example.com", "SECRET")
+
+	if !strings.HasPrefix(uri, "otpauth://totp/Desk%20Reservation:jane@example.com?") {
+		t.Errorf("unexpected URI: %s", uri)
+	}
+	if !strings.Contains(uri, "secret=SECRET") || !strings.Contains(uri, "issuer=Desk+Reservation") {
+		t.Errorf("URI should carry secret and issuer: %s", uri)
+	}
+}